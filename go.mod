@@ -4,6 +4,7 @@ go 1.23.0
 
 require (
 	github.com/charmbracelet/log v0.4.0
+	github.com/google/jsonschema-go v0.3.0
 	github.com/modelcontextprotocol/go-sdk v1.2.0
 	github.com/patrickmn/go-cache v2.1.0+incompatible
 )
@@ -14,7 +15,6 @@ require (
 	github.com/charmbracelet/lipgloss v0.13.1 // indirect
 	github.com/charmbracelet/x/ansi v0.3.2 // indirect
 	github.com/go-logfmt/logfmt v0.6.0 // indirect
-	github.com/google/jsonschema-go v0.3.0 // indirect
 	github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
 	github.com/mattn/go-runewidth v0.0.15 // indirect
@@ -33,6 +33,19 @@ const (
 	// ItemDetailKey is the cache key template for individual item details
 	ItemDetailKey Key = "item:detail:%d" // %d = item ID
 
+	// ItemIDsKey is the cache key for the list of every item ID
+	ItemIDsKey Key = "item:ids"
+
+	// SkinSourcesKey is the cache key template for the skin-to-item sources derived from
+	// the item index of a game build
+	SkinSourcesKey Key = "skin:sources:%d" // %d = game build ID
+
+	// ItemIndexKey is the cache key template for the item index of a game build
+	ItemIndexKey Key = "item:index:%d" // %d = game build ID
+	// ItemIndexLatestKey is the cache key for the most recently built item index,
+	// which the index of the next game build is updated from
+	ItemIndexLatestKey Key = "item:index:latest"
+
 	// Trading Post cache keys
 	TPPriceKey       Key = "tp:price:%d"          // %d = item ID
 	TPListingKey     Key = "tp:listing:%d"         // %d = item ID
@@ -47,9 +60,13 @@ const (
 	SharedInventoryKey Key = "inventory:%s"          // %s = hashed API key
 	CharactersKey      Key = "characters:%s"         // %s = hashed API key
 	CharacterKey       Key = "character:%s:%s"       // %s = hashed API key, %s = name
+	CharacterInventoryKey Key = "character:inventory:%s:%s" // %s = hashed API key, %s = name
+	CharacterEquipmentKey Key = "character:equipment:%s:%s" // %s = hashed API key, %s = name
+	CharacterHeroPointsKey Key = "character:heropoints:%s:%s" // %s = hashed API key, %s = name
 	UnlocksKey         Key = "unlocks:%s:%s"         // %s = hashed API key, %s = type
 	ProgressKey        Key = "progress:%s:%s"        // %s = hashed API key, %s = type
 	DailiesKey         Key = "dailies:%s:%s"         // %s = hashed API key, %s = type
+	DailyCatalogKey    Key = "dailies:catalog:%s"    // %s = type
 
 	// Wizard's Vault cache keys
 	WizardsVaultSeasonKey     Key = "wv:season"           // no params
@@ -58,23 +75,43 @@ const (
 
 	// Game data cache keys
 	SkinDetailKey      Key = "skin:detail:%d"       // %d = skin ID
+	SkinIDsKey         Key = "skin:ids"
 	RecipeDetailKey    Key = "recipe:detail:%d"      // %d = recipe ID
 	RecipeSearchKey    Key = "recipe:search:%s:%d"   // %s = direction (input/output), %d = item ID
+	MysticForgeRecipesKey Key = "recipe:forge:%d"  // %d = output item ID
 	AchievementKey     Key = "achievement:detail:%d" // %d = achievement ID
 	DailyAchievementKey Key = "achievements:daily"
+	AchievementCategoriesKey Key = "achievements:categories"
+	AchievementGroupsKey     Key = "achievements:groups"
+	AchievementCategoryKey   Key = "achievements:category:%d" // %d = category ID
+	MasteriesKey             Key = "masteries:all"
+	LegendaryArmoryKey Key = "legendaryarmory:list"
 
 	// Guild cache keys
 	GuildInfoKey    Key = "guild:info:%s"       // %s = guild ID
 	GuildSearchKey  Key = "guild:search:%s"     // %s = guild name
 	GuildDetailKey  Key = "guild:detail:%s:%s"  // %s = guild ID, %s = type
+	GuildUpgradesKey    Key = "guild:upgrades:all"
+	GuildPermissionsKey Key = "guild:permissions:all"
+	GuildLogKey         Key = "guild:log:%s" // %s = guild ID
 
 	// Metadata cache keys
 	ColorDetailKey     Key = "color:detail:%d"      // %d = color ID
+	ColorIDsKey        Key = "color:ids"
 	MiniDetailKey      Key = "mini:detail:%d"       // %d = mini ID
 	MountDetailKey     Key = "mount:%s:detail:%d"   // %s = type (skins/types), %d = mount ID
+	MountTypesKey      Key = "mount:types:all"
+	NamesKey           Key = "names:%s" // %s = endpoint, e.g. titles or mounts/skins
 	GameBuildKey       Key = "game:build"
 	TokenInfoKey       Key = "tokeninfo:%s"         // %s = hashed API key
 	DungeonDetailKey   Key = "dungeon:detail:%s"    // %s = dungeon/raid ID
+	DungeonListKey     Key = "dungeon:list:%s"      // %s = type (dungeons/raids)
+
+	// Map cache keys
+	ContinentsKey     Key = "continents:list"
+	ContinentFloorKey Key = "continent:floor:%d:%d"       // %d = continent ID, %d = floor ID
+	ContinentMapKey   Key = "continent:map:%d:%d:%d:%d"   // %d = continent ID, %d = floor ID, %d = region ID, %d = map ID
+	MapDetailKey      Key = "map:detail:%d"               // %d = map ID
 )
 
 // Cache durations
@@ -89,6 +126,9 @@ const (
 	// Item metadata - semi-static
 	ItemDataTTL = 24 * time.Hour // 1 day for item metadata
 
+	// Indexes built from every item - keyed by game build, so they only need rebuilding on patches
+	ItemIndexTTL = 7 * 24 * time.Hour
+
 	// Trading Post data - dynamic
 	TPPriceTTL       = 5 * time.Minute  // Prices change frequently
 	TPListingTTL     = 5 * time.Minute  // Listings change frequently
@@ -101,6 +141,7 @@ const (
 	UnlocksTTL     = 10 * time.Minute
 	ProgressTTL    = 5 * time.Minute
 	DailiesTTL     = 2 * time.Minute
+	DailyCatalogTTL = 24 * time.Hour
 
 	// Wizard's Vault
 	WVSeasonTTL          = 24 * time.Hour
@@ -112,20 +153,27 @@ const (
 	RecipeDataTTL         = 24 * time.Hour
 	AchievementDataTTL    = 24 * time.Hour
 	DailyAchievementTTL   = 1 * time.Hour
+	MasteryDataTTL        = 24 * time.Hour
 
 	// Guild
 	GuildInfoTTL   = 1 * time.Hour
 	GuildSearchTTL = 1 * time.Hour
 	GuildDetailTTL = 5 * time.Minute
+	GuildUpgradeDataTTL = 24 * time.Hour
+	GuildLogTTL         = 30 * 24 * time.Hour
 
 	// Metadata
 	ColorDataTTL    = 24 * time.Hour
 	MiniDataTTL     = 24 * time.Hour
 	MountDataTTL    = 24 * time.Hour
+	NameDataTTL     = 24 * time.Hour
 	GameBuildTTL    = 1 * time.Hour
 	TokenInfoTTL    = 10 * time.Minute
 	DungeonDataTTL  = 24 * time.Hour
 
+	// Maps
+	MapDataTTL = 24 * time.Hour
+
 	// Default cleanup interval
 	CleanupInterval = 10 * time.Minute
 )
@@ -224,6 +272,26 @@ func (m *Manager) GetItemDetailKey(id int) string {
 	return fmt.Sprintf(string(ItemDetailKey), id)
 }
 
+// GetItemIDsKey returns the cache key for the list of every item ID
+func (m *Manager) GetItemIDsKey() string {
+	return string(ItemIDsKey)
+}
+
+// GetSkinSourcesKey returns the cache key for the skin-to-item sources of a game build
+func (m *Manager) GetSkinSourcesKey(build int) string {
+	return fmt.Sprintf(string(SkinSourcesKey), build)
+}
+
+// GetItemIndexKey returns the cache key for the item index of a game build
+func (m *Manager) GetItemIndexKey(build int) string {
+	return fmt.Sprintf(string(ItemIndexKey), build)
+}
+
+// GetItemIndexLatestKey returns the cache key for the most recently built item index
+func (m *Manager) GetItemIndexLatestKey() string {
+	return string(ItemIndexLatestKey)
+}
+
 // GetTPPriceKey returns the cache key for TP price data
 func (m *Manager) GetTPPriceKey(itemID int) string {
 	return fmt.Sprintf(string(TPPriceKey), itemID)
@@ -279,6 +347,21 @@ func (m *Manager) GetCharacterKey(apiKeyHash string, name string) string {
 	return fmt.Sprintf(string(CharacterKey), apiKeyHash, name)
 }
 
+// GetCharacterInventoryKey returns the cache key for a character's bags
+func (m *Manager) GetCharacterInventoryKey(apiKeyHash string, name string) string {
+	return fmt.Sprintf(string(CharacterInventoryKey), apiKeyHash, name)
+}
+
+// GetCharacterEquipmentKey returns the cache key for a character's equipped items
+func (m *Manager) GetCharacterEquipmentKey(apiKeyHash string, name string) string {
+	return fmt.Sprintf(string(CharacterEquipmentKey), apiKeyHash, name)
+}
+
+// GetCharacterHeroPointsKey returns the cache key for a character's completed hero challenges
+func (m *Manager) GetCharacterHeroPointsKey(apiKeyHash string, name string) string {
+	return fmt.Sprintf(string(CharacterHeroPointsKey), apiKeyHash, name)
+}
+
 // GetUnlocksKey returns the cache key for account unlocks
 func (m *Manager) GetUnlocksKey(apiKeyHash string, unlockType string) string {
 	return fmt.Sprintf(string(UnlocksKey), apiKeyHash, unlockType)
@@ -294,6 +377,11 @@ func (m *Manager) GetDailiesKey(apiKeyHash string, dailyType string) string {
 	return fmt.Sprintf(string(DailiesKey), apiKeyHash, dailyType)
 }
 
+// GetDailyCatalogKey returns the cache key for the list of every possible daily entry
+func (m *Manager) GetDailyCatalogKey(dailyType string) string {
+	return fmt.Sprintf(string(DailyCatalogKey), dailyType)
+}
+
 // GetWizardsVaultSeasonKey returns the cache key for wizard's vault season info
 func (m *Manager) GetWizardsVaultSeasonKey() string {
 	return string(WizardsVaultSeasonKey)
@@ -309,6 +397,11 @@ func (m *Manager) GetWizardsVaultListingsKey(apiKeyHash string) string {
 	return fmt.Sprintf(string(WizardsVaultListingsKey), apiKeyHash)
 }
 
+// GetSkinIDsKey returns the cache key for the list of every skin ID
+func (m *Manager) GetSkinIDsKey() string {
+	return string(SkinIDsKey)
+}
+
 // GetSkinDetailKey returns the cache key for skin metadata
 func (m *Manager) GetSkinDetailKey(id int) string {
 	return fmt.Sprintf(string(SkinDetailKey), id)
@@ -319,6 +412,12 @@ func (m *Manager) GetRecipeDetailKey(id int) string {
 	return fmt.Sprintf(string(RecipeDetailKey), id)
 }
 
+// GetMysticForgeRecipesKey returns the cache key for the wiki's Mystic Forge recipes
+// producing an item
+func (m *Manager) GetMysticForgeRecipesKey(itemID int) string {
+	return fmt.Sprintf(string(MysticForgeRecipesKey), itemID)
+}
+
 // GetRecipeSearchKey returns the cache key for recipe search
 func (m *Manager) GetRecipeSearchKey(direction string, itemID int) string {
 	return fmt.Sprintf(string(RecipeSearchKey), direction, itemID)
@@ -334,6 +433,21 @@ func (m *Manager) GetDailyAchievementKey() string {
 	return string(DailyAchievementKey)
 }
 
+// GetAchievementCategoriesKey returns the cache key for the achievement category list
+func (m *Manager) GetAchievementCategoriesKey() string {
+	return string(AchievementCategoriesKey)
+}
+
+// GetAchievementGroupsKey returns the cache key for the achievement group list
+func (m *Manager) GetAchievementGroupsKey() string {
+	return string(AchievementGroupsKey)
+}
+
+// GetLegendaryArmoryKey returns the cache key for the legendary armory item list
+func (m *Manager) GetLegendaryArmoryKey() string {
+	return string(LegendaryArmoryKey)
+}
+
 // GetGuildInfoKey returns the cache key for guild info
 func (m *Manager) GetGuildInfoKey(guildID string) string {
 	return fmt.Sprintf(string(GuildInfoKey), guildID)
@@ -349,6 +463,26 @@ func (m *Manager) GetGuildDetailKey(guildID string, detailType string) string {
 	return fmt.Sprintf(string(GuildDetailKey), guildID, detailType)
 }
 
+// GetGuildUpgradesKey returns the cache key for every guild upgrade definition
+func (m *Manager) GetGuildUpgradesKey() string {
+	return string(GuildUpgradesKey)
+}
+
+// GetGuildLogKey returns the cache key for the log history collected for a guild
+func (m *Manager) GetGuildLogKey(guildID string) string {
+	return fmt.Sprintf(string(GuildLogKey), guildID)
+}
+
+// GetGuildPermissionsKey returns the cache key for every guild rank permission
+func (m *Manager) GetGuildPermissionsKey() string {
+	return string(GuildPermissionsKey)
+}
+
+// GetColorIDsKey returns the cache key for the list of every color ID
+func (m *Manager) GetColorIDsKey() string {
+	return string(ColorIDsKey)
+}
+
 // GetColorDetailKey returns the cache key for color metadata
 func (m *Manager) GetColorDetailKey(id int) string {
 	return fmt.Sprintf(string(ColorDetailKey), id)
@@ -364,6 +498,16 @@ func (m *Manager) GetMountDetailKey(mountType string, id int) string {
 	return fmt.Sprintf(string(MountDetailKey), mountType, id)
 }
 
+// GetMountTypesKey returns the cache key for every mount type
+func (m *Manager) GetMountTypesKey() string {
+	return string(MountTypesKey)
+}
+
+// GetNamesKey returns the cache key for the ID to name map of a metadata endpoint
+func (m *Manager) GetNamesKey(endpoint string) string {
+	return fmt.Sprintf(string(NamesKey), endpoint)
+}
+
 // GetGameBuildKey returns the cache key for game build number
 func (m *Manager) GetGameBuildKey() string {
 	return string(GameBuildKey)
@@ -378,3 +522,38 @@ func (m *Manager) GetTokenInfoKey(apiKeyHash string) string {
 func (m *Manager) GetDungeonDetailKey(id string) string {
 	return fmt.Sprintf(string(DungeonDetailKey), id)
 }
+
+// GetAchievementCategoryKey returns the cache key for a single achievement category
+func (m *Manager) GetAchievementCategoryKey(id int) string {
+	return fmt.Sprintf(string(AchievementCategoryKey), id)
+}
+
+// GetMasteriesKey returns the cache key for the mastery list
+func (m *Manager) GetMasteriesKey() string {
+	return string(MasteriesKey)
+}
+
+// GetDungeonListKey returns the cache key for every dungeon or raid with its paths or wings
+func (m *Manager) GetDungeonListKey(contentType string) string {
+	return fmt.Sprintf(string(DungeonListKey), contentType)
+}
+
+// GetContinentsKey returns the cache key for the continent list
+func (m *Manager) GetContinentsKey() string {
+	return string(ContinentsKey)
+}
+
+// GetContinentFloorKey returns the cache key for a continent floor
+func (m *Manager) GetContinentFloorKey(continentID, floorID int) string {
+	return fmt.Sprintf(string(ContinentFloorKey), continentID, floorID)
+}
+
+// GetContinentMapKey returns the cache key for a map's continent data
+func (m *Manager) GetContinentMapKey(continentID, floorID, regionID, mapID int) string {
+	return fmt.Sprintf(string(ContinentMapKey), continentID, floorID, regionID, mapID)
+}
+
+// GetMapDetailKey returns the cache key for map metadata
+func (m *Manager) GetMapDetailKey(id int) string {
+	return fmt.Sprintf(string(MapDetailKey), id)
+}
@@ -170,6 +170,34 @@ func TestManager_CacheKeys(t *testing.T) {
 		t.Errorf("Expected %s, got %s", expected, key)
 	}
 
+	// Test item IDs key
+	key = m.GetItemIDsKey()
+	expected = "item:ids"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
+
+	// Test skin sources key
+	key = m.GetSkinSourcesKey(171234)
+	expected = "skin:sources:171234"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
+
+	// Test item names key
+	key = m.GetItemIndexKey(171234)
+	expected = "item:index:171234"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
+
+	// Test latest item names key
+	key = m.GetItemIndexLatestKey()
+	expected = "item:index:latest"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
+
 	// Test TP price key
 	key = m.GetTPPriceKey(19976)
 	expected = "tp:price:19976"
@@ -247,6 +275,20 @@ func TestManager_CacheKeys(t *testing.T) {
 		t.Errorf("Expected %s, got %s", expected, key)
 	}
 
+	// Test character inventory key
+	key = m.GetCharacterInventoryKey("abcd1234", "My Character")
+	expected = "character:inventory:abcd1234:My Character"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
+
+	// Test character equipment key
+	key = m.GetCharacterEquipmentKey("abcd1234", "My Character")
+	expected = "character:equipment:abcd1234:My Character"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
+
 	// Test unlocks key
 	key = m.GetUnlocksKey("abcd1234", "skins")
 	expected = "unlocks:abcd1234:skins"
@@ -296,6 +338,13 @@ func TestManager_CacheKeys(t *testing.T) {
 		t.Errorf("Expected %s, got %s", expected, key)
 	}
 
+	// Test skin IDs key
+	key = m.GetSkinIDsKey()
+	expected = "skin:ids"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
+
 	// Test recipe detail key
 	key = m.GetRecipeDetailKey(200)
 	expected = "recipe:detail:200"
@@ -324,6 +373,41 @@ func TestManager_CacheKeys(t *testing.T) {
 		t.Errorf("Expected %s, got %s", expected, key)
 	}
 
+	// Test achievement categories key
+	key = m.GetAchievementCategoriesKey()
+	expected = "achievements:categories"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
+
+	// Test achievement groups key
+	key = m.GetAchievementGroupsKey()
+	expected = "achievements:groups"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
+
+	// Test achievement category key
+	key = m.GetAchievementCategoryKey(88)
+	expected = "achievements:category:88"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
+
+	// Test masteries key
+	key = m.GetMasteriesKey()
+	expected = "masteries:all"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
+
+	// Test legendary armory key
+	key = m.GetLegendaryArmoryKey()
+	expected = "legendaryarmory:list"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
+
 	// Test guild info key
 	key = m.GetGuildInfoKey("guild-uuid-123")
 	expected = "guild:info:guild-uuid-123"
@@ -345,6 +429,27 @@ func TestManager_CacheKeys(t *testing.T) {
 		t.Errorf("Expected %s, got %s", expected, key)
 	}
 
+	// Test guild upgrades key
+	key = m.GetGuildUpgradesKey()
+	expected = "guild:upgrades:all"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
+
+	// Test guild log key
+	key = m.GetGuildLogKey("guild-uuid-123")
+	expected = "guild:log:guild-uuid-123"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
+
+	// Test guild permissions key
+	key = m.GetGuildPermissionsKey()
+	expected = "guild:permissions:all"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
+
 	// Test color detail key
 	key = m.GetColorDetailKey(400)
 	expected = "color:detail:400"
@@ -352,6 +457,13 @@ func TestManager_CacheKeys(t *testing.T) {
 		t.Errorf("Expected %s, got %s", expected, key)
 	}
 
+	// Test color IDs key
+	key = m.GetColorIDsKey()
+	expected = "color:ids"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
+
 	// Test mini detail key
 	key = m.GetMiniDetailKey(500)
 	expected = "mini:detail:500"
@@ -366,6 +478,20 @@ func TestManager_CacheKeys(t *testing.T) {
 		t.Errorf("Expected %s, got %s", expected, key)
 	}
 
+	// Test mount types key
+	key = m.GetMountTypesKey()
+	expected = "mount:types:all"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
+
+	// Test names key
+	key = m.GetNamesKey("mounts/skins")
+	expected = "names:mounts/skins"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
+
 	// Test game build key
 	key = m.GetGameBuildKey()
 	expected = "game:build"
@@ -386,6 +512,55 @@ func TestManager_CacheKeys(t *testing.T) {
 	if key != expected {
 		t.Errorf("Expected %s, got %s", expected, key)
 	}
+
+	// Test dungeon list key
+	key = m.GetDungeonListKey("raids")
+	expected = "dungeon:list:raids"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
+
+	// Test daily catalog key
+	key = m.GetDailyCatalogKey("worldbosses")
+	expected = "dailies:catalog:worldbosses"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
+
+	// Test character hero points key
+	key = m.GetCharacterHeroPointsKey("abc123", "Alyx")
+	expected = "character:heropoints:abc123:Alyx"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
+
+	// Test continents key
+	key = m.GetContinentsKey()
+	expected = "continents:list"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
+
+	// Test continent floor key
+	key = m.GetContinentFloorKey(1, 1)
+	expected = "continent:floor:1:1"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
+
+	// Test continent map key
+	key = m.GetContinentMapKey(1, 1, 4, 15)
+	expected = "continent:map:1:1:4:15"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
+
+	// Test map detail key
+	key = m.GetMapDetailKey(15)
+	expected = "map:detail:15"
+	if key != expected {
+		t.Errorf("Expected %s, got %s", expected, key)
+	}
 }
 
 func TestManager_TTLExpiration(t *testing.T) {
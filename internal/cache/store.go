@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Store keeps data that is expensive to rebuild, such as the item index, as JSON files
+// in a data directory so it outlives the process. A Store without a directory keeps
+// nothing: Load finds nothing and Save does nothing.
+type Store struct {
+	dir string
+}
+
+// NewStore creates a store writing to dir; an empty dir disables the store
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Enabled reports whether the store has a directory to write to
+func (s *Store) Enabled() bool {
+	return s != nil && s.dir != ""
+}
+
+// Load decodes the file called name into dest, reporting false if there is none
+func (s *Store) Load(name string, dest interface{}) (bool, error) {
+	if !s.Enabled() {
+		return false, nil
+	}
+	if err := checkStoreName(name); err != nil {
+		return false, err
+	}
+	data, err := os.ReadFile(s.path(name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(data, dest); err != nil {
+		return false, fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+	return true, nil
+}
+
+// Save writes value as JSON to the file called name. The file is written under a
+// temporary name and renamed, so a crash never leaves a half-written file behind.
+func (s *Store) Save(name string, value interface{}) error {
+	if !s.Enabled() {
+		return nil
+	}
+	if err := checkStoreName(name); err != nil {
+		return err
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(name))
+}
+
+// checkStoreName rejects names that would leave the data directory. Names may come from
+// tool arguments, such as guild IDs, so only letters, digits, '-' and '_' are allowed.
+func checkStoreName(name string) error {
+	if name == "" {
+		return errors.New("empty store file name")
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return fmt.Errorf("invalid store file name %q", name)
+		}
+	}
+	return nil
+}
+
+// path returns the path of the file called name
+func (s *Store) path(name string) string {
+	return filepath.Join(s.dir, name+".json")
+}
@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStore_SaveAndLoad(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "data")
+	s := NewStore(dir)
+
+	type entry struct {
+		Build int      `json:"build"`
+		Names []string `json:"names"`
+	}
+
+	var got entry
+	found, err := s.Load("test", &got)
+	if err != nil || found {
+		t.Fatalf("Load() before Save = %v, %v; want false, nil", found, err)
+	}
+
+	want := entry{Build: 42, Names: []string{"Glob of Ectoplasm"}}
+	if err := s.Save("test", want); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	found, err = s.Load("test", &got)
+	if err != nil || !found {
+		t.Fatalf("Load() = %v, %v; want true, nil", found, err)
+	}
+	if got.Build != want.Build || len(got.Names) != 1 || got.Names[0] != want.Names[0] {
+		t.Errorf("Load() = %+v, want %+v", got, want)
+	}
+
+	// Only the saved file is left behind, not the temporary one
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Name() != "test.json" {
+		t.Errorf("data directory holds %v, want only test.json", files)
+	}
+}
+
+func TestStore_Disabled(t *testing.T) {
+	s := NewStore("")
+	if s.Enabled() {
+		t.Error("Expected a store without a directory to be disabled")
+	}
+	if err := s.Save("test", 1); err != nil {
+		t.Errorf("Save() error = %v", err)
+	}
+	var got int
+	if found, err := s.Load("test", &got); found || err != nil {
+		t.Errorf("Load() = %v, %v; want false, nil", found, err)
+	}
+}
+
+func TestStore_LoadCorrupt(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "test.json"), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]int
+	if _, err := NewStore(dir).Load("test", &got); err == nil {
+		t.Error("Expected an error decoding a corrupt file")
+	}
+}
+
+func TestStore_InvalidName(t *testing.T) {
+	s := NewStore(t.TempDir())
+	for _, name := range []string{"", "../escape", "a/b", "guild-log-1.json"} {
+		if err := s.Save(name, 1); err == nil {
+			t.Errorf("Save(%q) succeeded, want an error", name)
+		}
+		var got int
+		if _, err := s.Load(name, &got); err == nil {
+			t.Errorf("Load(%q) succeeded, want an error", name)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Package colors parses hex colours, converts sRGB to CIELAB and measures perceptual
+// colour difference with CIEDE2000.
+package colors
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RGB is an 8-bit sRGB colour
+type RGB [3]int
+
+// Lab is a colour in CIELAB space under the D65 white point
+type Lab struct {
+	L, A, B float64
+}
+
+// D65 reference white
+const (
+	whiteX = 0.95047
+	whiteY = 1.0
+	whiteZ = 1.08883
+)
+
+// ParseHex parses "#rrggbb", "rrggbb" or the "#rgb" shorthand
+func ParseHex(s string) (RGB, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return RGB{}, fmt.Errorf("invalid hex colour %q: expected #rrggbb", s)
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return RGB{}, fmt.Errorf("invalid hex colour %q: %w", s, err)
+	}
+	return RGB{int(v >> 16 & 0xff), int(v >> 8 & 0xff), int(v & 0xff)}, nil
+}
+
+// Hex formats the colour as "#rrggbb"
+func (c RGB) Hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", clamp(c[0]), clamp(c[1]), clamp(c[2]))
+}
+
+// Lab converts the colour to CIELAB
+func (c RGB) Lab() Lab {
+	r, g, b := linearize(c[0]), linearize(c[1]), linearize(c[2])
+	x := (0.4124564*r + 0.3575761*g + 0.1804375*b) / whiteX
+	y := (0.2126729*r + 0.7151522*g + 0.0721750*b) / whiteY
+	z := (0.0193339*r + 0.1191920*g + 0.9503041*b) / whiteZ
+
+	fx, fy, fz := labF(x), labF(y), labF(z)
+	return Lab{L: 116*fy - 16, A: 500 * (fx - fy), B: 200 * (fy - fz)}
+}
+
+// Distance returns the CIEDE2000 difference between two sRGB colours
+func Distance(a, b RGB) float64 {
+	return DeltaE2000(a.Lab(), b.Lab())
+}
+
+// DeltaE2000 returns the CIEDE2000 colour difference between two CIELAB colours.
+// Differences below about 1 are imperceptible; above about 10 the colours look distinct.
+func DeltaE2000(x, y Lab) float64 {
+	c1 := math.Hypot(x.A, x.B)
+	c2 := math.Hypot(y.A, y.B)
+	cBar7 := math.Pow((c1+c2)/2, 7)
+	g := 0.5 * (1 - math.Sqrt(cBar7/(cBar7+math.Pow(25, 7))))
+
+	a1 := (1 + g) * x.A
+	a2 := (1 + g) * y.A
+	c1p := math.Hypot(a1, x.B)
+	c2p := math.Hypot(a2, y.B)
+	h1p := hueAngle(x.B, a1)
+	h2p := hueAngle(y.B, a2)
+
+	dL := y.L - x.L
+	dC := c2p - c1p
+	var dh float64
+	switch {
+	case c1p*c2p == 0:
+		dh = 0
+	case math.Abs(h2p-h1p) <= 180:
+		dh = h2p - h1p
+	case h2p-h1p > 180:
+		dh = h2p - h1p - 360
+	default:
+		dh = h2p - h1p + 360
+	}
+	dH := 2 * math.Sqrt(c1p*c2p) * math.Sin(radians(dh/2))
+
+	lBar := (x.L + y.L) / 2
+	cBarP := (c1p + c2p) / 2
+	var hBarP float64
+	switch {
+	case c1p*c2p == 0:
+		hBarP = h1p + h2p
+	case math.Abs(h1p-h2p) <= 180:
+		hBarP = (h1p + h2p) / 2
+	case h1p+h2p < 360:
+		hBarP = (h1p + h2p + 360) / 2
+	default:
+		hBarP = (h1p + h2p - 360) / 2
+	}
+
+	t := 1 - 0.17*math.Cos(radians(hBarP-30)) + 0.24*math.Cos(radians(2*hBarP)) +
+		0.32*math.Cos(radians(3*hBarP+6)) - 0.20*math.Cos(radians(4*hBarP-63))
+	dTheta := 30 * math.Exp(-math.Pow((hBarP-275)/25, 2))
+	cBarP7 := math.Pow(cBarP, 7)
+	rc := 2 * math.Sqrt(cBarP7/(cBarP7+math.Pow(25, 7)))
+	lBar50 := (lBar - 50) * (lBar - 50)
+	sl := 1 + 0.015*lBar50/math.Sqrt(20+lBar50)
+	sc := 1 + 0.045*cBarP
+	sh := 1 + 0.015*cBarP*t
+	rt := -math.Sin(radians(2*dTheta)) * rc
+
+	lTerm, cTerm, hTerm := dL/sl, dC/sc, dH/sh
+	return math.Sqrt(lTerm*lTerm + cTerm*cTerm + hTerm*hTerm + rt*cTerm*hTerm)
+}
+
+// linearize converts an 8-bit sRGB channel to linear light
+func linearize(v int) float64 {
+	c := float64(clamp(v)) / 255
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+// labF is the CIELAB companding function
+func labF(t float64) float64 {
+	const epsilon = 216.0 / 24389.0
+	const kappa = 24389.0 / 27.0
+	if t > epsilon {
+		return math.Cbrt(t)
+	}
+	return (kappa*t + 16) / 116
+}
+
+// hueAngle returns atan2(b, a) in degrees within [0, 360)
+func hueAngle(b, a float64) float64 {
+	if a == 0 && b == 0 {
+		return 0
+	}
+	h := math.Atan2(b, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func clamp(v int) int {
+	return max(0, min(255, v))
+}
@@ -0,0 +1,87 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseHex(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    RGB
+		wantErr bool
+	}{
+		{"#ff8000", RGB{255, 128, 0}, false},
+		{"FF8000", RGB{255, 128, 0}, false},
+		{"#f80", RGB{255, 136, 0}, false},
+		{" #000000 ", RGB{0, 0, 0}, false},
+		{"#ff80", RGB{}, true},
+		{"#gg0000", RGB{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseHex(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseHex(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseHex(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestHex(t *testing.T) {
+	if got := (RGB{255, 128, 0}).Hex(); got != "#ff8000" {
+		t.Errorf("Hex() = %q, want #ff8000", got)
+	}
+	if got := (RGB{300, -5, 16}).Hex(); got != "#ff0010" {
+		t.Errorf("Hex() = %q, want clamped #ff0010", got)
+	}
+}
+
+func TestLab(t *testing.T) {
+	tests := []struct {
+		rgb  RGB
+		want Lab
+	}{
+		{RGB{255, 255, 255}, Lab{100, 0, 0}},
+		{RGB{0, 0, 0}, Lab{0, 0, 0}},
+		{RGB{255, 0, 0}, Lab{53.24, 80.09, 67.20}},
+	}
+	for _, tt := range tests {
+		got := tt.rgb.Lab()
+		if math.Abs(got.L-tt.want.L) > 0.05 || math.Abs(got.A-tt.want.A) > 0.05 || math.Abs(got.B-tt.want.B) > 0.05 {
+			t.Errorf("%v.Lab() = %+v, want %+v", tt.rgb, got, tt.want)
+		}
+	}
+}
+
+// Reference pairs from Sharma, Wu and Dalal, "The CIEDE2000 Color-Difference Formula"
+func TestDeltaE2000(t *testing.T) {
+	tests := []struct {
+		a, b Lab
+		want float64
+	}{
+		{Lab{50, 2.6772, -79.7751}, Lab{50, 0, -82.7485}, 2.0425},
+		{Lab{50, 0, 0}, Lab{50, -1, 2}, 2.3669},
+		{Lab{50, 2.5, 0}, Lab{73, 25, -18}, 27.1492},
+		{Lab{60.2574, -34.0099, 36.2677}, Lab{60.4626, -34.1751, 39.4387}, 1.2644},
+		{Lab{22.7233, 20.0904, -46.694}, Lab{23.0331, 14.973, -42.5619}, 2.0373},
+		{Lab{2.0776, 0.0795, -1.135}, Lab{0.9033, -0.0636, -0.5514}, 0.9082},
+	}
+	for _, tt := range tests {
+		if got := DeltaE2000(tt.a, tt.b); math.Abs(got-tt.want) > 0.0001 {
+			t.Errorf("DeltaE2000(%+v, %+v) = %.4f, want %.4f", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestDistance(t *testing.T) {
+	red := RGB{255, 0, 0}
+	if d := Distance(red, red); d != 0 {
+		t.Errorf("Distance(red, red) = %v, want 0", d)
+	}
+	if near, far := Distance(red, RGB{250, 10, 10}), Distance(red, RGB{0, 0, 255}); near >= far {
+		t.Errorf("near red (%v) should be closer than blue (%v)", near, far)
+	}
+}
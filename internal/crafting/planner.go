@@ -0,0 +1,233 @@
+// Package crafting expands crafting recipe trees against an account's owned
+// materials to work out what is still missing for a target item.
+package crafting
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Ingredient kinds. Unresolved ingredients are known only by name, such as Mystic Forge
+// ingredients the wiki could not resolve to an item; they can never be owned or priced.
+const (
+	KindItem       = "item"
+	KindCurrency   = "currency"
+	KindUnresolved = "unresolved"
+)
+
+// defaultMaxDepth bounds recipe expansion; legendary trees are rarely deeper than six levels
+const defaultMaxDepth = 10
+
+// Ingredient is a single input of a recipe
+type Ingredient struct {
+	Kind  string `json:"kind"`
+	ID    int    `json:"id"`
+	Name  string `json:"name,omitempty"`
+	Count int    `json:"count"`
+}
+
+// Recipe is a source-agnostic recipe used for tree expansion
+type Recipe struct {
+	ID          int          `json:"id,omitempty"`
+	Source      string       `json:"source"`
+	OutputID    int          `json:"output_item_id"`
+	OutputCount int          `json:"output_item_count"`
+	Ingredients []Ingredient `json:"ingredients"`
+}
+
+// RecipeLookup returns a recipe producing the given item, or nil if the item cannot be crafted
+type RecipeLookup func(ctx context.Context, itemID int) (*Recipe, error)
+
+// Node is a single requirement in an expanded recipe tree
+type Node struct {
+	Kind         string  `json:"kind"`
+	ID           int     `json:"id"`
+	Name         string  `json:"name,omitempty"`
+	Required     int     `json:"required"`
+	Owned        int     `json:"owned"`
+	Missing      int     `json:"missing"`
+	RecipeSource string  `json:"recipe_source,omitempty"`
+	Crafts       int     `json:"crafts,omitempty"`
+	Children     []*Node `json:"children,omitempty"`
+}
+
+// Requirement is an aggregated leaf requirement that is still missing
+type Requirement struct {
+	Kind          string `json:"kind"`
+	ID            int    `json:"id"`
+	Name          string `json:"name,omitempty"`
+	Missing       int    `json:"missing"`
+	UnitPrice     int    `json:"unit_price,omitempty"`
+	TotalCost     int    `json:"total_cost,omitempty"`
+	Tradeable     bool   `json:"tradeable"`
+	FormattedCost string `json:"total_cost_formatted,omitempty"`
+}
+
+// Plan is the result of expanding a target against owned materials
+type Plan struct {
+	Root    *Node         `json:"tree"`
+	Missing []Requirement `json:"missing"`
+}
+
+// Inventory tracks owned quantities keyed by kind and ID
+type Inventory map[string]int
+
+// Key returns the inventory key for an ingredient kind and ID
+func Key(kind string, id int) string {
+	return fmt.Sprintf("%s:%d", kind, id)
+}
+
+// Add adds count units of the given ingredient to the inventory
+func (inv Inventory) Add(kind string, id, count int) {
+	inv[Key(kind, id)] += count
+}
+
+// Planner expands recipe trees using a recipe lookup
+type Planner struct {
+	lookup   RecipeLookup
+	maxDepth int
+}
+
+// NewPlanner creates a planner backed by the given recipe lookup
+func NewPlanner(lookup RecipeLookup) *Planner {
+	return &Planner{lookup: lookup, maxDepth: defaultMaxDepth}
+}
+
+// Plan expands count units of itemID, consuming owned materials from inv as it goes.
+// The target item itself is not taken from the inventory, so owning one already
+// still plans a fresh craft. inv is modified in place.
+func (p *Planner) Plan(ctx context.Context, itemID, count int, inv Inventory) (*Plan, error) {
+	root := &Node{Kind: KindItem, ID: itemID, Required: count, Missing: count}
+	if err := p.expand(ctx, root, inv, 0, map[int]bool{itemID: true}); err != nil {
+		return nil, err
+	}
+	return &Plan{Root: root, Missing: collectMissing(root)}, nil
+}
+
+// consume takes up to node.Required units from the inventory and returns the remainder
+func consume(node *Node, inv Inventory) int {
+	key := Key(node.Kind, node.ID)
+	owned := inv[key]
+	if owned > node.Required {
+		owned = node.Required
+	}
+	inv[key] -= owned
+	node.Owned = owned
+	node.Missing = node.Required - owned
+	return node.Missing
+}
+
+// expand looks up a recipe for node and recursively plans its ingredients
+func (p *Planner) expand(ctx context.Context, node *Node, inv Inventory, depth int, path map[int]bool) error {
+	if node.Kind != KindItem || node.Missing == 0 || depth >= p.maxDepth {
+		return nil
+	}
+
+	recipe, err := p.lookup(ctx, node.ID)
+	if err != nil {
+		return fmt.Errorf("failed to look up recipe for item %d: %w", node.ID, err)
+	}
+	if recipe == nil || len(recipe.Ingredients) == 0 {
+		return nil
+	}
+
+	outputCount := recipe.OutputCount
+	if outputCount <= 0 {
+		outputCount = 1
+	}
+	node.Crafts = (node.Missing + outputCount - 1) / outputCount
+	node.RecipeSource = recipe.Source
+
+	for _, ing := range recipe.Ingredients {
+		child := &Node{Kind: ing.Kind, ID: ing.ID, Name: ing.Name, Required: ing.Count * node.Crafts}
+		node.Children = append(node.Children, child)
+		if consume(child, inv) == 0 {
+			continue
+		}
+		// Guard against recipe cycles (e.g. refinement loops in the Mystic Forge)
+		if child.Kind == KindItem && path[child.ID] {
+			continue
+		}
+		if child.Kind == KindItem {
+			path[child.ID] = true
+		}
+		if err := p.expand(ctx, child, inv, depth+1, path); err != nil {
+			return err
+		}
+		if child.Kind == KindItem {
+			delete(path, child.ID)
+		}
+	}
+	return nil
+}
+
+// collectMissing aggregates the unmet leaf requirements of a tree
+func collectMissing(root *Node) []Requirement {
+	totals := make(map[string]*Requirement)
+	var order []string
+
+	var walk func(n *Node)
+	walk = func(n *Node) {
+		if len(n.Children) > 0 {
+			for _, child := range n.Children {
+				walk(child)
+			}
+			return
+		}
+		if n.Missing == 0 {
+			return
+		}
+		key := Key(n.Kind, n.ID)
+		if n.Kind == KindUnresolved {
+			key = n.Kind + ":" + n.Name
+		}
+		req, ok := totals[key]
+		if !ok {
+			req = &Requirement{Kind: n.Kind, ID: n.ID, Name: n.Name}
+			totals[key] = req
+			order = append(order, key)
+		}
+		req.Missing += n.Missing
+	}
+	walk(root)
+
+	missing := make([]Requirement, 0, len(order))
+	for _, key := range order {
+		missing = append(missing, *totals[key])
+	}
+	return missing
+}
+
+// Walk calls fn for every node in the tree, parents before children
+func (n *Node) Walk(fn func(*Node)) {
+	fn(n)
+	for _, child := range n.Children {
+		child.Walk(fn)
+	}
+}
+
+// ApplyPrices fills in unit prices and totals for tradeable missing items and
+// returns the total cost in copper. prices maps item ID to a unit price.
+func (p *Plan) ApplyPrices(prices map[int]int) int {
+	total := 0
+	for i, req := range p.Missing {
+		if req.Kind != KindItem {
+			continue
+		}
+		price, ok := prices[req.ID]
+		if !ok || price == 0 {
+			continue
+		}
+		p.Missing[i].Tradeable = true
+		p.Missing[i].UnitPrice = price
+		p.Missing[i].TotalCost = price * req.Missing
+		total += p.Missing[i].TotalCost
+	}
+
+	// Most expensive first, so the LLM can point at what dominates the cost
+	sort.SliceStable(p.Missing, func(i, j int) bool {
+		return p.Missing[i].TotalCost > p.Missing[j].TotalCost
+	})
+	return total
+}
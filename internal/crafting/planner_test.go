@@ -0,0 +1,151 @@
+package crafting
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// mapLookup builds a RecipeLookup from a fixed recipe table
+func mapLookup(recipes map[int]*Recipe) RecipeLookup {
+	return func(_ context.Context, itemID int) (*Recipe, error) {
+		return recipes[itemID], nil
+	}
+}
+
+func TestPlanner_Plan(t *testing.T) {
+	// 1 Target (100) = 2x Gift (200) + 5x Coin (300)
+	// 1 Gift (200)   = 10x Ore (400) + 3 Spirit Shards (currency 23)
+	recipes := map[int]*Recipe{
+		100: {Source: "mystic_forge", OutputID: 100, OutputCount: 1, Ingredients: []Ingredient{
+			{Kind: KindItem, ID: 200, Count: 2},
+			{Kind: KindItem, ID: 300, Count: 5},
+		}},
+		200: {Source: "api", OutputID: 200, OutputCount: 1, Ingredients: []Ingredient{
+			{Kind: KindItem, ID: 400, Count: 10},
+			{Kind: KindCurrency, ID: 23, Count: 3},
+		}},
+	}
+
+	inv := Inventory{}
+	inv.Add(KindItem, 200, 1)       // one gift already made
+	inv.Add(KindItem, 300, 2)       // some coins
+	inv.Add(KindItem, 400, 4)       // some ore
+	inv.Add(KindCurrency, 23, 1000) // plenty of shards
+
+	plan, err := NewPlanner(mapLookup(recipes)).Plan(context.Background(), 100, 1, inv)
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+
+	root := plan.Root
+	if root.RecipeSource != "mystic_forge" || root.Crafts != 1 {
+		t.Errorf("root = %+v, want one mystic_forge craft", root)
+	}
+	gift := root.Children[0]
+	if gift.Owned != 1 || gift.Missing != 1 || gift.Crafts != 1 {
+		t.Errorf("gift = %+v, want owned 1, missing 1, 1 craft", gift)
+	}
+
+	want := map[string]int{
+		Key(KindItem, 300): 3,
+		Key(KindItem, 400): 6,
+	}
+	if len(plan.Missing) != len(want) {
+		t.Fatalf("got %d missing requirements, want %d: %+v", len(plan.Missing), len(want), plan.Missing)
+	}
+	for _, req := range plan.Missing {
+		if want[Key(req.Kind, req.ID)] != req.Missing {
+			t.Errorf("missing %s:%d = %d, want %d", req.Kind, req.ID, req.Missing, want[Key(req.Kind, req.ID)])
+		}
+	}
+	if inv[Key(KindCurrency, 23)] != 997 {
+		t.Errorf("spirit shards left = %d, want 997", inv[Key(KindCurrency, 23)])
+	}
+}
+
+func TestPlanner_OutputCountRoundsUp(t *testing.T) {
+	recipes := map[int]*Recipe{
+		100: {OutputID: 100, OutputCount: 5, Ingredients: []Ingredient{{Kind: KindItem, ID: 200, Count: 2}}},
+	}
+
+	plan, err := NewPlanner(mapLookup(recipes)).Plan(context.Background(), 100, 7, Inventory{})
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if plan.Root.Crafts != 2 {
+		t.Errorf("crafts = %d, want 2", plan.Root.Crafts)
+	}
+	if len(plan.Missing) != 1 || plan.Missing[0].Missing != 4 {
+		t.Errorf("missing = %+v, want 4 of item 200", plan.Missing)
+	}
+}
+
+func TestPlanner_Cycle(t *testing.T) {
+	// 100 needs 200, 200 needs 100: expansion must terminate
+	recipes := map[int]*Recipe{
+		100: {OutputID: 100, OutputCount: 1, Ingredients: []Ingredient{{Kind: KindItem, ID: 200, Count: 1}}},
+		200: {OutputID: 200, OutputCount: 1, Ingredients: []Ingredient{{Kind: KindItem, ID: 100, Count: 1}}},
+	}
+
+	plan, err := NewPlanner(mapLookup(recipes)).Plan(context.Background(), 100, 1, Inventory{})
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if len(plan.Missing) != 1 || plan.Missing[0].ID != 100 {
+		t.Errorf("missing = %+v, want the cyclic item 100 as a leaf", plan.Missing)
+	}
+}
+
+func TestPlanner_UnresolvedIngredients(t *testing.T) {
+	// 1 Gift (100) = 2x Ore (200) + 1 "Mystery Shard" the wiki could not resolve
+	recipes := map[int]*Recipe{
+		100: {Source: "mystic_forge", OutputID: 100, OutputCount: 1, Ingredients: []Ingredient{
+			{Kind: KindItem, ID: 200, Count: 2},
+			{Kind: KindUnresolved, Name: "Mystery Shard", Count: 1},
+		}},
+	}
+
+	plan, err := NewPlanner(mapLookup(recipes)).Plan(context.Background(), 100, 3, Inventory{})
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	var unresolved *Requirement
+	for i, req := range plan.Missing {
+		if req.Kind == KindUnresolved {
+			unresolved = &plan.Missing[i]
+		}
+	}
+	if unresolved == nil || unresolved.Name != "Mystery Shard" || unresolved.Missing != 3 {
+		t.Errorf("missing = %+v, want 3 unresolved Mystery Shard", plan.Missing)
+	}
+}
+
+func TestPlanner_LookupError(t *testing.T) {
+	lookup := func(context.Context, int) (*Recipe, error) { return nil, errors.New("boom") }
+	if _, err := NewPlanner(lookup).Plan(context.Background(), 100, 1, Inventory{}); err == nil {
+		t.Error("expected error from failing lookup")
+	}
+}
+
+func TestPlan_ApplyPrices(t *testing.T) {
+	plan := &Plan{Missing: []Requirement{
+		{Kind: KindItem, ID: 1, Missing: 10},
+		{Kind: KindItem, ID: 2, Missing: 1},
+		{Kind: KindCurrency, ID: 23, Missing: 50},
+		{Kind: KindItem, ID: 3, Missing: 4},
+	}}
+
+	total := plan.ApplyPrices(map[int]int{1: 100, 2: 5000})
+	if total != 6000 {
+		t.Errorf("total = %d, want 6000", total)
+	}
+	if plan.Missing[0].ID != 2 || plan.Missing[1].ID != 1 {
+		t.Errorf("expected most expensive requirements first, got %+v", plan.Missing)
+	}
+	for _, req := range plan.Missing {
+		if (req.ID == 3 || req.Kind == KindCurrency) && req.Tradeable {
+			t.Errorf("requirement %+v should not be tradeable", req)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+// Package fractals provides the daily fractal rotation and Mistlock Instability schedule,
+// computed for any date from a bundled cycle and schedule, and parses the daily fractal
+// achievement names the API reports.
+package fractals
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DateLayout is the layout of the anchor dates and of learned days
+const DateLayout = "2006-01-02"
+
+// Tier 4 fractal levels, the only ones with daily Tier 4 achievements
+const (
+	MinTier4Level = 76
+	MaxTier4Level = 100
+)
+
+// CycleLength is the number of days after which the daily fractals repeat
+const CycleLength = 15
+
+//go:embed rotation.json
+var rotationJSON []byte
+
+var (
+	reDailyTier        = regexp.MustCompile(`^Daily Tier (\d) (.+)$`)
+	reDailyRecommended = regexp.MustCompile(`^Daily Recommended Fractal.*Scale (\d+)$`)
+)
+
+// CycleDay is the Tier 4 fractals and recommended scales of one day of the cycle
+type CycleDay struct {
+	Tier4       []string `json:"t4"`
+	Recommended []int    `json:"recommended"`
+}
+
+// Known reports whether the day's fractals are known
+func (d CycleDay) Known() bool {
+	return len(d.Tier4) > 0 || len(d.Recommended) > 0
+}
+
+// Cycle is the daily fractal cycle. Days[0] is the anchor date's fractals and each day
+// after moves one entry on, wrapping around after CycleLength days. Empty days are
+// unknown.
+type Cycle struct {
+	Anchor string     `json:"anchor"`
+	Days   []CycleDay `json:"days"`
+}
+
+// Schedule is the Mistlock Instability schedule. Each fractal level lists the
+// instabilities of consecutive days from the anchor date, repeating once the list runs
+// out. Levels without a list have no instabilities.
+type Schedule struct {
+	Anchor string             `json:"anchor"`
+	Levels map[int][][]string `json:"levels"`
+}
+
+// LearnedDay is a day's fractals read from the API, which override the bundled cycle
+// day at the same position
+type LearnedDay struct {
+	Date string `json:"date"`
+	CycleDay
+}
+
+// Rotation is a versioned cycle and instability schedule, plus the cycle days learned
+// from the API since
+type Rotation struct {
+	Version       string   `json:"version"`
+	Source        string   `json:"source"`
+	Instabilities []string `json:"instabilities"`
+	Cycle         Cycle    `json:"cycle"`
+	Schedule      Schedule `json:"instability_schedule"`
+
+	cycleAnchor    time.Time
+	scheduleAnchor time.Time
+
+	mu      sync.RWMutex
+	learned map[int]LearnedDay // by cycle position
+}
+
+// Day is the rotation for a single UTC day
+type Day struct {
+	// Position is the day's place in the cycle, from 0 to CycleLength-1
+	Position int
+	CycleDay
+	// Learned is set when the fractals were read from the API rather than bundled
+	Learned       bool
+	Instabilities map[int][]string
+}
+
+// Load parses the bundled rotation
+func Load() (*Rotation, error) {
+	return Parse(rotationJSON)
+}
+
+// Parse parses and validates a rotation. Anchors must use DateLayout, the cycle must be
+// empty or CycleLength days long, every instability must be listed in the catalogue,
+// levels must be 1 to 100 and every level must list the same number of days.
+func Parse(data []byte) (*Rotation, error) {
+	var r Rotation
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("failed to decode fractal rotation: %w", err)
+	}
+
+	var err error
+	if r.cycleAnchor, err = time.Parse(DateLayout, r.Cycle.Anchor); err != nil {
+		return nil, fmt.Errorf("invalid cycle anchor %q: %w", r.Cycle.Anchor, err)
+	}
+	if n := len(r.Cycle.Days); n != 0 && n != CycleLength {
+		return nil, fmt.Errorf("cycle has %d days, want %d", n, CycleLength)
+	}
+	for i, day := range r.Cycle.Days {
+		for _, scale := range day.Recommended {
+			if scale < 1 || scale > MaxTier4Level {
+				return nil, fmt.Errorf("cycle day %d: invalid recommended scale %d", i, scale)
+			}
+		}
+	}
+
+	if len(r.Schedule.Levels) > 0 {
+		if r.scheduleAnchor, err = time.Parse(DateLayout, r.Schedule.Anchor); err != nil {
+			return nil, fmt.Errorf("invalid instability schedule anchor %q: %w", r.Schedule.Anchor, err)
+		}
+	}
+	known := make(map[string]bool, len(r.Instabilities))
+	for _, name := range r.Instabilities {
+		known[name] = true
+	}
+	scheduleDays := -1
+	for level, days := range r.Schedule.Levels {
+		if level < 1 || level > MaxTier4Level {
+			return nil, fmt.Errorf("instability schedule: invalid fractal level %d", level)
+		}
+		if scheduleDays >= 0 && len(days) != scheduleDays {
+			return nil, fmt.Errorf("instability schedule: level %d lists %d days, other levels %d", level, len(days), scheduleDays)
+		}
+		scheduleDays = len(days)
+		for i, names := range days {
+			for _, name := range names {
+				if !known[name] {
+					return nil, fmt.Errorf("instability schedule: unknown instability %q at level %d, day %d", name, level, i)
+				}
+			}
+		}
+	}
+	return &r, nil
+}
+
+// daysSince returns the whole UTC days from anchor to the day containing t
+func daysSince(anchor, t time.Time) int {
+	y, m, d := t.UTC().Date()
+	return int(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Sub(anchor).Hours() / 24)
+}
+
+// position returns n modulo length, counting back from the end for negative n
+func position(n, length int) int {
+	return (n%length + length) % length
+}
+
+// Day returns the rotation for the UTC day containing t: the cycle day, learned or
+// bundled, and the scheduled instabilities. ok is false when neither is known.
+func (r *Rotation) Day(t time.Time) (Day, bool) {
+	day := Day{Position: position(daysSince(r.cycleAnchor, t), CycleLength), Instabilities: map[int][]string{}}
+
+	r.mu.RLock()
+	learned, ok := r.learned[day.Position]
+	r.mu.RUnlock()
+	switch {
+	case ok:
+		day.CycleDay, day.Learned = learned.CycleDay, true
+	case day.Position < len(r.Cycle.Days):
+		day.CycleDay = r.Cycle.Days[day.Position]
+	}
+
+	n := daysSince(r.scheduleAnchor, t)
+	for level, days := range r.Schedule.Levels {
+		if len(days) == 0 {
+			continue
+		}
+		if names := days[position(n, len(days))]; len(names) > 0 {
+			day.Instabilities[level] = names
+		}
+	}
+	return day, day.Known() || len(day.Instabilities) > 0
+}
+
+// Learn records the fractals read from the API for the UTC day containing t, replacing
+// what was known for its cycle position, and reports whether anything changed
+func (r *Rotation) Learn(t time.Time, day CycleDay) bool {
+	if !day.Known() {
+		return false
+	}
+	pos := position(daysSince(r.cycleAnchor, t), CycleLength)
+	date := t.UTC().Format(DateLayout)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.learned[pos]; ok && old.Date >= date {
+		return false
+	}
+	if r.learned == nil {
+		r.learned = make(map[int]LearnedDay)
+	}
+	r.learned[pos] = LearnedDay{Date: date, CycleDay: day}
+	return true
+}
+
+// LoadLearned restores days learned earlier, such as those saved by a previous run; a
+// later day replaces an earlier one at the same cycle position
+func (r *Rotation) LoadLearned(days []LearnedDay) error {
+	for _, day := range days {
+		t, err := time.Parse(DateLayout, day.Date)
+		if err != nil {
+			return fmt.Errorf("invalid learned day %q: %w", day.Date, err)
+		}
+		r.Learn(t, day.CycleDay)
+	}
+	return nil
+}
+
+// Learned returns the days learned from the API, oldest first
+func (r *Rotation) Learned() []LearnedDay {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	days := make([]LearnedDay, 0, len(r.learned))
+	for _, day := range r.learned {
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Date < days[j].Date })
+	return days
+}
+
+// Levels returns the fractal levels with instabilities for the day, in ascending order
+func (d Day) Levels() []int {
+	levels := make([]int, 0, len(d.Instabilities))
+	for level := range d.Instabilities {
+		levels = append(levels, level)
+	}
+	sort.Ints(levels)
+	return levels
+}
+
+// ParseDailyAchievement extracts the fractal from a daily fractal achievement name.
+// "Daily Tier 4 Nightmare" yields tier 4 and "Nightmare"; "Daily Recommended
+// Fractal—Scale 40" yields scale 40. ok is false for any other name.
+func ParseDailyAchievement(name string) (tier int, fractal string, scale int, ok bool) {
+	if m := reDailyTier.FindStringSubmatch(name); m != nil {
+		tier, _ = strconv.Atoi(m[1])
+		return tier, m[2], 0, true
+	}
+	if m := reDailyRecommended.FindStringSubmatch(name); m != nil {
+		scale, _ = strconv.Atoi(m[1])
+		return 0, "", scale, true
+	}
+	return 0, "", 0, false
+}
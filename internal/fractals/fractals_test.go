@@ -0,0 +1,200 @@
+package fractals
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	r, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if r.Version == "" || r.Source == "" {
+		t.Error("bundled rotation should have a version and source")
+	}
+	if len(r.Instabilities) == 0 {
+		t.Error("bundled rotation should list the instability catalogue")
+	}
+}
+
+func TestLoadKnownDate(t *testing.T) {
+	r, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(r.Cycle.Days) != CycleLength {
+		t.Fatalf("bundled cycle has %d days, want %d", len(r.Cycle.Days), CycleLength)
+	}
+
+	day, ok := r.Day(time.Date(2026, 10, 20, 18, 0, 0, 0, time.UTC))
+	if !ok || day.Position != 2 || day.Learned {
+		t.Fatalf("Day() = %+v, %v", day, ok)
+	}
+	if want := []string{"Cliffside", "Twilight Oasis", "Underground Facility"}; !reflect.DeepEqual(day.Tier4, want) {
+		t.Errorf("Tier4 = %v, want %v", day.Tier4, want)
+	}
+	if want := []int{10, 32, 65}; !reflect.DeepEqual(day.Recommended, want) {
+		t.Errorf("Recommended = %v, want %v", day.Recommended, want)
+	}
+	if n := len(day.Levels()); n != MaxTier4Level-MinTier4Level+1 {
+		t.Errorf("instabilities for %d levels, want every Tier 4 level", n)
+	}
+	if want := []string{"Boon Overload", "Mists Convergence", "No Pain, No Gain"}; !reflect.DeepEqual(day.Instabilities[100], want) {
+		t.Errorf("Instabilities[100] = %v, want %v", day.Instabilities[100], want)
+	}
+
+	// A cycle later the fractals repeat
+	later, _ := r.Day(time.Date(2026, 11, 4, 0, 0, 0, 0, time.UTC))
+	if !reflect.DeepEqual(later.CycleDay, day.CycleDay) {
+		t.Errorf("Day() a cycle later = %+v, want %+v", later.CycleDay, day.CycleDay)
+	}
+}
+
+func TestParse(t *testing.T) {
+	data := []byte(`{
+		"version": "test",
+		"instabilities": ["Afflicted", "Flux Bomb", "Vengeance"],
+		"cycle": {
+			"anchor": "2026-10-18",
+			"days": [
+				{"t4": ["Nightmare", "Snowblind"], "recommended": [12, 40]},
+				{"t4": ["Aetherblade"], "recommended": [2]},
+				{}, {}, {}, {}, {}, {}, {}, {}, {}, {}, {}, {},
+				{"t4": ["Sunqua Peak"], "recommended": [99]}
+			]
+		},
+		"instability_schedule": {
+			"anchor": "2026-10-17",
+			"levels": {
+				"98": [["Afflicted"], ["Vengeance", "Afflicted"], []],
+				"76": [["Flux Bomb"], ["Flux Bomb"], ["Vengeance"]]
+			}
+		}
+	}`)
+	r, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	day, ok := r.Day(time.Date(2026, 10, 18, 23, 59, 0, 0, time.UTC))
+	if !ok {
+		t.Fatal("Day() should find 2026-10-18")
+	}
+	if day.Position != 0 || !reflect.DeepEqual(day.Tier4, []string{"Nightmare", "Snowblind"}) || day.Learned {
+		t.Errorf("Day(2026-10-18) = %+v", day)
+	}
+	if !reflect.DeepEqual(day.Levels(), []int{76, 98}) {
+		t.Errorf("Levels() = %v, want [76 98]", day.Levels())
+	}
+	if !reflect.DeepEqual(day.Instabilities[98], []string{"Vengeance", "Afflicted"}) {
+		t.Errorf("Instabilities[98] = %v", day.Instabilities[98])
+	}
+
+	// A time zone ahead of UTC is still the UTC day
+	local := time.Date(2026, 10, 19, 1, 0, 0, 0, time.FixedZone("UTC+3", 3*60*60))
+	if day, _ := r.Day(local); day.Position != 0 {
+		t.Errorf("Day() should use the UTC date, got position %d", day.Position)
+	}
+
+	// The cycle and the schedule repeat, both forwards and backwards from their anchors
+	tests := []struct {
+		date     time.Time
+		position int
+		tier4    []string
+		levels   []int
+	}{
+		{time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC), 0, []string{"Nightmare", "Snowblind"}, []int{76, 98}},
+		{time.Date(2026, 11, 3, 0, 0, 0, 0, time.UTC), 1, []string{"Aetherblade"}, []int{76}},
+		{time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), 14, []string{"Sunqua Peak"}, []int{76, 98}},
+		{time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC), 2, nil, []int{76, 98}},
+	}
+	for _, tt := range tests {
+		day, ok := r.Day(tt.date)
+		if !ok || day.Position != tt.position || !reflect.DeepEqual(day.Tier4, tt.tier4) || !reflect.DeepEqual(day.Levels(), tt.levels) {
+			t.Errorf("Day(%s) = %+v, %v; want position %d, t4 %v, levels %v",
+				tt.date.Format(DateLayout), day, ok, tt.position, tt.tier4, tt.levels)
+		}
+	}
+}
+
+func TestRotationLearn(t *testing.T) {
+	r, err := Parse([]byte(`{"cycle": {"anchor": "2026-10-18"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	date := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+	if _, ok := r.Day(date); ok {
+		t.Fatal("an empty rotation should know no day")
+	}
+
+	learned := CycleDay{Tier4: []string{"Deepstone"}, Recommended: []int{25}}
+	if !r.Learn(date, learned) {
+		t.Fatal("Learn() should record a new day")
+	}
+	if r.Learn(date, learned) {
+		t.Error("Learn() should not record the same day twice")
+	}
+
+	// The learned day applies to every date at the same cycle position
+	for _, d := range []time.Time{date, date.AddDate(0, 0, CycleLength), date.AddDate(0, 0, -CycleLength)} {
+		day, ok := r.Day(d)
+		if !ok || !day.Learned || !reflect.DeepEqual(day.Tier4, learned.Tier4) {
+			t.Errorf("Day(%s) = %+v, %v; want the learned day", d.Format(DateLayout), day, ok)
+		}
+	}
+
+	// Saved days restore in a new rotation, and an earlier day does not replace a later one
+	restored, _ := Parse([]byte(`{"cycle": {"anchor": "2026-10-18"}}`))
+	older := LearnedDay{Date: "2026-10-05", CycleDay: CycleDay{Tier4: []string{"Old"}}}
+	if err := restored.LoadLearned(append(r.Learned(), older)); err != nil {
+		t.Fatalf("LoadLearned() error = %v", err)
+	}
+	if got := restored.Learned(); len(got) != 1 || got[0].Date != "2026-10-20" {
+		t.Errorf("Learned() = %+v, want only 2026-10-20", got)
+	}
+	if err := restored.LoadLearned([]LearnedDay{{Date: "20/10/2026"}}); err == nil {
+		t.Error("LoadLearned() should reject a bad date")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"bad json":            `{`,
+		"no cycle anchor":     `{}`,
+		"bad cycle anchor":    `{"cycle": {"anchor": "18/10/2026"}}`,
+		"short cycle":         `{"cycle": {"anchor": "2026-10-18", "days": [{}, {}]}}`,
+		"bad schedule anchor": `{"cycle": {"anchor": "2026-10-18"}, "instabilities": ["Afflicted"], "instability_schedule": {"levels": {"80": [["Afflicted"]]}}}`,
+		"unknown instability": `{"cycle": {"anchor": "2026-10-18"}, "instabilities": ["Afflicted"], "instability_schedule": {"anchor": "2026-10-18", "levels": {"80": [["Birds"]]}}}`,
+		"bad level":           `{"cycle": {"anchor": "2026-10-18"}, "instabilities": ["Afflicted"], "instability_schedule": {"anchor": "2026-10-18", "levels": {"101": [["Afflicted"]]}}}`,
+		"uneven levels":       `{"cycle": {"anchor": "2026-10-18"}, "instabilities": ["Afflicted"], "instability_schedule": {"anchor": "2026-10-18", "levels": {"80": [["Afflicted"]], "81": [[], []]}}}`,
+	}
+	for name, data := range tests {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("%s: Parse() expected error", name)
+		}
+	}
+}
+
+func TestParseDailyAchievement(t *testing.T) {
+	tests := []struct {
+		name    string
+		tier    int
+		fractal string
+		scale   int
+		ok      bool
+	}{
+		{"Daily Tier 4 Nightmare", 4, "Nightmare", 0, true},
+		{"Daily Tier 1 Siren's Reef", 1, "Siren's Reef", 0, true},
+		{"Daily Recommended Fractal—Scale 40", 0, "", 40, true},
+		{"Daily Recommended Fractal - Scale 7", 0, "", 7, true},
+		{"Fractal Adept", 0, "", 0, false},
+	}
+	for _, tt := range tests {
+		tier, fractal, scale, ok := ParseDailyAchievement(tt.name)
+		if tier != tt.tier || fractal != tt.fractal || scale != tt.scale || ok != tt.ok {
+			t.Errorf("ParseDailyAchievement(%q) = %d, %q, %d, %v", tt.name, tier, fractal, scale, ok)
+		}
+	}
+}
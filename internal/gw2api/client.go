@@ -6,10 +6,14 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
-	"io"
+	"maps"
 	"net/http"
+	"net/url"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/log"
@@ -21,14 +25,21 @@ const (
 	baseURL        = "https://api.guildwars2.com/v2"
 	userAgent      = "github.com/AlyxPink/gw2-mcp"
 	requestTimeout = 30 * time.Second
+
+	// maxIDsPerRequest is the GW2 API limit on the number of IDs in a single ?ids= query
+	maxIDsPerRequest = 200
 )
 
 // Client handles GW2 API requests
 type Client struct {
 	httpClient *http.Client
 	cache      *cache.Manager
+	store      *cache.Store
 	logger     *log.Logger
 	apiKey     string
+
+	// itemIndexMu is held while the item index is built, so it is only built once
+	itemIndexMu sync.Mutex
 }
 
 // WalletEntry represents a single currency in the wallet
@@ -165,12 +176,13 @@ type TransactionList struct {
 }
 
 // NewClient creates a new GW2 API client
-func NewClient(cacheManager *cache.Manager, logger *log.Logger, apiKey string) *Client {
+func NewClient(cacheManager *cache.Manager, store *cache.Store, logger *log.Logger, apiKey string) *Client {
 	return &Client{
 		httpClient: &http.Client{
 			Timeout: requestTimeout,
 		},
 		cache:  cacheManager,
+		store:  store,
 		logger: logger,
 		apiKey: apiKey,
 	}
@@ -183,14 +195,14 @@ func (c *Client) APIKey() string {
 
 // apiKeyHash returns a short hash of the API key for cache keys
 func (c *Client) apiKeyHash() string {
-	hash := sha256.Sum256([]byte(c.apiKey))
+	hash := sha256.Sum256([]byte(c.APIKey()))
 	return fmt.Sprintf("%x", hash[:8])
 }
 
 // GetWallet retrieves wallet information for the configured API key
 func (c *Client) GetWallet(ctx context.Context) (*WalletInfo, error) {
-	if c.apiKey == "" {
-		return nil, fmt.Errorf("GW2_API_KEY environment variable not configured")
+	if c.APIKey() == "" {
+		return nil, errNoAPIKey
 	}
 
 	apiKeyHash := c.apiKeyHash()
@@ -206,7 +218,7 @@ func (c *Client) GetWallet(ctx context.Context) (*WalletInfo, error) {
 	c.logger.Debug("Wallet cache miss, fetching from API", "api_key_hash", apiKeyHash)
 
 	// Fetch wallet data from API
-	walletEntries, err := c.fetchWallet(ctx, c.apiKey)
+	walletEntries, err := c.fetchWallet(ctx, c.APIKey())
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch wallet: %w", err)
 	}
@@ -265,7 +277,7 @@ func (c *Client) GetCurrencies(ctx context.Context, ids []int) (map[int]Currency
 	// Fetch missing currencies from API
 	if len(missingIDs) > 0 {
 		fetchedCurrencies, err := c.fetchCurrencies(ctx, missingIDs)
-		if err != nil {
+		if err = ignorePartial(err); err != nil {
 			return nil, fmt.Errorf("failed to fetch currencies: %w", err)
 		}
 
@@ -337,10 +349,10 @@ func (c *Client) GetItems(ctx context.Context, ids []int) (map[int]Item, error)
 		}
 	}
 
-	// Fetch missing items from API
-	if len(missingIDs) > 0 {
-		fetchedItems, err := c.fetchItems(ctx, missingIDs)
-		if err != nil {
+	// Fetch missing items from API, respecting the per-request ID limit
+	for _, chunk := range chunkIDs(dedupeIDs(missingIDs), maxIDsPerRequest) {
+		fetchedItems, err := c.fetchItems(ctx, chunk)
+		if err = ignorePartial(err); err != nil {
 			return nil, fmt.Errorf("failed to fetch items: %w", err)
 		}
 
@@ -356,6 +368,88 @@ func (c *Client) GetItems(ctx context.Context, ids []int) (map[int]Item, error)
 	return items, nil
 }
 
+// DetailType returns the type-specific subtype from the item details (e.g. "Helm", "Greatsword", "Ring")
+func (i Item) DetailType() string {
+	if len(i.Details) == 0 {
+		return ""
+	}
+	var details struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(i.Details, &details); err != nil {
+		return ""
+	}
+	return details.Type
+}
+
+// DefaultSkin returns the skin an armor, weapon, back or gathering tool item applies, or 0
+func (i Item) DefaultSkin() int {
+	if len(i.Details) == 0 {
+		return 0
+	}
+	var details struct {
+		DefaultSkin int `json:"default_skin"`
+	}
+	if err := json.Unmarshal(i.Details, &details); err != nil {
+		return 0
+	}
+	return details.DefaultSkin
+}
+
+// Tradeable reports whether the item can be listed on the Trading Post
+func (i Item) Tradeable() bool {
+	for _, flag := range i.Flags {
+		if flag == "AccountBound" || flag == "SoulbindOnAcquire" {
+			return false
+		}
+	}
+	return true
+}
+
+// GetItemIDs retrieves the ID of every item
+func (c *Client) GetItemIDs(ctx context.Context) ([]int, error) {
+	cacheKey := c.cache.GetItemIDsKey()
+	var ids []int
+	if c.cache.GetJSON(cacheKey, &ids) {
+		return ids, nil
+	}
+
+	if err := c.fetchPublic(ctx, "/items", &ids); err != nil {
+		return nil, fmt.Errorf("failed to fetch item IDs: %w", err)
+	}
+
+	if err := c.cache.SetJSON(cacheKey, ids, cache.ItemDataTTL); err != nil {
+		c.logger.Warn("Failed to cache item IDs", "error", err)
+	}
+	return ids, nil
+}
+
+// dedupeIDs returns ids with duplicates removed, preserving order
+func dedupeIDs(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	result := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// chunkIDs splits ids into batches of at most size elements
+func chunkIDs(ids []int, size int) [][]int {
+	var chunks [][]int
+	for len(ids) > size {
+		chunks = append(chunks, ids[:size])
+		ids = ids[size:]
+	}
+	if len(ids) > 0 {
+		chunks = append(chunks, ids)
+	}
+	return chunks
+}
+
 // fetchWallet makes the actual API call to get wallet data
 func (c *Client) fetchWallet(ctx context.Context, apiKey string) ([]WalletEntry, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", baseURL+"/account/wallet", http.NoBody)
@@ -377,11 +471,7 @@ func (c *Client) fetchWallet(ctx context.Context, apiKey string) ([]WalletEntry,
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		body, readErr := io.ReadAll(resp.Body)
-		if readErr != nil {
-			return nil, fmt.Errorf("API request failed with status %d and failed to read body: %w", resp.StatusCode, readErr)
-		}
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, readAPIError(resp)
 	}
 
 	var wallet []WalletEntry
@@ -412,11 +502,7 @@ func (c *Client) fetchCurrencyIDs(ctx context.Context) ([]int, error) {
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		body, readErr := io.ReadAll(resp.Body)
-		if readErr != nil {
-			return nil, fmt.Errorf("API request failed with status %d and failed to read body: %w", resp.StatusCode, readErr)
-		}
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, readAPIError(resp)
 	}
 
 	var ids []int
@@ -453,17 +539,10 @@ func (c *Client) fetchCurrencies(ctx context.Context, ids []int) ([]Currency, er
 		}
 	}()
 
-	if resp.StatusCode != http.StatusOK {
-		body, readErr := io.ReadAll(resp.Body)
-		if readErr != nil {
-			return nil, fmt.Errorf("API request failed with status %d and failed to read body: %w", resp.StatusCode, readErr)
-		}
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
 	var currencies []Currency
-	if err := json.NewDecoder(resp.Body).Decode(&currencies); err != nil {
-		return nil, err
+	if err := decodeResponse(resp, &currencies); err != nil {
+		// A partial response still holds the IDs that exist
+		return currencies, err
 	}
 
 	return currencies, nil
@@ -494,17 +573,10 @@ func (c *Client) fetchItems(ctx context.Context, ids []int) ([]Item, error) {
 		}
 	}()
 
-	if resp.StatusCode != http.StatusOK {
-		body, readErr := io.ReadAll(resp.Body)
-		if readErr != nil {
-			return nil, fmt.Errorf("API request failed with status %d and failed to read body: %w", resp.StatusCode, readErr)
-		}
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
 	var items []Item
-	if err := json.NewDecoder(resp.Body).Decode(&items); err != nil {
-		return nil, err
+	if err := decodeResponse(resp, &items); err != nil {
+		// A partial response still holds the IDs that exist
+		return items, err
 	}
 
 	return items, nil
@@ -526,9 +598,9 @@ func (c *Client) GetPrices(ctx context.Context, itemIDs []int) ([]PriceInfo, err
 		}
 	}
 
-	// Fetch missing prices from API
-	if len(missingIDs) > 0 {
-		fetched, err := c.fetchPrices(ctx, missingIDs)
+	// Fetch missing prices from API, respecting the per-request ID limit
+	for _, chunk := range chunkIDs(dedupeIDs(missingIDs), maxIDsPerRequest) {
+		fetched, err := c.fetchPrices(ctx, chunk)
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch prices: %w", err)
 		}
@@ -594,12 +666,9 @@ func (c *Client) fetchPrices(ctx context.Context, ids []int) ([]PriceInfo, error
 		}
 	}()
 
-	if resp.StatusCode != http.StatusOK {
-		body, readErr := io.ReadAll(resp.Body)
-		if readErr != nil {
-			return nil, fmt.Errorf("API request failed with status %d and failed to read body: %w", resp.StatusCode, readErr)
-		}
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	// 206 means some IDs are not tradeable; the body still holds the valid ones
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		return nil, readAPIError(resp)
 	}
 
 	var prices []PriceInfo
@@ -627,7 +696,7 @@ func (c *Client) GetListings(ctx context.Context, itemIDs []int) ([]ListingInfo,
 
 	if len(missingIDs) > 0 {
 		fetched, err := c.fetchListings(ctx, missingIDs)
-		if err != nil {
+		if err = ignorePartial(err); err != nil {
 			return nil, fmt.Errorf("failed to fetch listings: %w", err)
 		}
 
@@ -685,17 +754,10 @@ func (c *Client) fetchListings(ctx context.Context, ids []int) ([]ListingInfo, e
 		}
 	}()
 
-	if resp.StatusCode != http.StatusOK {
-		body, readErr := io.ReadAll(resp.Body)
-		if readErr != nil {
-			return nil, fmt.Errorf("API request failed with status %d and failed to read body: %w", resp.StatusCode, readErr)
-		}
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
 	var listings []ListingInfo
-	if err := json.NewDecoder(resp.Body).Decode(&listings); err != nil {
-		return nil, err
+	if err := decodeResponse(resp, &listings); err != nil {
+		// A partial response still holds the IDs that exist
+		return listings, err
 	}
 
 	return listings, nil
@@ -754,11 +816,7 @@ func (c *Client) fetchGemExchange(ctx context.Context, direction string, quantit
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		body, readErr := io.ReadAll(resp.Body)
-		if readErr != nil {
-			return nil, fmt.Errorf("API request failed with status %d and failed to read body: %w", resp.StatusCode, readErr)
-		}
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, readAPIError(resp)
 	}
 
 	var rate ExchangeRate
@@ -771,8 +829,8 @@ func (c *Client) fetchGemExchange(ctx context.Context, direction string, quantit
 
 // GetDelivery retrieves trading post delivery box for the configured API key
 func (c *Client) GetDelivery(ctx context.Context) (*DeliveryInfo, error) {
-	if c.apiKey == "" {
-		return nil, fmt.Errorf("GW2_API_KEY environment variable not configured")
+	if c.APIKey() == "" {
+		return nil, errNoAPIKey
 	}
 
 	apiKeyHash := c.apiKeyHash()
@@ -786,7 +844,7 @@ func (c *Client) GetDelivery(ctx context.Context) (*DeliveryInfo, error) {
 
 	c.logger.Debug("TP delivery cache miss, fetching from API", "api_key_hash", apiKeyHash)
 
-	fetched, err := c.fetchDelivery(ctx, c.apiKey)
+	fetched, err := c.fetchDelivery(ctx, c.APIKey())
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch delivery: %w", err)
 	}
@@ -841,11 +899,7 @@ func (c *Client) fetchDelivery(ctx context.Context, apiKey string) (*DeliveryInf
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		body, readErr := io.ReadAll(resp.Body)
-		if readErr != nil {
-			return nil, fmt.Errorf("API request failed with status %d and failed to read body: %w", resp.StatusCode, readErr)
-		}
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, readAPIError(resp)
 	}
 
 	var delivery DeliveryInfo
@@ -866,8 +920,8 @@ var validTransactionTypes = map[string]bool{
 
 // GetTransactions retrieves trading post transactions for the configured API key
 func (c *Client) GetTransactions(ctx context.Context, txType string) (*TransactionList, error) {
-	if c.apiKey == "" {
-		return nil, fmt.Errorf("GW2_API_KEY environment variable not configured")
+	if c.APIKey() == "" {
+		return nil, errNoAPIKey
 	}
 
 	if !validTransactionTypes[txType] {
@@ -885,7 +939,7 @@ func (c *Client) GetTransactions(ctx context.Context, txType string) (*Transacti
 
 	c.logger.Debug("TP transactions cache miss, fetching from API", "api_key_hash", apiKeyHash, "type", txType)
 
-	transactions, err := c.fetchTransactions(ctx, c.apiKey, txType)
+	transactions, err := c.fetchTransactions(ctx, c.APIKey(), txType)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch transactions: %w", err)
 	}
@@ -949,11 +1003,7 @@ func (c *Client) fetchTransactions(ctx context.Context, apiKey string, txType st
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		body, readErr := io.ReadAll(resp.Body)
-		if readErr != nil {
-			return nil, fmt.Errorf("API request failed with status %d and failed to read body: %w", resp.StatusCode, readErr)
-		}
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, readAPIError(resp)
 	}
 
 	var transactions []Transaction
@@ -966,19 +1016,20 @@ func (c *Client) fetchTransactions(ctx context.Context, apiKey string, txType st
 
 // requireAPIKey checks that the API key is configured
 func (c *Client) requireAPIKey() error {
-	if c.apiKey == "" {
-		return fmt.Errorf("GW2_API_KEY environment variable not configured")
+	if c.APIKey() == "" {
+		return errNoAPIKey
 	}
 	return nil
 }
 
-// fetchAuthenticated performs an authenticated GET request and decodes JSON into dest
+// fetchAuthenticated performs an authenticated GET request and decodes JSON into dest.
+// A 206 response is decoded too and returned with an ErrPartial error.
 func (c *Client) fetchAuthenticated(ctx context.Context, path string, dest interface{}) error {
 	req, err := http.NewRequestWithContext(ctx, "GET", baseURL+path, http.NoBody)
 	if err != nil {
 		return err
 	}
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Authorization", "Bearer "+c.APIKey())
 	req.Header.Set("User-Agent", userAgent)
 
 	resp, err := c.httpClient.Do(req)
@@ -991,18 +1042,11 @@ func (c *Client) fetchAuthenticated(ctx context.Context, path string, dest inter
 		}
 	}()
 
-	if resp.StatusCode != http.StatusOK {
-		body, readErr := io.ReadAll(resp.Body)
-		if readErr != nil {
-			return fmt.Errorf("API request failed with status %d and failed to read body: %w", resp.StatusCode, readErr)
-		}
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	return json.NewDecoder(resp.Body).Decode(dest)
+	return decodeResponse(resp, dest)
 }
 
-// fetchPublic performs an unauthenticated GET request and decodes JSON into dest
+// fetchPublic performs an unauthenticated GET request and decodes JSON into dest.
+// A 206 response is decoded too and returned with an ErrPartial error.
 func (c *Client) fetchPublic(ctx context.Context, path string, dest interface{}) error {
 	req, err := http.NewRequestWithContext(ctx, "GET", baseURL+path, http.NoBody)
 	if err != nil {
@@ -1020,15 +1064,7 @@ func (c *Client) fetchPublic(ctx context.Context, path string, dest interface{})
 		}
 	}()
 
-	if resp.StatusCode != http.StatusOK {
-		body, readErr := io.ReadAll(resp.Body)
-		if readErr != nil {
-			return fmt.Errorf("API request failed with status %d and failed to read body: %w", resp.StatusCode, readErr)
-		}
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	return json.NewDecoder(resp.Body).Decode(dest)
+	return decodeResponse(resp, dest)
 }
 
 // fetchPublicRaw performs an unauthenticated GET request and returns raw JSON
@@ -1049,19 +1085,7 @@ func (c *Client) fetchPublicRaw(ctx context.Context, path string) (json.RawMessa
 		}
 	}()
 
-	if resp.StatusCode != http.StatusOK {
-		body, readErr := io.ReadAll(resp.Body)
-		if readErr != nil {
-			return nil, fmt.Errorf("API request failed with status %d and failed to read body: %w", resp.StatusCode, readErr)
-		}
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-	return json.RawMessage(body), nil
+	return readResponse(resp)
 }
 
 // fetchAuthenticatedRaw performs an authenticated GET request and returns raw JSON
@@ -1070,7 +1094,7 @@ func (c *Client) fetchAuthenticatedRaw(ctx context.Context, path string) (json.R
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Authorization", "Bearer "+c.APIKey())
 	req.Header.Set("User-Agent", userAgent)
 
 	resp, err := c.httpClient.Do(req)
@@ -1083,19 +1107,7 @@ func (c *Client) fetchAuthenticatedRaw(ctx context.Context, path string) (json.R
 		}
 	}()
 
-	if resp.StatusCode != http.StatusOK {
-		body, readErr := io.ReadAll(resp.Body)
-		if readErr != nil {
-			return nil, fmt.Errorf("API request failed with status %d and failed to read body: %w", resp.StatusCode, readErr)
-		}
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-	return json.RawMessage(body), nil
+	return readResponse(resp)
 }
 
 // idsToParam converts a slice of ints to a comma-separated string for API queries
@@ -1406,6 +1418,7 @@ type CharacterInfo struct {
 	Training        json.RawMessage      `json:"training,omitempty"`
 	BuildTabs       json.RawMessage      `json:"build_tabs,omitempty"`
 	EquipmentTabs   json.RawMessage      `json:"equipment_tabs,omitempty"`
+	Bags            []*CharacterBag      `json:"bags,omitempty"`
 	Flags           []string             `json:"flags,omitempty"`
 }
 
@@ -1421,226 +1434,840 @@ func (c *Client) GetCharacter(ctx context.Context, name string) (*CharacterInfo,
 		return &info, nil
 	}
 
-	if err := c.fetchAuthenticated(ctx, "/characters/"+name, &info); err != nil {
+	if err := c.fetchAuthenticated(ctx, "/characters/"+url.PathEscape(name), &info); err != nil {
 		return nil, fmt.Errorf("failed to fetch character %q: %w", name, err)
 	}
 
+	c.enrichBagItemNames(ctx, info.Bags)
+
 	if err := c.cache.SetJSON(cacheKey, info, cache.AccountDataTTL); err != nil {
 		c.logger.Warn("Failed to cache character data", "name", name, "error", err)
 	}
 	return &info, nil
 }
 
-// --- Phase 3: Account Unlocks ---
+// BagSlot represents a single slot inside a character bag
+type BagSlot struct {
+	ID        int             `json:"id"`
+	Count     int             `json:"count"`
+	Charges   int             `json:"charges,omitempty"`
+	Skin      int             `json:"skin,omitempty"`
+	Binding   string          `json:"binding,omitempty"`
+	BoundTo   string          `json:"bound_to,omitempty"`
+	Upgrades  []int           `json:"upgrades,omitempty"`
+	Infusions []int           `json:"infusions,omitempty"`
+	Dyes      []int           `json:"dyes,omitempty"`
+	Stats     json.RawMessage `json:"stats,omitempty"`
+	ItemName  string          `json:"item_name,omitempty"`
+}
 
-var validUnlockTypes = map[string]bool{
-	"skins": true, "dyes": true, "minis": true, "titles": true,
-	"recipes": true, "finishers": true, "outfits": true, "gliders": true,
-	"mailcarriers": true, "novelties": true, "emotes": true,
-	"mounts/skins": true, "mounts/types": true, "skiffs": true, "jadebots": true,
+// CharacterBag represents an equipped bag and its contents
+type CharacterBag struct {
+	ID        int        `json:"id"`
+	Size      int        `json:"size"`
+	Inventory []*BagSlot `json:"inventory"`
+	ItemName  string     `json:"item_name,omitempty"`
+}
+
+// CharacterInventory represents the bags of a character from /v2/characters/:id/inventory
+type CharacterInventory struct {
+	Character string          `json:"character"`
+	Bags      []*CharacterBag `json:"bags"`
+	UsedSlots int             `json:"used_slots"`
+	UpdatedAt time.Time       `json:"updated_at"`
 }
 
-// GetAccountUnlocks retrieves unlocked IDs for the given unlock type
-func (c *Client) GetAccountUnlocks(ctx context.Context, unlockType string) (json.RawMessage, error) {
+// GetCharacterInventory retrieves the bags and bag contents of a character
+func (c *Client) GetCharacterInventory(ctx context.Context, name string) (*CharacterInventory, error) {
 	if err := c.requireAPIKey(); err != nil {
 		return nil, err
 	}
 
-	if !validUnlockTypes[unlockType] {
-		return nil, fmt.Errorf("invalid unlock type %q", unlockType)
+	cacheKey := c.cache.GetCharacterInventoryKey(c.apiKeyHash(), name)
+	var info CharacterInventory
+	if c.cache.GetJSON(cacheKey, &info) {
+		return &info, nil
 	}
 
-	cacheKey := c.cache.GetUnlocksKey(c.apiKeyHash(), unlockType)
-	var cached json.RawMessage
-	if c.cache.GetJSON(cacheKey, &cached) {
-		return cached, nil
+	var resp struct {
+		Bags []*CharacterBag `json:"bags"`
+	}
+	if err := c.fetchAuthenticated(ctx, "/characters/"+url.PathEscape(name)+"/inventory", &resp); err != nil {
+		return nil, fmt.Errorf("failed to fetch inventory for character %q: %w", name, err)
 	}
 
-	data, err := c.fetchAuthenticatedRaw(ctx, "/account/"+unlockType)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch unlocks for %s: %w", unlockType, err)
+	c.enrichBagItemNames(ctx, resp.Bags)
+
+	used := 0
+	for _, bag := range resp.Bags {
+		if bag == nil {
+			continue
+		}
+		for _, slot := range bag.Inventory {
+			if slot != nil {
+				used++
+			}
+		}
 	}
 
-	if err := c.cache.SetJSON(cacheKey, data, cache.UnlocksTTL); err != nil {
-		c.logger.Warn("Failed to cache unlocks", "type", unlockType, "error", err)
+	info = CharacterInventory{Character: name, Bags: resp.Bags, UsedSlots: used, UpdatedAt: time.Now()}
+	if err := c.cache.SetJSON(cacheKey, info, cache.AccountDataTTL); err != nil {
+		c.logger.Warn("Failed to cache character inventory", "name", name, "error", err)
 	}
-	return data, nil
+	return &info, nil
 }
 
-// --- Phase 4: Account Progress ---
+// enrichBagItemNames resolves item names for bags and their slots in place
+func (c *Client) enrichBagItemNames(ctx context.Context, bags []*CharacterBag) {
+	var itemIDs []int
+	for _, bag := range bags {
+		if bag == nil {
+			continue
+		}
+		itemIDs = append(itemIDs, bag.ID)
+		for _, slot := range bag.Inventory {
+			if slot != nil {
+				itemIDs = append(itemIDs, slot.ID)
+			}
+		}
+	}
+	if len(itemIDs) == 0 {
+		return
+	}
 
-var validProgressTypes = map[string]bool{
-	"achievements": true, "masteries": true, "mastery/points": true,
-	"luck": true, "legendaryarmory": true, "progression": true,
+	items, err := c.GetItems(ctx, itemIDs)
+	if err != nil {
+		c.logger.Warn("Failed to get item metadata for character bags", "error", err)
+		return
+	}
+	for _, bag := range bags {
+		if bag == nil {
+			continue
+		}
+		if item, ok := items[bag.ID]; ok {
+			bag.ItemName = item.Name
+		}
+		for _, slot := range bag.Inventory {
+			if slot != nil {
+				if item, ok := items[slot.ID]; ok {
+					slot.ItemName = item.Name
+				}
+			}
+		}
+	}
+}
+
+// EquipmentItem represents an equipped item from /v2/characters/:id/equipment
+type EquipmentItem struct {
+	ID        int             `json:"id"`
+	Slot      string          `json:"slot,omitempty"`
+	Location  string          `json:"location,omitempty"`
+	Count     int             `json:"count,omitempty"`
+	Charges   int             `json:"charges,omitempty"`
+	Skin      int             `json:"skin,omitempty"`
+	Binding   string          `json:"binding,omitempty"`
+	BoundTo   string          `json:"bound_to,omitempty"`
+	Upgrades  []int           `json:"upgrades,omitempty"`
+	Infusions []int           `json:"infusions,omitempty"`
+	Dyes      []int           `json:"dyes,omitempty"`
+	Tabs      []int           `json:"tabs,omitempty"`
+	Stats     json.RawMessage `json:"stats,omitempty"`
+	ItemName  string          `json:"item_name,omitempty"`
+}
+
+// CharacterEquipment represents the equipped items of a character
+type CharacterEquipment struct {
+	Character string          `json:"character"`
+	Equipment []EquipmentItem `json:"equipment"`
+	UpdatedAt time.Time       `json:"updated_at"`
 }
 
-// GetAccountProgress retrieves account progress data for the given type
-func (c *Client) GetAccountProgress(ctx context.Context, progressType string) (json.RawMessage, error) {
+// GetCharacterEquipment retrieves the equipped items of a character across all equipment tabs
+func (c *Client) GetCharacterEquipment(ctx context.Context, name string) (*CharacterEquipment, error) {
 	if err := c.requireAPIKey(); err != nil {
 		return nil, err
 	}
 
-	if !validProgressTypes[progressType] {
-		return nil, fmt.Errorf("invalid progress type %q", progressType)
+	cacheKey := c.cache.GetCharacterEquipmentKey(c.apiKeyHash(), name)
+	var info CharacterEquipment
+	if c.cache.GetJSON(cacheKey, &info) {
+		return &info, nil
 	}
 
-	cacheKey := c.cache.GetProgressKey(c.apiKeyHash(), progressType)
-	var cached json.RawMessage
-	if c.cache.GetJSON(cacheKey, &cached) {
-		return cached, nil
+	var resp struct {
+		Equipment []EquipmentItem `json:"equipment"`
+	}
+	if err := c.fetchAuthenticated(ctx, "/characters/"+url.PathEscape(name)+"/equipment", &resp); err != nil {
+		return nil, fmt.Errorf("failed to fetch equipment for character %q: %w", name, err)
 	}
 
-	data, err := c.fetchAuthenticatedRaw(ctx, "/account/"+progressType)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch progress for %s: %w", progressType, err)
+	// Enrich with item names
+	if len(resp.Equipment) > 0 {
+		itemIDs := make([]int, len(resp.Equipment))
+		for i, eq := range resp.Equipment {
+			itemIDs[i] = eq.ID
+		}
+		items, err := c.GetItems(ctx, itemIDs)
+		if err != nil {
+			c.logger.Warn("Failed to get item metadata for equipment", "error", err)
+		} else {
+			for i, eq := range resp.Equipment {
+				if item, ok := items[eq.ID]; ok {
+					resp.Equipment[i].ItemName = item.Name
+				}
+			}
+		}
 	}
 
-	if err := c.cache.SetJSON(cacheKey, data, cache.ProgressTTL); err != nil {
-		c.logger.Warn("Failed to cache progress", "type", progressType, "error", err)
+	info = CharacterEquipment{Character: name, Equipment: resp.Equipment, UpdatedAt: time.Now()}
+	if err := c.cache.SetJSON(cacheKey, info, cache.AccountDataTTL); err != nil {
+		c.logger.Warn("Failed to cache character equipment", "name", name, "error", err)
 	}
-	return data, nil
+	return &info, nil
 }
 
-// --- Phase 5: Account Dailies ---
+// --- Phase 3: Account Unlocks ---
 
-var validDailyTypes = map[string]bool{
-	"dailycrafting": true, "dungeons": true, "raids": true,
-	"mapchests": true, "worldbosses": true,
+var validUnlockTypes = map[string]bool{
+	"skins": true, "dyes": true, "minis": true, "titles": true,
+	"recipes": true, "finishers": true, "outfits": true, "gliders": true,
+	"mailcarriers": true, "novelties": true, "emotes": true,
+	"mounts/skins": true, "mounts/types": true, "skiffs": true, "jadebots": true,
 }
 
-// GetAccountDailies retrieves completed daily IDs/names for the given type
-func (c *Client) GetAccountDailies(ctx context.Context, dailyType string) (json.RawMessage, error) {
+// UnlockTypes returns the unlock types GetAccountUnlocks accepts, sorted
+func UnlockTypes() []string {
+	return slices.Sorted(maps.Keys(validUnlockTypes))
+}
+
+// fetchAccountUnlocks retrieves the raw unlocked IDs for the given unlock type
+func (c *Client) fetchAccountUnlocks(ctx context.Context, unlockType string) (json.RawMessage, error) {
 	if err := c.requireAPIKey(); err != nil {
 		return nil, err
 	}
 
-	if !validDailyTypes[dailyType] {
-		return nil, fmt.Errorf("invalid daily type %q", dailyType)
+	if !validUnlockTypes[unlockType] {
+		return nil, fmt.Errorf("invalid unlock type %q", unlockType)
 	}
 
-	cacheKey := c.cache.GetDailiesKey(c.apiKeyHash(), dailyType)
+	cacheKey := c.cache.GetUnlocksKey(c.apiKeyHash(), unlockType)
 	var cached json.RawMessage
 	if c.cache.GetJSON(cacheKey, &cached) {
 		return cached, nil
 	}
 
-	data, err := c.fetchAuthenticatedRaw(ctx, "/account/"+dailyType)
+	data, err := c.fetchAuthenticatedRaw(ctx, "/account/"+unlockType)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch dailies for %s: %w", dailyType, err)
+		return nil, fmt.Errorf("failed to fetch unlocks for %s: %w", unlockType, err)
 	}
 
-	if err := c.cache.SetJSON(cacheKey, data, cache.DailiesTTL); err != nil {
-		c.logger.Warn("Failed to cache dailies", "type", dailyType, "error", err)
+	if err := c.cache.SetJSON(cacheKey, data, cache.UnlocksTTL); err != nil {
+		c.logger.Warn("Failed to cache unlocks", "type", unlockType, "error", err)
 	}
 	return data, nil
 }
 
-// --- Phase 6: Wizard's Vault ---
+// unlockNameEndpoints maps unlock types to the public endpoint that names their IDs.
+// Emotes and mount types are unlocked by name already, and recipes have no names.
+var unlockNameEndpoints = map[string]string{
+	"skins": "skins", "dyes": "colors", "minis": "minis", "titles": "titles",
+	"finishers": "finishers", "outfits": "outfits", "gliders": "gliders",
+	"mailcarriers": "mailcarriers", "novelties": "novelties", "mounts/skins": "mounts/skins",
+	"skiffs": "skiffs", "jadebots": "jadebots",
+}
 
-// GetWizardsVault retrieves current wizard's vault season info
-func (c *Client) GetWizardsVault(ctx context.Context) (json.RawMessage, error) {
-	cacheKey := c.cache.GetWizardsVaultSeasonKey()
-	var cached json.RawMessage
-	if c.cache.GetJSON(cacheKey, &cached) {
-		return cached, nil
-	}
+// UnlockedFinisher is an entry of /v2/account/finishers
+type UnlockedFinisher struct {
+	ID        int  `json:"id"`
+	Permanent bool `json:"permanent"`
+	Quantity  int  `json:"quantity,omitempty"`
+}
 
-	data, err := c.fetchPublicRaw(ctx, "/wizardsvault")
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch wizard's vault season: %w", err)
-	}
+// AccountUnlock is one unlocked skin, dye, mini or other collectible
+type AccountUnlock struct {
+	ID int `json:"id,omitempty"`
+	// Key is set instead of ID for emotes and mount types, which are unlocked by name
+	Key  string `json:"key,omitempty"`
+	Name string `json:"name,omitempty"`
+	// Permanent and Quantity are set for finishers, which can also be unlocked for a
+	// number of uses
+	Permanent *bool `json:"permanent,omitempty"`
+	Quantity  int   `json:"quantity,omitempty"`
+}
 
-	if err := c.cache.SetJSON(cacheKey, data, cache.WVSeasonTTL); err != nil {
-		c.logger.Warn("Failed to cache wizard's vault season", "error", err)
-	}
-	return data, nil
+// AccountUnlocks is everything the account has unlocked of one type
+type AccountUnlocks struct {
+	Type    string          `json:"type"`
+	Count   int             `json:"count"`
+	Unlocks []AccountUnlock `json:"unlocks"`
 }
 
-var validWVObjectiveTypes = map[string]bool{
-	"daily": true, "weekly": true, "special": true,
+// decodeAccountUnlocks decodes an /v2/account unlock list, whose entries are IDs, names or,
+// for finishers, objects
+func decodeAccountUnlocks(unlockType string, data json.RawMessage) ([]AccountUnlock, error) {
+	var unlocks []AccountUnlock
+	switch unlockType {
+	case "finishers":
+		var finishers []UnlockedFinisher
+		if err := json.Unmarshal(data, &finishers); err != nil {
+			return nil, fmt.Errorf("failed to decode %s unlocks: %w", unlockType, err)
+		}
+		for _, f := range finishers {
+			unlocks = append(unlocks, AccountUnlock{ID: f.ID, Permanent: &f.Permanent, Quantity: f.Quantity})
+		}
+	case "emotes", "mounts/types":
+		var keys []string
+		if err := json.Unmarshal(data, &keys); err != nil {
+			return nil, fmt.Errorf("failed to decode %s unlocks: %w", unlockType, err)
+		}
+		for _, key := range keys {
+			unlocks = append(unlocks, AccountUnlock{Key: key})
+		}
+	default:
+		var ids []int
+		if err := json.Unmarshal(data, &ids); err != nil {
+			return nil, fmt.Errorf("failed to decode %s unlocks: %w", unlockType, err)
+		}
+		for _, id := range ids {
+			unlocks = append(unlocks, AccountUnlock{ID: id})
+		}
+	}
+	return unlocks, nil
 }
 
-// GetWizardsVaultObjectives retrieves wizard's vault objectives
-func (c *Client) GetWizardsVaultObjectives(ctx context.Context, objType string) (json.RawMessage, error) {
-	if !validWVObjectiveTypes[objType] {
-		return nil, fmt.Errorf("invalid wizard's vault objective type %q: must be daily, weekly, or special", objType)
+// GetAccountUnlocks retrieves everything unlocked of the given type, with names
+func (c *Client) GetAccountUnlocks(ctx context.Context, unlockType string) (*AccountUnlocks, error) {
+	data, err := c.fetchAccountUnlocks(ctx, unlockType)
+	if err != nil {
+		return nil, err
+	}
+	unlocks, err := decodeAccountUnlocks(unlockType, data)
+	if err != nil {
+		return nil, err
 	}
 
-	// Use authenticated endpoint if API key available
-	if c.apiKey != "" {
-		keyHash := c.apiKeyHash()
-		cacheKey := c.cache.GetWizardsVaultObjectivesKey(keyHash, objType)
-		var cached json.RawMessage
-		if c.cache.GetJSON(cacheKey, &cached) {
-			return cached, nil
+	if endpoint, ok := unlockNameEndpoints[unlockType]; ok && len(unlocks) > 0 {
+		ids := make([]int, len(unlocks))
+		for i, u := range unlocks {
+			ids[i] = u.ID
 		}
-
-		data, err := c.fetchAuthenticatedRaw(ctx, "/account/wizardsvault/"+objType)
+		names, err := c.getNames(ctx, endpoint, ids)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch wizard's vault objectives: %w", err)
+			c.logger.Warn("Failed to get unlock names", "type", unlockType, "error", err)
 		}
-
-		if err := c.cache.SetJSON(cacheKey, data, cache.WVObjectivesAuthTTL); err != nil {
-			c.logger.Warn("Failed to cache wizard's vault objectives", "error", err)
+		for i := range unlocks {
+			unlocks[i].Name = names[unlocks[i].ID]
 		}
-		return data, nil
 	}
 
-	// Fall back to public endpoint
-	cacheKey := c.cache.GetWizardsVaultObjectivesKey("public", objType)
-	var cached json.RawMessage
-	if c.cache.GetJSON(cacheKey, &cached) {
-		return cached, nil
-	}
+	return &AccountUnlocks{Type: unlockType, Count: len(unlocks), Unlocks: unlocks}, nil
+}
 
-	// Public endpoint returns all objectives, not type-specific
-	data, err := c.fetchPublicRaw(ctx, "/wizardsvault/objectives")
+// GetAccountUnlockIDs retrieves the set of IDs unlocked of the given type, without names
+func (c *Client) GetAccountUnlockIDs(ctx context.Context, unlockType string) (map[int]bool, error) {
+	data, err := c.fetchAccountUnlocks(ctx, unlockType)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch wizard's vault objectives: %w", err)
+		return nil, err
+	}
+	unlocks, err := decodeAccountUnlocks(unlockType, data)
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[int]bool, len(unlocks))
+	for _, u := range unlocks {
+		set[u.ID] = true
 	}
+	return set, nil
+}
 
-	if err := c.cache.SetJSON(cacheKey, data, cache.WVObjectivesPublicTTL); err != nil {
-		c.logger.Warn("Failed to cache wizard's vault objectives", "error", err)
+// namedEntry is the ID and name every named metadata endpoint returns
+type namedEntry struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// getNames looks up the names of IDs from a public metadata endpoint such as "titles".
+// Each endpoint's names are cached together, so only IDs not seen before are fetched. On
+// error the names found so far are returned with it.
+func (c *Client) getNames(ctx context.Context, endpoint string, ids []int) (map[int]string, error) {
+	cacheKey := c.cache.GetNamesKey(endpoint)
+	names := make(map[int]string)
+	c.cache.GetJSON(cacheKey, &names)
+
+	var missingIDs []int
+	for _, id := range dedupeIDs(ids) {
+		if _, ok := names[id]; !ok {
+			missingIDs = append(missingIDs, id)
+		}
+	}
+	if len(missingIDs) == 0 {
+		return names, nil
+	}
+
+	var fetchErr error
+	for _, chunk := range chunkIDs(missingIDs, maxIDsPerRequest) {
+		var batch []namedEntry
+		if err := ignorePartial(c.fetchPublic(ctx, "/"+endpoint+"?ids="+idsToParam(chunk), &batch)); err != nil {
+			fetchErr = fmt.Errorf("failed to fetch %s names: %w", endpoint, err)
+			continue
+		}
+		for _, entry := range batch {
+			names[entry.ID] = entry.Name
+		}
+	}
+
+	if err := c.cache.SetJSON(cacheKey, names, cache.NameDataTTL); err != nil {
+		c.logger.Warn("Failed to cache names", "endpoint", endpoint, "error", err)
+	}
+	return names, fetchErr
+}
+
+// --- Phase 4: Account Progress ---
+
+var validProgressTypes = map[string]bool{
+	"achievements": true, "masteries": true, "mastery/points": true,
+	"luck": true, "legendaryarmory": true, "progression": true,
+}
+
+// ProgressTypes returns the progress types GetAccountProgress accepts, sorted
+func ProgressTypes() []string {
+	return slices.Sorted(maps.Keys(validProgressTypes))
+}
+
+// fetchAccountProgress retrieves raw account progress data for the given type
+func (c *Client) fetchAccountProgress(ctx context.Context, progressType string) (json.RawMessage, error) {
+	if err := c.requireAPIKey(); err != nil {
+		return nil, err
+	}
+
+	if !validProgressTypes[progressType] {
+		return nil, fmt.Errorf("invalid progress type %q", progressType)
+	}
+
+	cacheKey := c.cache.GetProgressKey(c.apiKeyHash(), progressType)
+	var cached json.RawMessage
+	if c.cache.GetJSON(cacheKey, &cached) {
+		return cached, nil
+	}
+
+	data, err := c.fetchAuthenticatedRaw(ctx, "/account/"+progressType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch progress for %s: %w", progressType, err)
+	}
+
+	if err := c.cache.SetJSON(cacheKey, data, cache.ProgressTTL); err != nil {
+		c.logger.Warn("Failed to cache progress", "type", progressType, "error", err)
 	}
 	return data, nil
 }
 
-// GetWizardsVaultListings retrieves wizard's vault reward listings
-func (c *Client) GetWizardsVaultListings(ctx context.Context) (json.RawMessage, error) {
-	if c.apiKey != "" {
-		keyHash := c.apiKeyHash()
-		cacheKey := c.cache.GetWizardsVaultListingsKey(keyHash)
-		var cached json.RawMessage
-		if c.cache.GetJSON(cacheKey, &cached) {
-			return cached, nil
-		}
+// AccountProgression is one account-wide progression value, such as fractal agony
+// impedance or luck
+type AccountProgression struct {
+	ID    string `json:"id"`
+	Value int    `json:"value"`
+}
+
+// AccountProgress is the account's progress of one type; only the field for that type is set
+type AccountProgress struct {
+	Type            string                `json:"type"`
+	Achievements    []AccountAchievement  `json:"achievements,omitempty"`
+	Masteries       []AccountMastery      `json:"masteries,omitempty"`
+	MasteryPoints   *AccountMasteryPoints `json:"mastery_points,omitempty"`
+	LegendaryArmory []AccountLegendary    `json:"legendary_armory,omitempty"`
+	// Progression holds the luck and progression types
+	Progression []AccountProgression `json:"progression,omitempty"`
+}
 
-		data, err := c.fetchAuthenticatedRaw(ctx, "/account/wizardsvault/listings")
+// GetAccountProgress retrieves the account's progress of the given type, with names
+func (c *Client) GetAccountProgress(ctx context.Context, progressType string) (*AccountProgress, error) {
+	progress := AccountProgress{Type: progressType}
+	var err error
+	switch progressType {
+	case "achievements":
+		if progress.Achievements, err = c.GetAccountAchievements(ctx); err != nil {
+			return nil, err
+		}
+		ids := make([]int, len(progress.Achievements))
+		for i, a := range progress.Achievements {
+			ids[i] = a.ID
+		}
+		names, err := c.getNames(ctx, "achievements", ids)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch wizard's vault listings: %w", err)
+			c.logger.Warn("Failed to get achievement names", "error", err)
 		}
-
-		if err := c.cache.SetJSON(cacheKey, data, cache.WVListingsTTL); err != nil {
-			c.logger.Warn("Failed to cache wizard's vault listings", "error", err)
+		for i := range progress.Achievements {
+			progress.Achievements[i].Name = names[progress.Achievements[i].ID]
+		}
+	case "masteries":
+		if progress.Masteries, err = c.GetAccountMasteries(ctx); err != nil {
+			return nil, err
 		}
-		return data, nil
+		masteries, err := c.GetMasteries(ctx)
+		if err != nil {
+			c.logger.Warn("Failed to get mastery names", "error", err)
+		}
+		tracks := make(map[int]Mastery, len(masteries))
+		for _, m := range masteries {
+			tracks[m.ID] = m
+		}
+		for i := range progress.Masteries {
+			track := tracks[progress.Masteries[i].ID]
+			progress.Masteries[i].Name = track.Name
+			progress.Masteries[i].Region = track.Region
+		}
+	case "mastery/points":
+		if progress.MasteryPoints, err = c.GetAccountMasteryPoints(ctx); err != nil {
+			return nil, err
+		}
+	case "legendaryarmory":
+		if progress.LegendaryArmory, err = c.GetAccountLegendaryArmory(ctx); err != nil {
+			return nil, err
+		}
+		ids := make([]int, len(progress.LegendaryArmory))
+		for i, l := range progress.LegendaryArmory {
+			ids[i] = l.ID
+		}
+		if len(ids) > 0 {
+			items, err := c.GetItems(ctx, ids)
+			if err != nil {
+				c.logger.Warn("Failed to get item metadata for legendary armory", "error", err)
+			}
+			for i := range progress.LegendaryArmory {
+				progress.LegendaryArmory[i].Name = items[progress.LegendaryArmory[i].ID].Name
+			}
+		}
+	case "luck", "progression":
+		data, err := c.fetchAccountProgress(ctx, progressType)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &progress.Progression); err != nil {
+			return nil, fmt.Errorf("failed to decode %s progress: %w", progressType, err)
+		}
+	default:
+		return nil, fmt.Errorf("invalid progress type %q", progressType)
+	}
+	return &progress, nil
+}
+
+// LegendaryArmoryEntry represents a legendary item that can be stored in the armory
+type LegendaryArmoryEntry struct {
+	ID       int `json:"id"`
+	MaxCount int `json:"max_count"`
+}
+
+// GetLegendaryArmory retrieves every item that can be stored in the Legendary Armory
+func (c *Client) GetLegendaryArmory(ctx context.Context) ([]LegendaryArmoryEntry, error) {
+	cacheKey := c.cache.GetLegendaryArmoryKey()
+	var entries []LegendaryArmoryEntry
+	if c.cache.GetJSON(cacheKey, &entries) {
+		return entries, nil
+	}
+
+	if err := c.fetchPublic(ctx, "/legendaryarmory?ids=all", &entries); err != nil {
+		return nil, fmt.Errorf("failed to fetch legendary armory: %w", err)
+	}
+
+	if err := c.cache.SetJSON(cacheKey, entries, cache.ItemDataTTL); err != nil {
+		c.logger.Warn("Failed to cache legendary armory", "error", err)
+	}
+	return entries, nil
+}
+
+// AccountLegendary represents a legendary unlocked in the account's armory
+type AccountLegendary struct {
+	ID    int    `json:"id"`
+	Count int    `json:"count"`
+	Name  string `json:"name,omitempty"`
+}
+
+// GetAccountLegendaryArmory retrieves the legendaries unlocked in the account's armory
+func (c *Client) GetAccountLegendaryArmory(ctx context.Context) ([]AccountLegendary, error) {
+	data, err := c.fetchAccountProgress(ctx, "legendaryarmory")
+	if err != nil {
+		return nil, err
+	}
+
+	var owned []AccountLegendary
+	if err := json.Unmarshal(data, &owned); err != nil {
+		return nil, fmt.Errorf("failed to decode legendary armory progress: %w", err)
+	}
+	return owned, nil
+}
+
+// --- Phase 5: Account Dailies ---
+
+var validDailyTypes = map[string]bool{
+	"dailycrafting": true, "dungeons": true, "raids": true,
+	"mapchests": true, "worldbosses": true,
+}
+
+// DailyTypes returns the daily types GetAccountDailies accepts, sorted
+func DailyTypes() []string {
+	return slices.Sorted(maps.Keys(validDailyTypes))
+}
+
+// fetchAccountDailies retrieves the raw completed daily IDs for the given type
+func (c *Client) fetchAccountDailies(ctx context.Context, dailyType string) (json.RawMessage, error) {
+	if err := c.requireAPIKey(); err != nil {
+		return nil, err
+	}
+
+	if !validDailyTypes[dailyType] {
+		return nil, fmt.Errorf("invalid daily type %q", dailyType)
 	}
 
-	cacheKey := c.cache.GetWizardsVaultListingsKey("public")
+	cacheKey := c.cache.GetDailiesKey(c.apiKeyHash(), dailyType)
 	var cached json.RawMessage
 	if c.cache.GetJSON(cacheKey, &cached) {
 		return cached, nil
 	}
 
-	data, err := c.fetchPublicRaw(ctx, "/wizardsvault/listings")
+	data, err := c.fetchAuthenticatedRaw(ctx, "/account/"+dailyType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch dailies for %s: %w", dailyType, err)
+	}
+
+	if err := c.cache.SetJSON(cacheKey, data, cache.DailiesTTL); err != nil {
+		c.logger.Warn("Failed to cache dailies", "type", dailyType, "error", err)
+	}
+	return data, nil
+}
+
+// AccountDailies is the daily or weekly content of one type the account has completed
+type AccountDailies struct {
+	Type      string   `json:"type"`
+	Completed []string `json:"completed"`
+	// Remaining is set for types with a public list of every entry: dailycrafting,
+	// mapchests and worldbosses
+	Remaining []string `json:"remaining,omitempty"`
+}
+
+// GetAccountDailies retrieves the completed entries of the given daily type and, where
+// every entry is known, the entries still to do
+func (c *Client) GetAccountDailies(ctx context.Context, dailyType string) (*AccountDailies, error) {
+	data, err := c.fetchAccountDailies(ctx, dailyType)
+	if err != nil {
+		return nil, err
+	}
+	dailies := AccountDailies{Type: dailyType, Completed: []string{}}
+	if err := json.Unmarshal(data, &dailies.Completed); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", dailyType, err)
+	}
+
+	if validDailyCatalogTypes[dailyType] {
+		catalog, err := c.GetDailyCatalog(ctx, dailyType)
+		if err != nil {
+			c.logger.Warn("Failed to get daily catalog", "type", dailyType, "error", err)
+		}
+		for _, id := range catalog {
+			if !slices.Contains(dailies.Completed, id) {
+				dailies.Remaining = append(dailies.Remaining, id)
+			}
+		}
+	}
+	return &dailies, nil
+}
+
+// validDailyCatalogTypes lists the daily types with a public list of every possible entry
+var validDailyCatalogTypes = map[string]bool{
+	"dailycrafting": true, "mapchests": true, "worldbosses": true,
+}
+
+// GetDailyCatalog retrieves every possible entry for a daily type, such as all world boss
+// IDs, so completed entries from GetAccountDailies can be compared against the full set
+func (c *Client) GetDailyCatalog(ctx context.Context, dailyType string) ([]string, error) {
+	if !validDailyCatalogTypes[dailyType] {
+		return nil, fmt.Errorf("invalid daily catalog type %q", dailyType)
+	}
+
+	cacheKey := c.cache.GetDailyCatalogKey(dailyType)
+	var ids []string
+	if c.cache.GetJSON(cacheKey, &ids) {
+		return ids, nil
+	}
+
+	if err := c.fetchPublic(ctx, "/"+dailyType, &ids); err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", dailyType, err)
+	}
+
+	if err := c.cache.SetJSON(cacheKey, ids, cache.DailyCatalogTTL); err != nil {
+		c.logger.Warn("Failed to cache daily catalog", "type", dailyType, "error", err)
+	}
+	return ids, nil
+}
+
+// --- Phase 6: Wizard's Vault ---
+
+// WizardsVaultSeason represents the current Wizard's Vault season from /v2/wizardsvault
+type WizardsVaultSeason struct {
+	Title      string    `json:"title"`
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	Listings   []int     `json:"listings"`
+	Objectives []int     `json:"objectives"`
+}
+
+// WizardsVaultObjective represents a Wizard's Vault objective. Progress fields are only
+// present on the authenticated endpoint.
+type WizardsVaultObjective struct {
+	ID               int    `json:"id"`
+	Title            string `json:"title"`
+	Track            string `json:"track"`
+	Acclaim          int    `json:"acclaim"`
+	ProgressCurrent  int    `json:"progress_current,omitempty"`
+	ProgressComplete int    `json:"progress_complete,omitempty"`
+	Claimed          bool   `json:"claimed,omitempty"`
+}
+
+// Done reports whether the objective's progress is complete
+func (o WizardsVaultObjective) Done() bool {
+	return o.ProgressComplete > 0 && o.ProgressCurrent >= o.ProgressComplete
+}
+
+// WizardsVaultProgress represents the account's objectives for a period with the meta
+// reward for completing enough of them. Without an API key, only Objectives is set and
+// it lists every objective in the season.
+type WizardsVaultProgress struct {
+	MetaProgressCurrent  int                     `json:"meta_progress_current,omitempty"`
+	MetaProgressComplete int                     `json:"meta_progress_complete,omitempty"`
+	MetaRewardItemID     int                     `json:"meta_reward_item_id,omitempty"`
+	MetaRewardAstral     int                     `json:"meta_reward_astral,omitempty"`
+	MetaRewardClaimed    bool                    `json:"meta_reward_claimed,omitempty"`
+	Objectives           []WizardsVaultObjective `json:"objectives"`
+}
+
+// WizardsVaultListing represents a reward in the Wizard's Vault. Purchased is only
+// present on the authenticated endpoint; PurchaseLimit is absent for unlimited rewards.
+type WizardsVaultListing struct {
+	ID            int    `json:"id"`
+	ItemID        int    `json:"item_id"`
+	ItemName      string `json:"item_name,omitempty"`
+	ItemCount     int    `json:"item_count"`
+	Type          string `json:"type"`
+	Cost          int    `json:"cost"`
+	PurchaseLimit *int   `json:"purchase_limit,omitempty"`
+	Purchased     *int   `json:"purchased,omitempty"`
+}
+
+// Remaining returns how many more times the listing can be bought, or -1 if unlimited
+func (l WizardsVaultListing) Remaining() int {
+	if l.PurchaseLimit == nil {
+		return -1
+	}
+	bought := 0
+	if l.Purchased != nil {
+		bought = *l.Purchased
+	}
+	return max(0, *l.PurchaseLimit-bought)
+}
+
+// GetWizardsVault retrieves current wizard's vault season info
+func (c *Client) GetWizardsVault(ctx context.Context) (*WizardsVaultSeason, error) {
+	cacheKey := c.cache.GetWizardsVaultSeasonKey()
+	var season WizardsVaultSeason
+	if c.cache.GetJSON(cacheKey, &season) {
+		return &season, nil
+	}
+
+	if err := c.fetchPublic(ctx, "/wizardsvault", &season); err != nil {
+		return nil, fmt.Errorf("failed to fetch wizard's vault season: %w", err)
+	}
+
+	if err := c.cache.SetJSON(cacheKey, season, cache.WVSeasonTTL); err != nil {
+		c.logger.Warn("Failed to cache wizard's vault season", "error", err)
+	}
+	return &season, nil
+}
+
+var validWVObjectiveTypes = map[string]bool{
+	"daily": true, "weekly": true, "special": true,
+}
+
+// GetWizardsVaultObjectives retrieves wizard's vault objectives
+func (c *Client) GetWizardsVaultObjectives(ctx context.Context, objType string) (*WizardsVaultProgress, error) {
+	if !validWVObjectiveTypes[objType] {
+		return nil, fmt.Errorf("invalid wizard's vault objective type %q: must be daily, weekly, or special", objType)
+	}
+
+	// Use authenticated endpoint if API key available
+	if c.APIKey() != "" {
+		keyHash := c.apiKeyHash()
+		cacheKey := c.cache.GetWizardsVaultObjectivesKey(keyHash, objType)
+		var progress WizardsVaultProgress
+		if c.cache.GetJSON(cacheKey, &progress) {
+			return &progress, nil
+		}
+
+		if err := c.fetchAuthenticated(ctx, "/account/wizardsvault/"+objType, &progress); err != nil {
+			return nil, fmt.Errorf("failed to fetch wizard's vault objectives: %w", err)
+		}
+
+		if err := c.cache.SetJSON(cacheKey, progress, cache.WVObjectivesAuthTTL); err != nil {
+			c.logger.Warn("Failed to cache wizard's vault objectives", "error", err)
+		}
+		return &progress, nil
+	}
+
+	// Fall back to public endpoint
+	cacheKey := c.cache.GetWizardsVaultObjectivesKey("public", objType)
+	var progress WizardsVaultProgress
+	if c.cache.GetJSON(cacheKey, &progress) {
+		return &progress, nil
+	}
+
+	// Public endpoint returns all objectives, not type-specific
+	if err := c.fetchPublic(ctx, "/wizardsvault/objectives?ids=all", &progress.Objectives); err != nil {
+		return nil, fmt.Errorf("failed to fetch wizard's vault objectives: %w", err)
+	}
+
+	if err := c.cache.SetJSON(cacheKey, progress, cache.WVObjectivesPublicTTL); err != nil {
+		c.logger.Warn("Failed to cache wizard's vault objectives", "error", err)
+	}
+	return &progress, nil
+}
+
+// GetWizardsVaultListings retrieves wizard's vault reward listings with item names
+func (c *Client) GetWizardsVaultListings(ctx context.Context) ([]WizardsVaultListing, error) {
+	keyHash, path := "public", "/wizardsvault/listings?ids=all"
+	if c.APIKey() != "" {
+		keyHash, path = c.apiKeyHash(), "/account/wizardsvault/listings"
+	}
+
+	cacheKey := c.cache.GetWizardsVaultListingsKey(keyHash)
+	var listings []WizardsVaultListing
+	if c.cache.GetJSON(cacheKey, &listings) {
+		return listings, nil
+	}
+
+	var err error
+	if c.APIKey() != "" {
+		err = c.fetchAuthenticated(ctx, path, &listings)
+	} else {
+		err = c.fetchPublic(ctx, path, &listings)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch wizard's vault listings: %w", err)
 	}
 
-	if err := c.cache.SetJSON(cacheKey, data, cache.WVListingsTTL); err != nil {
+	// Enrich with item names
+	itemIDs := make([]int, len(listings))
+	for i, l := range listings {
+		itemIDs[i] = l.ItemID
+	}
+	if items, err := c.GetItems(ctx, itemIDs); err != nil {
+		c.logger.Warn("Failed to get item metadata for wizard's vault listings", "error", err)
+	} else {
+		for i, l := range listings {
+			listings[i].ItemName = items[l.ItemID].Name
+		}
+	}
+
+	if err := c.cache.SetJSON(cacheKey, listings, cache.WVListingsTTL); err != nil {
 		c.logger.Warn("Failed to cache wizard's vault listings", "error", err)
 	}
-	return data, nil
+	return listings, nil
 }
 
 // --- Phase 7: Game Data Lookups ---
@@ -1658,6 +2285,46 @@ type Skin struct {
 	Details      json.RawMessage `json:"details,omitempty"`
 }
 
+// DetailType returns the weapon type, armor slot or tool type of the skin (e.g. "Greatsword", "Helm")
+func (s Skin) DetailType() string {
+	var details struct {
+		Type string `json:"type"`
+	}
+	if len(s.Details) == 0 || json.Unmarshal(s.Details, &details) != nil {
+		return ""
+	}
+	return details.Type
+}
+
+// WeightClass returns the armor weight of the skin (e.g. "Light", "Heavy"), or "" for non-armor
+func (s Skin) WeightClass() string {
+	var details struct {
+		WeightClass string `json:"weight_class"`
+	}
+	if len(s.Details) == 0 || json.Unmarshal(s.Details, &details) != nil {
+		return ""
+	}
+	return details.WeightClass
+}
+
+// GetSkinIDs retrieves the ID of every skin
+func (c *Client) GetSkinIDs(ctx context.Context) ([]int, error) {
+	cacheKey := c.cache.GetSkinIDsKey()
+	var ids []int
+	if c.cache.GetJSON(cacheKey, &ids) {
+		return ids, nil
+	}
+
+	if err := c.fetchPublic(ctx, "/skins", &ids); err != nil {
+		return nil, fmt.Errorf("failed to fetch skin IDs: %w", err)
+	}
+
+	if err := c.cache.SetJSON(cacheKey, ids, cache.ItemDataTTL); err != nil {
+		c.logger.Warn("Failed to cache skin IDs", "error", err)
+	}
+	return ids, nil
+}
+
 // GetSkins retrieves skin metadata for the given IDs
 func (c *Client) GetSkins(ctx context.Context, ids []int) ([]Skin, error) {
 	var results []Skin
@@ -1675,8 +2342,12 @@ func (c *Client) GetSkins(ctx context.Context, ids []int) ([]Skin, error) {
 
 	if len(missingIDs) > 0 {
 		var fetched []Skin
-		if err := c.fetchPublic(ctx, "/skins?ids="+idsToParam(missingIDs), &fetched); err != nil {
-			return nil, fmt.Errorf("failed to fetch skins: %w", err)
+		for _, chunk := range chunkIDs(dedupeIDs(missingIDs), maxIDsPerRequest) {
+			var batch []Skin
+			if err := ignorePartial(c.fetchPublic(ctx, "/skins?ids="+idsToParam(chunk), &batch)); err != nil {
+				return nil, fmt.Errorf("failed to fetch skins: %w", err)
+			}
+			fetched = append(fetched, batch...)
 		}
 		for _, skin := range fetched {
 			results = append(results, skin)
@@ -1733,7 +2404,7 @@ func (c *Client) GetRecipes(ctx context.Context, ids []int) ([]Recipe, error) {
 
 	if len(missingIDs) > 0 {
 		var fetched []Recipe
-		if err := c.fetchPublic(ctx, "/recipes?ids="+idsToParam(missingIDs), &fetched); err != nil {
+		if err := ignorePartial(c.fetchPublic(ctx, "/recipes?ids="+idsToParam(missingIDs), &fetched)); err != nil {
 			return nil, fmt.Errorf("failed to fetch recipes: %w", err)
 		}
 		for _, recipe := range fetched {
@@ -1834,8 +2505,12 @@ func (c *Client) GetAchievements(ctx context.Context, ids []int) ([]Achievement,
 
 	if len(missingIDs) > 0 {
 		var fetched []Achievement
-		if err := c.fetchPublic(ctx, "/achievements?ids="+idsToParam(missingIDs), &fetched); err != nil {
-			return nil, fmt.Errorf("failed to fetch achievements: %w", err)
+		for _, chunk := range chunkIDs(dedupeIDs(missingIDs), maxIDsPerRequest) {
+			var batch []Achievement
+			if err := ignorePartial(c.fetchPublic(ctx, "/achievements?ids="+idsToParam(chunk), &batch)); err != nil {
+				return nil, fmt.Errorf("failed to fetch achievements: %w", err)
+			}
+			fetched = append(fetched, batch...)
 		}
 		for _, ach := range fetched {
 			results = append(results, ach)
@@ -1848,36 +2523,137 @@ func (c *Client) GetAchievements(ctx context.Context, ids []int) ([]Achievement,
 	return results, nil
 }
 
-// DailyAchievements represents daily achievement categories
-type DailyAchievements struct {
-	Today    json.RawMessage `json:"today"`
-	Tomorrow json.RawMessage `json:"tomorrow"`
+// AchievementCategory represents an achievement category from /v2/achievements/categories
+type AchievementCategory struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Order        int    `json:"order"`
+	Icon         string `json:"icon,omitempty"`
+	Achievements []int  `json:"achievements"`
 }
 
-// GetDailyAchievements retrieves today's and tomorrow's daily achievements
-func (c *Client) GetDailyAchievements(ctx context.Context) (*DailyAchievements, error) {
-	cacheKey := c.cache.GetDailyAchievementKey()
-	var cached DailyAchievements
-	if c.cache.GetJSON(cacheKey, &cached) {
-		return &cached, nil
+// GetAchievementCategories retrieves every achievement category
+func (c *Client) GetAchievementCategories(ctx context.Context) ([]AchievementCategory, error) {
+	cacheKey := c.cache.GetAchievementCategoriesKey()
+	var categories []AchievementCategory
+	if c.cache.GetJSON(cacheKey, &categories) {
+		return categories, nil
 	}
 
-	today, err := c.fetchPublicRaw(ctx, "/achievements/daily")
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch daily achievements: %w", err)
+	if err := c.fetchPublic(ctx, "/achievements/categories?ids=all", &categories); err != nil {
+		return nil, fmt.Errorf("failed to fetch achievement categories: %w", err)
 	}
 
-	tomorrow, err := c.fetchPublicRaw(ctx, "/achievements/daily/tomorrow")
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch tomorrow's daily achievements: %w", err)
+	if err := c.cache.SetJSON(cacheKey, categories, cache.AchievementDataTTL); err != nil {
+		c.logger.Warn("Failed to cache achievement categories", "error", err)
 	}
+	return categories, nil
+}
 
-	result := &DailyAchievements{Today: today, Tomorrow: tomorrow}
-	if err := c.cache.SetJSON(cacheKey, result, cache.DailyAchievementTTL); err != nil {
-		c.logger.Warn("Failed to cache daily achievements", "error", err)
+// GetAchievementCategory retrieves a single achievement category. Unlike the full list,
+// it is cached only briefly so rotating categories such as Daily Fractals stay current.
+func (c *Client) GetAchievementCategory(ctx context.Context, id int) (*AchievementCategory, error) {
+	cacheKey := c.cache.GetAchievementCategoryKey(id)
+	var category AchievementCategory
+	if c.cache.GetJSON(cacheKey, &category) {
+		return &category, nil
 	}
-	return result, nil
-}
+
+	if err := c.fetchPublic(ctx, fmt.Sprintf("/achievements/categories/%d", id), &category); err != nil {
+		return nil, fmt.Errorf("failed to fetch achievement category %d: %w", id, err)
+	}
+
+	if err := c.cache.SetJSON(cacheKey, category, cache.DailyAchievementTTL); err != nil {
+		c.logger.Warn("Failed to cache achievement category", "id", id, "error", err)
+	}
+	return &category, nil
+}
+
+// AchievementGroup represents a top-level achievement group from /v2/achievements/groups
+type AchievementGroup struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Order       int    `json:"order"`
+	Categories  []int  `json:"categories"`
+}
+
+// GetAchievementGroups retrieves every achievement group
+func (c *Client) GetAchievementGroups(ctx context.Context) ([]AchievementGroup, error) {
+	cacheKey := c.cache.GetAchievementGroupsKey()
+	var groups []AchievementGroup
+	if c.cache.GetJSON(cacheKey, &groups) {
+		return groups, nil
+	}
+
+	if err := c.fetchPublic(ctx, "/achievements/groups?ids=all", &groups); err != nil {
+		return nil, fmt.Errorf("failed to fetch achievement groups: %w", err)
+	}
+
+	if err := c.cache.SetJSON(cacheKey, groups, cache.AchievementDataTTL); err != nil {
+		c.logger.Warn("Failed to cache achievement groups", "error", err)
+	}
+	return groups, nil
+}
+
+// AccountAchievement represents the account's progress on a single achievement
+type AccountAchievement struct {
+	ID       int   `json:"id"`
+	Bits     []int `json:"bits,omitempty"`
+	Current  int   `json:"current"`
+	Max      int   `json:"max"`
+	Done     bool  `json:"done"`
+	Repeated int   `json:"repeated,omitempty"`
+	Unlocked *bool `json:"unlocked,omitempty"`
+	// Name is filled in by GetAccountProgress
+	Name string `json:"name,omitempty"`
+}
+
+// GetAccountAchievements retrieves the account's progress on every started achievement
+func (c *Client) GetAccountAchievements(ctx context.Context) ([]AccountAchievement, error) {
+	data, err := c.fetchAccountProgress(ctx, "achievements")
+	if err != nil {
+		return nil, err
+	}
+
+	var progress []AccountAchievement
+	if err := json.Unmarshal(data, &progress); err != nil {
+		return nil, fmt.Errorf("failed to decode achievement progress: %w", err)
+	}
+	return progress, nil
+}
+
+// DailyAchievements represents daily achievement categories
+type DailyAchievements struct {
+	Today    json.RawMessage `json:"today"`
+	Tomorrow json.RawMessage `json:"tomorrow"`
+}
+
+// GetDailyAchievements retrieves today's and tomorrow's daily achievements
+func (c *Client) GetDailyAchievements(ctx context.Context) (*DailyAchievements, error) {
+	cacheKey := c.cache.GetDailyAchievementKey()
+	var cached DailyAchievements
+	if c.cache.GetJSON(cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	today, err := c.fetchPublicRaw(ctx, "/achievements/daily")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch daily achievements: %w", err)
+	}
+
+	tomorrow, err := c.fetchPublicRaw(ctx, "/achievements/daily/tomorrow")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tomorrow's daily achievements: %w", err)
+	}
+
+	result := &DailyAchievements{Today: today, Tomorrow: tomorrow}
+	if err := c.cache.SetJSON(cacheKey, result, cache.DailyAchievementTTL); err != nil {
+		c.logger.Warn("Failed to cache daily achievements", "error", err)
+	}
+	return result, nil
+}
 
 // --- Phase 8: Guild Tools ---
 
@@ -1950,8 +2726,8 @@ var validGuildDetailTypes = map[string]bool{
 	"storage": true, "treasury": true, "teams": true, "upgrades": true,
 }
 
-// GetGuildDetails retrieves authenticated guild detail data
-func (c *Client) GetGuildDetails(ctx context.Context, guildID, detailType string) (json.RawMessage, error) {
+// fetchGuildDetail retrieves raw authenticated guild detail data
+func (c *Client) fetchGuildDetail(ctx context.Context, guildID, detailType string) (json.RawMessage, error) {
 	if err := c.requireAPIKey(); err != nil {
 		return nil, err
 	}
@@ -1978,6 +2754,452 @@ func (c *Client) GetGuildDetails(ctx context.Context, guildID, detailType string
 	return data, nil
 }
 
+// GuildStashSlot is one occupied slot of a guild bank tab
+type GuildStashSlot struct {
+	ID    int    `json:"id"`
+	Count int    `json:"count"`
+	Name  string `json:"name,omitempty"`
+}
+
+// GuildStashSection is one guild bank tab; empty slots are nil
+type GuildStashSection struct {
+	UpgradeID int               `json:"upgrade_id"`
+	Size      int               `json:"size"`
+	Coins     int               `json:"coins"`
+	Note      string            `json:"note,omitempty"`
+	Inventory []*GuildStashSlot `json:"inventory"`
+}
+
+// GuildTreasuryNeed is how many of a treasury item an upgrade still needs
+type GuildTreasuryNeed struct {
+	UpgradeID int `json:"upgrade_id"`
+	Count     int `json:"count"`
+}
+
+// GuildTreasuryItem is an item deposited towards guild upgrades
+type GuildTreasuryItem struct {
+	ItemID   int                 `json:"item_id"`
+	Count    int                 `json:"count"`
+	Name     string              `json:"name,omitempty"`
+	NeededBy []GuildTreasuryNeed `json:"needed_by"`
+}
+
+// GuildUpgradeCost is one ingredient of a guild upgrade; Item and Collectible costs carry an item ID
+type GuildUpgradeCost struct {
+	Type   string `json:"type"`
+	Name   string `json:"name,omitempty"`
+	Count  int    `json:"count"`
+	ItemID int    `json:"item_id,omitempty"`
+}
+
+// GuildUpgrade represents a guild upgrade from /v2/guild/upgrades
+type GuildUpgrade struct {
+	ID            int                `json:"id"`
+	Name          string             `json:"name"`
+	Description   string             `json:"description,omitempty"`
+	Type          string             `json:"type"`
+	// BagMaxItems and BagMaxCoins are the capacity of BankBag upgrades (guild bank tabs)
+	BagMaxItems   int                `json:"bag_max_items,omitempty"`
+	BagMaxCoins   int                `json:"bag_max_coins,omitempty"`
+	Icon          string             `json:"icon,omitempty"`
+	BuildTime     int                `json:"build_time"`
+	RequiredLevel int                `json:"required_level"`
+	Experience    int                `json:"experience"`
+	Prerequisites []int              `json:"prerequisites"`
+	Costs         []GuildUpgradeCost `json:"costs"`
+}
+
+// GuildPermission represents a guild rank permission from /v2/guild/permissions
+type GuildPermission struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// GuildMember is a guild member with their rank; Joined is missing for very old members
+type GuildMember struct {
+	Name   string     `json:"name"`
+	Rank   string     `json:"rank"`
+	Joined *time.Time `json:"joined,omitempty"`
+}
+
+// GuildRank is a guild rank with the permission IDs it grants; lower Order ranks higher
+type GuildRank struct {
+	ID          string   `json:"id"`
+	Order       int      `json:"order"`
+	Permissions []string `json:"permissions"`
+	Icon        string   `json:"icon,omitempty"`
+}
+
+// GuildLogEntry is one entry of a guild's log; which fields are set depends on Type
+// (joined, invited, kick, rank_change, treasury, stash, motd, upgrade, invite_declined)
+type GuildLogEntry struct {
+	ID         int       `json:"id"`
+	Time       time.Time `json:"time"`
+	Type       string    `json:"type"`
+	User       string    `json:"user,omitempty"`
+	InvitedBy  string    `json:"invited_by,omitempty"`
+	KickedBy   string    `json:"kicked_by,omitempty"`
+	ChangedBy  string    `json:"changed_by,omitempty"`
+	DeclinedBy string    `json:"declined_by,omitempty"`
+	OldRank    string    `json:"old_rank,omitempty"`
+	NewRank    string    `json:"new_rank,omitempty"`
+	Operation  string    `json:"operation,omitempty"`
+	ItemID     int       `json:"item_id,omitempty"`
+	ItemName   string    `json:"item_name,omitempty"`
+	Count      int       `json:"count,omitempty"`
+	Coins      int       `json:"coins,omitempty"`
+	MOTD       string    `json:"motd,omitempty"`
+	Action     string    `json:"action,omitempty"`
+	UpgradeID  int       `json:"upgrade_id,omitempty"`
+	RecipeID   int       `json:"recipe_id,omitempty"`
+}
+
+// GuildLog is the log history collected for a guild, newest entry first. The API only
+// returns the latest 100 entries, so history is kept locally, saved to the data
+// directory, and extended with ?since=. The newest entry is the cursor for the next fetch.
+type GuildLog struct {
+	Entries []GuildLogEntry `json:"entries"`
+	// GapAfter is a log ID after which entries may be missing because more than a page
+	// of new entries arrived between two fetches; 0 means the history is unbroken
+	GapAfter int `json:"gap_after,omitempty"`
+}
+
+const (
+	// guildLogPageSize is the most entries /guild/:id/log returns per request
+	guildLogPageSize = 100
+	// maxGuildLogEntries bounds the history kept per guild
+	maxGuildLogEntries = 10000
+	// maxGuildLogPages bounds the pages fetched per call
+	maxGuildLogPages = maxGuildLogEntries / guildLogPageSize
+)
+
+// Cursor returns the newest log ID collected, or 0 when nothing has been collected
+func (l *GuildLog) Cursor() int {
+	if len(l.Entries) == 0 {
+		return 0
+	}
+	return l.Entries[0].ID
+}
+
+// Merge adds newer entries fetched after the cursor, newest first, records a gap when
+// a full page arrived that does not reach back to the cursor, and reports how many
+// entries were new
+func (l *GuildLog) Merge(fetched []GuildLogEntry) int {
+	cursor := l.Cursor()
+	fresh := make([]GuildLogEntry, 0, len(fetched))
+	for _, e := range fetched {
+		if e.ID > cursor {
+			fresh = append(fresh, e)
+		}
+	}
+	if len(fresh) == 0 {
+		return 0
+	}
+	sort.Slice(fresh, func(i, j int) bool { return fresh[i].ID > fresh[j].ID })
+
+	if cursor > 0 && len(fetched) >= guildLogPageSize && fresh[len(fresh)-1].ID > cursor+1 {
+		l.GapAfter = cursor
+	}
+	l.Entries = append(fresh, l.Entries...)
+	if len(l.Entries) > maxGuildLogEntries {
+		l.Entries = l.Entries[:maxGuildLogEntries]
+	}
+	return len(fresh)
+}
+
+// guildLogFile returns the name a guild's log history is saved under in the data directory
+func guildLogFile(guildID string) string {
+	return "guild-log-" + guildID
+}
+
+// GetGuildLog retrieves the guild log, fetching only entries newer than those already
+// collected and keeping the combined history. History is read from the cache, or from
+// the data directory after a restart, and pages are fetched with ?since= until a short
+// page shows the log is caught up.
+func (c *Client) GetGuildLog(ctx context.Context, guildID string) (*GuildLog, error) {
+	if err := c.requireAPIKey(); err != nil {
+		return nil, err
+	}
+
+	cacheKey := c.cache.GetGuildLogKey(guildID)
+	var history GuildLog
+	if !c.cache.GetJSON(cacheKey, &history) {
+		if _, err := c.store.Load(guildLogFile(guildID), &history); err != nil {
+			c.logger.Warn("Failed to load saved guild log", "id", guildID, "error", err)
+		}
+	}
+
+	for page := 0; page < maxGuildLogPages; page++ {
+		path := "/guild/" + guildID + "/log"
+		if cursor := history.Cursor(); cursor > 0 {
+			path += "?since=" + strconv.Itoa(cursor)
+		}
+		var fetched []GuildLogEntry
+		if err := c.fetchAuthenticated(ctx, path, &fetched); err != nil {
+			return nil, fmt.Errorf("failed to fetch guild log: %w", err)
+		}
+		if history.Merge(fetched) == 0 || len(fetched) < guildLogPageSize {
+			break
+		}
+	}
+
+	if err := c.cache.SetJSON(cacheKey, history, cache.GuildLogTTL); err != nil {
+		c.logger.Warn("Failed to cache guild log", "id", guildID, "error", err)
+	}
+	if err := c.store.Save(guildLogFile(guildID), history); err != nil {
+		c.logger.Warn("Failed to save guild log", "id", guildID, "error", err)
+	}
+	return &history, nil
+}
+
+// getGuildDetail decodes one authenticated guild detail type into dest
+func (c *Client) getGuildDetail(ctx context.Context, guildID, detailType string, dest interface{}) error {
+	data, err := c.fetchGuildDetail(ctx, guildID, detailType)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, dest); err != nil {
+		return fmt.Errorf("failed to decode guild %s: %w", detailType, err)
+	}
+	return nil
+}
+
+// GetGuildStash retrieves the guild bank tabs with item names
+func (c *Client) GetGuildStash(ctx context.Context, guildID string) ([]GuildStashSection, error) {
+	var stash []GuildStashSection
+	if err := c.getGuildDetail(ctx, guildID, "stash", &stash); err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	for _, section := range stash {
+		for _, slot := range section.Inventory {
+			if slot != nil {
+				ids = append(ids, slot.ID)
+			}
+		}
+	}
+	items, err := c.GetItems(ctx, ids)
+	if err != nil {
+		c.logger.Warn("Failed to get item metadata for guild stash", "error", err)
+		return stash, nil
+	}
+	for _, section := range stash {
+		for _, slot := range section.Inventory {
+			if slot != nil {
+				slot.Name = items[slot.ID].Name
+			}
+		}
+	}
+	return stash, nil
+}
+
+// GetGuildTreasury retrieves the items deposited towards guild upgrades with item names
+func (c *Client) GetGuildTreasury(ctx context.Context, guildID string) ([]GuildTreasuryItem, error) {
+	var treasury []GuildTreasuryItem
+	if err := c.getGuildDetail(ctx, guildID, "treasury", &treasury); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(treasury))
+	for i, t := range treasury {
+		ids[i] = t.ItemID
+	}
+	items, err := c.GetItems(ctx, ids)
+	if err != nil {
+		c.logger.Warn("Failed to get item metadata for guild treasury", "error", err)
+		return treasury, nil
+	}
+	for i, t := range treasury {
+		treasury[i].Name = items[t.ItemID].Name
+	}
+	return treasury, nil
+}
+
+// GetGuildMembers retrieves the guild's members
+func (c *Client) GetGuildMembers(ctx context.Context, guildID string) ([]GuildMember, error) {
+	var members []GuildMember
+	if err := c.getGuildDetail(ctx, guildID, "members", &members); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// GetGuildRanks retrieves the guild's ranks
+func (c *Client) GetGuildRanks(ctx context.Context, guildID string) ([]GuildRank, error) {
+	var ranks []GuildRank
+	if err := c.getGuildDetail(ctx, guildID, "ranks", &ranks); err != nil {
+		return nil, err
+	}
+	return ranks, nil
+}
+
+// GetGuildUpgradeIDs retrieves the IDs of the guild's completed upgrades
+func (c *Client) GetGuildUpgradeIDs(ctx context.Context, guildID string) ([]int, error) {
+	var ids []int
+	if err := c.getGuildDetail(ctx, guildID, "upgrades", &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// GuildStorageItem is a decoration or other guild upgrade item held in the guild's storage
+type GuildStorageItem struct {
+	ID    int    `json:"id"`
+	Count int    `json:"count"`
+	Name  string `json:"name,omitempty"`
+}
+
+// PvPWinLoss is a PvP team's game record
+type PvPWinLoss struct {
+	Wins       int `json:"wins"`
+	Losses     int `json:"losses"`
+	Desertions int `json:"desertions"`
+	Byes       int `json:"byes"`
+	Forfeits   int `json:"forfeits"`
+}
+
+// PvPScores is the final score of a PvP game
+type PvPScores struct {
+	Red  int `json:"red"`
+	Blue int `json:"blue"`
+}
+
+// PvPGame is one game played by a guild PvP team
+type PvPGame struct {
+	ID           string    `json:"id"`
+	MapID        int       `json:"map_id"`
+	Started      time.Time `json:"started"`
+	Ended        time.Time `json:"ended"`
+	Result       string    `json:"result"`
+	Team         string    `json:"team"`
+	RatingType   string    `json:"rating_type"`
+	RatingChange int       `json:"rating_change,omitempty"`
+	Season       string    `json:"season,omitempty"`
+	Scores       PvPScores `json:"scores"`
+}
+
+// GuildTeamMember is a member of a guild PvP team
+type GuildTeamMember struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// GuildTeamSeason is a guild PvP team's record in one PvP season
+type GuildTeamSeason struct {
+	ID     string `json:"id"`
+	Wins   int    `json:"wins"`
+	Losses int    `json:"losses"`
+	Rating int    `json:"rating"`
+}
+
+// GuildTeam is a guild PvP team from /v2/guild/:id/teams
+type GuildTeam struct {
+	ID        int                   `json:"id"`
+	Name      string                `json:"name"`
+	Members   []GuildTeamMember     `json:"members"`
+	Aggregate PvPWinLoss            `json:"aggregate"`
+	Ladders   map[string]PvPWinLoss `json:"ladders"`
+	Games     []PvPGame             `json:"games"`
+	Seasons   []GuildTeamSeason     `json:"seasons"`
+}
+
+// GetGuildStorage retrieves the guild's storage with upgrade names
+func (c *Client) GetGuildStorage(ctx context.Context, guildID string) ([]GuildStorageItem, error) {
+	var storage []GuildStorageItem
+	if err := c.getGuildDetail(ctx, guildID, "storage", &storage); err != nil {
+		return nil, err
+	}
+
+	upgrades, err := c.GetGuildUpgrades(ctx)
+	if err != nil {
+		c.logger.Warn("Failed to get guild upgrade names for guild storage", "error", err)
+		return storage, nil
+	}
+	names := make(map[int]string, len(upgrades))
+	for _, u := range upgrades {
+		names[u.ID] = u.Name
+	}
+	for i, s := range storage {
+		storage[i].Name = names[s.ID]
+	}
+	return storage, nil
+}
+
+// GetGuildTeams retrieves the guild's PvP teams
+func (c *Client) GetGuildTeams(ctx context.Context, guildID string) ([]GuildTeam, error) {
+	var teams []GuildTeam
+	if err := c.getGuildDetail(ctx, guildID, "teams", &teams); err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+// GetGuildLogEntries retrieves the latest page of the guild's log with item names; use
+// GetGuildLog for the history collected beyond it
+func (c *Client) GetGuildLogEntries(ctx context.Context, guildID string) ([]GuildLogEntry, error) {
+	var entries []GuildLogEntry
+	if err := c.getGuildDetail(ctx, guildID, "log", &entries); err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	for _, e := range entries {
+		if e.ItemID != 0 {
+			ids = append(ids, e.ItemID)
+		}
+	}
+	if len(ids) == 0 {
+		return entries, nil
+	}
+	items, err := c.GetItems(ctx, ids)
+	if err != nil {
+		c.logger.Warn("Failed to get item metadata for guild log", "error", err)
+		return entries, nil
+	}
+	for i, e := range entries {
+		entries[i].ItemName = items[e.ItemID].Name
+	}
+	return entries, nil
+}
+
+// GetGuildUpgrades retrieves every guild upgrade definition
+func (c *Client) GetGuildUpgrades(ctx context.Context) ([]GuildUpgrade, error) {
+	cacheKey := c.cache.GetGuildUpgradesKey()
+	var upgrades []GuildUpgrade
+	if c.cache.GetJSON(cacheKey, &upgrades) {
+		return upgrades, nil
+	}
+
+	if err := c.fetchPublic(ctx, "/guild/upgrades?ids=all", &upgrades); err != nil {
+		return nil, fmt.Errorf("failed to fetch guild upgrades: %w", err)
+	}
+
+	if err := c.cache.SetJSON(cacheKey, upgrades, cache.GuildUpgradeDataTTL); err != nil {
+		c.logger.Warn("Failed to cache guild upgrades", "error", err)
+	}
+	return upgrades, nil
+}
+
+// GetGuildPermissions retrieves every permission a guild rank can be granted
+func (c *Client) GetGuildPermissions(ctx context.Context) ([]GuildPermission, error) {
+	cacheKey := c.cache.GetGuildPermissionsKey()
+	var permissions []GuildPermission
+	if c.cache.GetJSON(cacheKey, &permissions) {
+		return permissions, nil
+	}
+
+	if err := c.fetchPublic(ctx, "/guild/permissions?ids=all", &permissions); err != nil {
+		return nil, fmt.Errorf("failed to fetch guild permissions: %w", err)
+	}
+
+	if err := c.cache.SetJSON(cacheKey, permissions, cache.GuildUpgradeDataTTL); err != nil {
+		c.logger.Warn("Failed to cache guild permissions", "error", err)
+	}
+	return permissions, nil
+}
+
 // --- Phase 9: Game Metadata ---
 
 // ColorComponent represents the material-specific color adjustments
@@ -1999,6 +3221,28 @@ type Color struct {
 	Leather *ColorComponent `json:"leather,omitempty"`
 	Metal   *ColorComponent `json:"metal,omitempty"`
 	Fur     *ColorComponent `json:"fur,omitempty"`
+	// Item is the dye item that unlocks the color; starter dyes have none
+	Item int `json:"item,omitempty"`
+	// Categories are the hue, material and rarity of the color, in that order
+	Categories []string `json:"categories,omitempty"`
+}
+
+// GetColorIDs retrieves the ID of every dye color
+func (c *Client) GetColorIDs(ctx context.Context) ([]int, error) {
+	cacheKey := c.cache.GetColorIDsKey()
+	var ids []int
+	if c.cache.GetJSON(cacheKey, &ids) {
+		return ids, nil
+	}
+
+	if err := c.fetchPublic(ctx, "/colors", &ids); err != nil {
+		return nil, fmt.Errorf("failed to fetch color IDs: %w", err)
+	}
+
+	if err := c.cache.SetJSON(cacheKey, ids, cache.ColorDataTTL); err != nil {
+		c.logger.Warn("Failed to cache color IDs", "error", err)
+	}
+	return ids, nil
 }
 
 // GetColors retrieves color metadata for the given IDs
@@ -2018,8 +3262,12 @@ func (c *Client) GetColors(ctx context.Context, ids []int) ([]Color, error) {
 
 	if len(missingIDs) > 0 {
 		var fetched []Color
-		if err := c.fetchPublic(ctx, "/colors?ids="+idsToParam(missingIDs), &fetched); err != nil {
-			return nil, fmt.Errorf("failed to fetch colors: %w", err)
+		for _, chunk := range chunkIDs(dedupeIDs(missingIDs), maxIDsPerRequest) {
+			var batch []Color
+			if err := ignorePartial(c.fetchPublic(ctx, "/colors?ids="+idsToParam(chunk), &batch)); err != nil {
+				return nil, fmt.Errorf("failed to fetch colors: %w", err)
+			}
+			fetched = append(fetched, batch...)
 		}
 		for _, color := range fetched {
 			results = append(results, color)
@@ -2057,8 +3305,12 @@ func (c *Client) GetMinis(ctx context.Context, ids []int) ([]Mini, error) {
 
 	if len(missingIDs) > 0 {
 		var fetched []Mini
-		if err := c.fetchPublic(ctx, "/minis?ids="+idsToParam(missingIDs), &fetched); err != nil {
-			return nil, fmt.Errorf("failed to fetch minis: %w", err)
+		for _, chunk := range chunkIDs(dedupeIDs(missingIDs), maxIDsPerRequest) {
+			var batch []Mini
+			if err := ignorePartial(c.fetchPublic(ctx, "/minis?ids="+idsToParam(chunk), &batch)); err != nil {
+				return nil, fmt.Errorf("failed to fetch minis: %w", err)
+			}
+			fetched = append(fetched, batch...)
 		}
 		for _, mini := range fetched {
 			results = append(results, mini)
@@ -2071,19 +3323,105 @@ func (c *Client) GetMinis(ctx context.Context, ids []int) ([]Mini, error) {
 	return results, nil
 }
 
-// GetMountsInfo retrieves mount info (skins or types) for the given IDs
-func (c *Client) GetMountsInfo(ctx context.Context, mountType string, ids []int) (json.RawMessage, error) {
-	if mountType != "skins" && mountType != "types" {
-		return nil, fmt.Errorf("invalid mount type %q: must be 'skins' or 'types'", mountType)
+// MountDyeSlot is a dye channel of a mount skin with its default color
+type MountDyeSlot struct {
+	ColorID   int    `json:"color_id"`
+	Material  string `json:"material"`
+	ColorName string `json:"color_name,omitempty"`
+}
+
+// MountSkin represents a mount skin from /v2/mounts/skins
+type MountSkin struct {
+	ID       int            `json:"id"`
+	Name     string         `json:"name"`
+	Icon     string         `json:"icon,omitempty"`
+	Mount    string         `json:"mount"`
+	DyeSlots []MountDyeSlot `json:"dye_slots"`
+}
+
+// MountSkill is a skill of a mount type
+type MountSkill struct {
+	ID   int    `json:"id"`
+	Slot string `json:"slot"`
+}
+
+// MountType represents a mount from /v2/mounts/types
+type MountType struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	DefaultSkin int          `json:"default_skin"`
+	Skins       []int        `json:"skins"`
+	Skills      []MountSkill `json:"skills"`
+}
+
+// GetMountSkins retrieves mount skins for the given IDs, with the names of their default dyes
+func (c *Client) GetMountSkins(ctx context.Context, ids []int) ([]MountSkin, error) {
+	var results []MountSkin
+	var missingIDs []int
+	for _, id := range ids {
+		var skin MountSkin
+		if c.cache.GetJSON(c.cache.GetMountDetailKey("skins", id), &skin) {
+			results = append(results, skin)
+		} else {
+			missingIDs = append(missingIDs, id)
+		}
 	}
 
-	path := fmt.Sprintf("/mounts/%s?ids=%s", mountType, idsToParam(ids))
-	data, err := c.fetchPublicRaw(ctx, path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch mount %s: %w", mountType, err)
+	if len(missingIDs) > 0 {
+		var fetched []MountSkin
+		for _, chunk := range chunkIDs(dedupeIDs(missingIDs), maxIDsPerRequest) {
+			var batch []MountSkin
+			if err := ignorePartial(c.fetchPublic(ctx, "/mounts/skins?ids="+idsToParam(chunk), &batch)); err != nil {
+				return nil, fmt.Errorf("failed to fetch mount skins: %w", err)
+			}
+			fetched = append(fetched, batch...)
+		}
+
+		var colorIDs []int
+		for _, skin := range fetched {
+			for _, slot := range skin.DyeSlots {
+				colorIDs = append(colorIDs, slot.ColorID)
+			}
+		}
+		colors, err := c.GetColors(ctx, dedupeIDs(colorIDs))
+		if err != nil {
+			c.logger.Warn("Failed to get dye names for mount skins", "error", err)
+		}
+		colorNames := make(map[int]string, len(colors))
+		for _, color := range colors {
+			colorNames[color.ID] = color.Name
+		}
+
+		for _, skin := range fetched {
+			for i := range skin.DyeSlots {
+				skin.DyeSlots[i].ColorName = colorNames[skin.DyeSlots[i].ColorID]
+			}
+			results = append(results, skin)
+			if err := c.cache.SetJSON(c.cache.GetMountDetailKey("skins", skin.ID), skin, cache.MountDataTTL); err != nil {
+				c.logger.Warn("Failed to cache mount skin", "id", skin.ID, "error", err)
+			}
+		}
 	}
 
-	return data, nil
+	return results, nil
+}
+
+// GetMountTypes retrieves every mount type with its skins and skills
+func (c *Client) GetMountTypes(ctx context.Context) ([]MountType, error) {
+	cacheKey := c.cache.GetMountTypesKey()
+	var types []MountType
+	if c.cache.GetJSON(cacheKey, &types) {
+		return types, nil
+	}
+
+	if err := c.fetchPublic(ctx, "/mounts/types?ids=all", &types); err != nil {
+		return nil, fmt.Errorf("failed to fetch mount types: %w", err)
+	}
+
+	if err := c.cache.SetJSON(cacheKey, types, cache.MountDataTTL); err != nil {
+		c.logger.Warn("Failed to cache mount types", "error", err)
+	}
+	return types, nil
 }
 
 // BuildInfo represents the game build number
@@ -2138,18 +3476,420 @@ func (c *Client) GetTokenInfo(ctx context.Context) (*TokenInfo, error) {
 	return &info, nil
 }
 
-// GetDungeonsAndRaids retrieves dungeon or raid metadata for the given IDs
-func (c *Client) GetDungeonsAndRaids(ctx context.Context, contentType string, ids []string) (json.RawMessage, error) {
-	if contentType != "dungeons" && contentType != "raids" {
-		return nil, fmt.Errorf("invalid type %q: must be 'dungeons' or 'raids'", contentType)
+// DungeonPath represents a single path of a dungeon
+type DungeonPath struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// Dungeon represents a dungeon from /v2/dungeons
+type Dungeon struct {
+	ID    string        `json:"id"`
+	Paths []DungeonPath `json:"paths"`
+}
+
+// RaidEvent represents a boss or checkpoint encounter within a raid wing
+type RaidEvent struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// RaidWing represents a wing of a raid
+type RaidWing struct {
+	ID     string      `json:"id"`
+	Events []RaidEvent `json:"events"`
+}
+
+// Raid represents a raid from /v2/raids
+type Raid struct {
+	ID    string     `json:"id"`
+	Wings []RaidWing `json:"wings"`
+}
+
+// GetDungeons retrieves every dungeon with its paths
+func (c *Client) GetDungeons(ctx context.Context) ([]Dungeon, error) {
+	cacheKey := c.cache.GetDungeonListKey("dungeons")
+	var dungeons []Dungeon
+	if c.cache.GetJSON(cacheKey, &dungeons) {
+		return dungeons, nil
+	}
+
+	if err := c.fetchPublic(ctx, "/dungeons?ids=all", &dungeons); err != nil {
+		return nil, fmt.Errorf("failed to fetch dungeons: %w", err)
+	}
+
+	if err := c.cache.SetJSON(cacheKey, dungeons, cache.DungeonDataTTL); err != nil {
+		c.logger.Warn("Failed to cache dungeons", "error", err)
+	}
+	return dungeons, nil
+}
+
+// GetRaids retrieves every raid with its wings and encounters
+func (c *Client) GetRaids(ctx context.Context) ([]Raid, error) {
+	cacheKey := c.cache.GetDungeonListKey("raids")
+	var raids []Raid
+	if c.cache.GetJSON(cacheKey, &raids) {
+		return raids, nil
+	}
+
+	if err := c.fetchPublic(ctx, "/raids?ids=all", &raids); err != nil {
+		return nil, fmt.Errorf("failed to fetch raids: %w", err)
+	}
+
+	if err := c.cache.SetJSON(cacheKey, raids, cache.DungeonDataTTL); err != nil {
+		c.logger.Warn("Failed to cache raids", "error", err)
 	}
+	return raids, nil
+}
 
-	idsParam := strings.Join(ids, ",")
-	path := fmt.Sprintf("/%s?ids=%s", contentType, idsParam)
-	data, err := c.fetchPublicRaw(ctx, path)
+// GetAccountDungeonClears retrieves the dungeon path IDs completed since daily reset
+func (c *Client) GetAccountDungeonClears(ctx context.Context) ([]string, error) {
+	data, err := c.fetchAccountDailies(ctx, "dungeons")
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch %s: %w", contentType, err)
+		return nil, err
 	}
 
-	return data, nil
+	var paths []string
+	if err := json.Unmarshal(data, &paths); err != nil {
+		return nil, fmt.Errorf("failed to decode dungeon clears: %w", err)
+	}
+	return paths, nil
+}
+
+// GetAccountRaidClears retrieves the raid encounter IDs cleared since weekly reset
+func (c *Client) GetAccountRaidClears(ctx context.Context) ([]string, error) {
+	data, err := c.fetchAccountDailies(ctx, "raids")
+	if err != nil {
+		return nil, err
+	}
+
+	var events []string
+	if err := json.Unmarshal(data, &events); err != nil {
+		return nil, fmt.Errorf("failed to decode raid clears: %w", err)
+	}
+	return events, nil
+}
+
+// --- Masteries ---
+
+// MasteryLevel represents a single level of a mastery track
+type MasteryLevel struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Instruction string `json:"instruction"`
+	Icon        string `json:"icon,omitempty"`
+	PointCost   int    `json:"point_cost"`
+	ExpCost     int    `json:"exp_cost"`
+}
+
+// Mastery represents a mastery track from /v2/masteries
+type Mastery struct {
+	ID          int            `json:"id"`
+	Name        string         `json:"name"`
+	Requirement string         `json:"requirement"`
+	Order       int            `json:"order"`
+	Background  string         `json:"background,omitempty"`
+	Region      string         `json:"region"`
+	Levels      []MasteryLevel `json:"levels"`
+}
+
+// AccountMastery represents the account's progress in a mastery track. Level is the
+// 0-based index of the highest unlocked level; tracks not started are omitted.
+type AccountMastery struct {
+	ID    int `json:"id"`
+	Level int `json:"level"`
+	// Name and Region are filled in by GetAccountProgress
+	Name   string `json:"name,omitempty"`
+	Region string `json:"region,omitempty"`
+}
+
+// GetMasteries retrieves every mastery track with its levels
+func (c *Client) GetMasteries(ctx context.Context) ([]Mastery, error) {
+	cacheKey := c.cache.GetMasteriesKey()
+	var masteries []Mastery
+	if c.cache.GetJSON(cacheKey, &masteries) {
+		return masteries, nil
+	}
+
+	if err := c.fetchPublic(ctx, "/masteries?ids=all", &masteries); err != nil {
+		return nil, fmt.Errorf("failed to fetch masteries: %w", err)
+	}
+
+	if err := c.cache.SetJSON(cacheKey, masteries, cache.MasteryDataTTL); err != nil {
+		c.logger.Warn("Failed to cache masteries", "error", err)
+	}
+	return masteries, nil
+}
+
+// GetAccountMasteries retrieves the account's progress in each started mastery track
+func (c *Client) GetAccountMasteries(ctx context.Context) ([]AccountMastery, error) {
+	data, err := c.fetchAccountProgress(ctx, "masteries")
+	if err != nil {
+		return nil, err
+	}
+
+	var masteries []AccountMastery
+	if err := json.Unmarshal(data, &masteries); err != nil {
+		return nil, fmt.Errorf("failed to decode account masteries: %w", err)
+	}
+	return masteries, nil
+}
+
+// MasteryPointTotal represents the mastery points earned and spent in a region
+type MasteryPointTotal struct {
+	Region string `json:"region"`
+	Spent  int    `json:"spent"`
+	Earned int    `json:"earned"`
+}
+
+// AccountMasteryPoints represents the account's mastery point totals and the IDs of the
+// mastery point insights and challenges it has unlocked
+type AccountMasteryPoints struct {
+	Totals   []MasteryPointTotal `json:"totals"`
+	Unlocked []int               `json:"unlocked"`
+}
+
+// GetAccountMasteryPoints retrieves the account's mastery point totals per region
+func (c *Client) GetAccountMasteryPoints(ctx context.Context) (*AccountMasteryPoints, error) {
+	data, err := c.fetchAccountProgress(ctx, "mastery/points")
+	if err != nil {
+		return nil, err
+	}
+
+	var points AccountMasteryPoints
+	if err := json.Unmarshal(data, &points); err != nil {
+		return nil, fmt.Errorf("failed to decode mastery points: %w", err)
+	}
+	return &points, nil
+}
+
+// --- Maps and Continents ---
+
+// Continent represents a continent from /v2/continents
+type Continent struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	ContinentDims [2]int `json:"continent_dims"`
+	MinZoom       int    `json:"min_zoom"`
+	MaxZoom       int    `json:"max_zoom"`
+	Floors        []int  `json:"floors"`
+}
+
+// PointOfInterest represents a landmark, waypoint, vista or unlock on a map
+type PointOfInterest struct {
+	ID       int        `json:"id"`
+	Name     string     `json:"name,omitempty"`
+	Type     string     `json:"type"`
+	Floor    int        `json:"floor"`
+	Coord    [2]float64 `json:"coord"`
+	ChatLink string     `json:"chat_link"`
+	Icon     string     `json:"icon,omitempty"`
+}
+
+// MapTask represents a renown heart on a map
+type MapTask struct {
+	ID        int          `json:"id"`
+	Objective string       `json:"objective"`
+	Level     int          `json:"level"`
+	Coord     [2]float64   `json:"coord"`
+	Bounds    [][2]float64 `json:"bounds,omitempty"`
+	ChatLink  string       `json:"chat_link"`
+}
+
+// SkillChallenge represents a hero challenge on a map
+type SkillChallenge struct {
+	ID    string     `json:"id"`
+	Coord [2]float64 `json:"coord"`
+}
+
+// MapSector represents a named area of a map
+type MapSector struct {
+	ID       int          `json:"id"`
+	Name     string       `json:"name,omitempty"`
+	Level    int          `json:"level"`
+	Coord    [2]float64   `json:"coord"`
+	Bounds   [][2]float64 `json:"bounds,omitempty"`
+	ChatLink string       `json:"chat_link"`
+}
+
+// MasteryPoint represents a mastery insight on a map
+type MasteryPoint struct {
+	ID     int        `json:"id"`
+	Region string     `json:"region"`
+	Coord  [2]float64 `json:"coord"`
+}
+
+// ContinentMap represents a map with its points of interest, as nested in a continent floor
+type ContinentMap struct {
+	ID               int                        `json:"id"`
+	Name             string                     `json:"name"`
+	MinLevel         int                        `json:"min_level"`
+	MaxLevel         int                        `json:"max_level"`
+	DefaultFloor     int                        `json:"default_floor"`
+	LabelCoord       [2]float64                 `json:"label_coord"`
+	MapRect          [2][2]float64              `json:"map_rect"`
+	ContinentRect    [2][2]float64              `json:"continent_rect"`
+	PointsOfInterest map[string]PointOfInterest `json:"points_of_interest"`
+	Tasks            map[string]MapTask         `json:"tasks"`
+	SkillChallenges  []SkillChallenge           `json:"skill_challenges"`
+	Sectors          map[string]MapSector       `json:"sectors"`
+	MasteryPoints    []MasteryPoint             `json:"mastery_points,omitempty"`
+}
+
+// ContinentRegion represents a region of a continent floor
+type ContinentRegion struct {
+	ID            int                     `json:"id"`
+	Name          string                  `json:"name"`
+	LabelCoord    [2]float64              `json:"label_coord"`
+	ContinentRect [2][2]float64           `json:"continent_rect"`
+	Maps          map[string]ContinentMap `json:"maps"`
+}
+
+// ContinentFloor represents a floor of a continent with every region and map on it
+type ContinentFloor struct {
+	ID          int                        `json:"id"`
+	TextureDims [2]int                     `json:"texture_dims"`
+	ClampedView [][2]float64               `json:"clamped_view,omitempty"`
+	Regions     map[string]ContinentRegion `json:"regions"`
+}
+
+// MapInfo represents map metadata from /v2/maps
+type MapInfo struct {
+	ID            int           `json:"id"`
+	Name          string        `json:"name"`
+	MinLevel      int           `json:"min_level"`
+	MaxLevel      int           `json:"max_level"`
+	DefaultFloor  int           `json:"default_floor"`
+	Type          string        `json:"type"`
+	Floors        []int         `json:"floors"`
+	RegionID      int           `json:"region_id"`
+	RegionName    string        `json:"region_name"`
+	ContinentID   int           `json:"continent_id"`
+	ContinentName string        `json:"continent_name"`
+	MapRect       [2][2]float64 `json:"map_rect"`
+	ContinentRect [2][2]float64 `json:"continent_rect"`
+}
+
+// GetContinents retrieves every continent
+func (c *Client) GetContinents(ctx context.Context) ([]Continent, error) {
+	cacheKey := c.cache.GetContinentsKey()
+	var continents []Continent
+	if c.cache.GetJSON(cacheKey, &continents) {
+		return continents, nil
+	}
+
+	if err := c.fetchPublic(ctx, "/continents?ids=all", &continents); err != nil {
+		return nil, fmt.Errorf("failed to fetch continents: %w", err)
+	}
+
+	if err := c.cache.SetJSON(cacheKey, continents, cache.MapDataTTL); err != nil {
+		c.logger.Warn("Failed to cache continents", "error", err)
+	}
+	return continents, nil
+}
+
+// GetContinentFloor retrieves a continent floor with all of its regions, maps, points of
+// interest, hearts, hero challenges and sectors
+func (c *Client) GetContinentFloor(ctx context.Context, continentID, floorID int) (*ContinentFloor, error) {
+	cacheKey := c.cache.GetContinentFloorKey(continentID, floorID)
+	var floor ContinentFloor
+	if c.cache.GetJSON(cacheKey, &floor) {
+		return &floor, nil
+	}
+
+	path := fmt.Sprintf("/continents/%d/floors/%d", continentID, floorID)
+	if err := c.fetchPublic(ctx, path, &floor); err != nil {
+		return nil, fmt.Errorf("failed to fetch continent %d floor %d: %w", continentID, floorID, err)
+	}
+
+	if err := c.cache.SetJSON(cacheKey, floor, cache.MapDataTTL); err != nil {
+		c.logger.Warn("Failed to cache continent floor", "continent", continentID, "floor", floorID, "error", err)
+	}
+	return &floor, nil
+}
+
+// GetContinentMap retrieves a single map's points of interest, hearts, hero challenges
+// and sectors from the continent hierarchy
+func (c *Client) GetContinentMap(ctx context.Context, continentID, floorID, regionID, mapID int) (*ContinentMap, error) {
+	cacheKey := c.cache.GetContinentMapKey(continentID, floorID, regionID, mapID)
+	var m ContinentMap
+	if c.cache.GetJSON(cacheKey, &m) {
+		return &m, nil
+	}
+
+	path := fmt.Sprintf("/continents/%d/floors/%d/regions/%d/maps/%d", continentID, floorID, regionID, mapID)
+	if err := c.fetchPublic(ctx, path, &m); err != nil {
+		return nil, fmt.Errorf("failed to fetch continent map %d: %w", mapID, err)
+	}
+
+	if err := c.cache.SetJSON(cacheKey, m, cache.MapDataTTL); err != nil {
+		c.logger.Warn("Failed to cache continent map", "id", mapID, "error", err)
+	}
+	return &m, nil
+}
+
+// GetMaps retrieves map metadata for the given IDs
+func (c *Client) GetMaps(ctx context.Context, ids []int) ([]MapInfo, error) {
+	var results []MapInfo
+	var missingIDs []int
+
+	for _, id := range ids {
+		var m MapInfo
+		if c.cache.GetJSON(c.cache.GetMapDetailKey(id), &m) {
+			results = append(results, m)
+		} else {
+			missingIDs = append(missingIDs, id)
+		}
+	}
+
+	for _, chunk := range chunkIDs(dedupeIDs(missingIDs), maxIDsPerRequest) {
+		var fetched []MapInfo
+		if err := ignorePartial(c.fetchPublic(ctx, "/maps?ids="+idsToParam(chunk), &fetched)); err != nil {
+			return nil, fmt.Errorf("failed to fetch maps: %w", err)
+		}
+		for _, m := range fetched {
+			results = append(results, m)
+			if err := c.cache.SetJSON(c.cache.GetMapDetailKey(m.ID), m, cache.MapDataTTL); err != nil {
+				c.logger.Warn("Failed to cache map", "id", m.ID, "error", err)
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// GetAccountMapChests retrieves the Hero's Choice map chests opened since daily reset
+func (c *Client) GetAccountMapChests(ctx context.Context) ([]string, error) {
+	data, err := c.fetchAccountDailies(ctx, "mapchests")
+	if err != nil {
+		return nil, err
+	}
+
+	var chests []string
+	if err := json.Unmarshal(data, &chests); err != nil {
+		return nil, fmt.Errorf("failed to decode map chests: %w", err)
+	}
+	return chests, nil
+}
+
+// GetCharacterHeroPoints retrieves the IDs of the hero challenges a character has completed
+func (c *Client) GetCharacterHeroPoints(ctx context.Context, name string) ([]string, error) {
+	if err := c.requireAPIKey(); err != nil {
+		return nil, err
+	}
+
+	cacheKey := c.cache.GetCharacterHeroPointsKey(c.apiKeyHash(), name)
+	var points []string
+	if c.cache.GetJSON(cacheKey, &points) {
+		return points, nil
+	}
+
+	if err := c.fetchAuthenticated(ctx, "/characters/"+url.PathEscape(name)+"/heropoints", &points); err != nil {
+		return nil, fmt.Errorf("failed to fetch hero points for character %q: %w", name, err)
+	}
+
+	if err := c.cache.SetJSON(cacheKey, points, cache.AccountDataTTL); err != nil {
+		c.logger.Warn("Failed to cache character hero points", "name", name, "error", err)
+	}
+	return points, nil
 }
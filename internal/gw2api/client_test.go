@@ -0,0 +1,324 @@
+package gw2api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/AlyxPink/gw2-mcp/internal/cache"
+)
+
+// readFixture reads a recorded GW2 API response from testdata
+func readFixture(t *testing.T, name string) json.RawMessage {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("reading fixture: %v", err)
+	}
+	return data
+}
+
+// decodeStrict decodes a fixture and fails on fields the model does not know, so new or
+// renamed API fields show up as test failures
+func decodeStrict(t *testing.T, name string, dest any) {
+	t.Helper()
+	dec := json.NewDecoder(bytes.NewReader(readFixture(t, name)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dest); err != nil {
+		t.Fatalf("decoding %s: %v", name, err)
+	}
+}
+
+func TestFixturesDecode(t *testing.T) {
+	tests := []struct {
+		fixture string
+		dest    any
+	}{
+		{"account_finishers.json", &[]UnlockedFinisher{}},
+		{"account_achievements.json", &[]AccountAchievement{}},
+		{"account_masteries.json", &[]AccountMastery{}},
+		{"account_mastery_points.json", &AccountMasteryPoints{}},
+		{"account_legendaryarmory.json", &[]AccountLegendary{}},
+		{"account_luck.json", &[]AccountProgression{}},
+		{"account_progression.json", &[]AccountProgression{}},
+		{"account_wizardsvault_daily.json", &WizardsVaultProgress{}},
+		{"account_wizardsvault_listings.json", &[]WizardsVaultListing{}},
+		{"wizardsvault.json", &WizardsVaultSeason{}},
+		{"guild_members.json", &[]GuildMember{}},
+		{"guild_ranks.json", &[]GuildRank{}},
+		{"guild_stash.json", &[]GuildStashSection{}},
+		{"guild_treasury.json", &[]GuildTreasuryItem{}},
+		{"guild_upgrades.json", &[]GuildUpgrade{}},
+		{"guild_storage.json", &[]GuildStorageItem{}},
+		{"guild_log.json", &[]GuildLogEntry{}},
+		{"guild_teams.json", &[]GuildTeam{}},
+		{"mounts_skins.json", &[]MountSkin{}},
+		{"mounts_types.json", &[]MountType{}},
+		{"dungeons.json", &[]Dungeon{}},
+		{"raids.json", &[]Raid{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			decodeStrict(t, tt.fixture, tt.dest)
+			if reflect.ValueOf(tt.dest).Elem().IsZero() {
+				t.Errorf("%s decoded to an empty value", tt.fixture)
+			}
+		})
+	}
+}
+
+func TestDecodeAccountUnlocks(t *testing.T) {
+	permanent, limited := true, false
+	tests := []struct {
+		unlockType string
+		fixture    string
+		want       []AccountUnlock
+	}{
+		{"skins", "account_skins.json", []AccountUnlock{{ID: 1}, {ID: 2}, {ID: 5}, {ID: 2541}}},
+		{"finishers", "account_finishers.json", []AccountUnlock{
+			{ID: 1, Permanent: &permanent},
+			{ID: 2, Permanent: &limited, Quantity: 4},
+		}},
+		{"emotes", "account_emotes.json", []AccountUnlock{{Key: "bless"}, {Key: "heroic"}, {Key: "hiss"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.unlockType, func(t *testing.T) {
+			got, err := decodeAccountUnlocks(tt.unlockType, readFixture(t, tt.fixture))
+			if err != nil {
+				t.Fatalf("decodeAccountUnlocks() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeAccountUnlocks() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := decodeAccountUnlocks("skins", readFixture(t, "account_emotes.json")); err == nil {
+		t.Error("decoding emote names as skin IDs should fail")
+	}
+}
+
+func TestGuildFixtures(t *testing.T) {
+	var teams []GuildTeam
+	decodeStrict(t, "guild_teams.json", &teams)
+	team := teams[0]
+	if team.Aggregate.Wins != 12 || team.Ladders["ranked"].Wins != 10 || len(team.Members) != 2 {
+		t.Errorf("team = %+v", team)
+	}
+	if game := team.Games[0]; game.Scores.Red != 500 || game.Ended.Sub(game.Started).Minutes() < 12 {
+		t.Errorf("game = %+v", game)
+	}
+
+	var entries []GuildLogEntry
+	decodeStrict(t, "guild_log.json", &entries)
+	types := map[string]bool{}
+	for _, e := range entries {
+		types[e.Type] = true
+	}
+	for _, want := range []string{"joined", "invited", "kick", "rank_change", "treasury", "stash", "motd", "upgrade", "invite_declined"} {
+		if !types[want] {
+			t.Errorf("guild_log.json has no %s entry", want)
+		}
+	}
+	if entries[0].Operation != "withdraw" || entries[0].ItemID != 19721 {
+		t.Errorf("stash entry = %+v", entries[0])
+	}
+}
+
+func TestWizardsVaultFixtures(t *testing.T) {
+	var season WizardsVaultSeason
+	decodeStrict(t, "wizardsvault.json", &season)
+	if season.Title != "Season 1" || !season.End.After(season.Start) || len(season.Objectives) != 3 {
+		t.Errorf("season = %+v", season)
+	}
+
+	var daily WizardsVaultProgress
+	decodeStrict(t, "account_wizardsvault_daily.json", &daily)
+	if daily.MetaProgressComplete != 4 || len(daily.Objectives) != 2 {
+		t.Fatalf("daily = %+v", daily)
+	}
+	if !daily.Objectives[0].Done() || daily.Objectives[1].Done() {
+		t.Errorf("objectives done = %v, %v; want true, false", daily.Objectives[0].Done(), daily.Objectives[1].Done())
+	}
+
+	var listings []WizardsVaultListing
+	decodeStrict(t, "account_wizardsvault_listings.json", &listings)
+	if got := listings[0].Remaining(); got != 3 {
+		t.Errorf("limited listing remaining = %d, want 3", got)
+	}
+	if got := listings[1].Remaining(); got != -1 {
+		t.Errorf("unlimited listing remaining = %d, want -1", got)
+	}
+}
+
+func TestGuildManagementFixtures(t *testing.T) {
+	var members []GuildMember
+	decodeStrict(t, "guild_members.json", &members)
+	if len(members) != 3 || members[0].Joined == nil || members[2].Joined != nil {
+		t.Errorf("members = %+v; want a join time for all but the oldest member", members)
+	}
+
+	var ranks []GuildRank
+	decodeStrict(t, "guild_ranks.json", &ranks)
+	if ranks[0].ID != "Leader" || ranks[0].Order >= ranks[1].Order || len(ranks[1].Permissions) != 2 {
+		t.Errorf("ranks = %+v", ranks)
+	}
+
+	var stash []GuildStashSection
+	decodeStrict(t, "guild_stash.json", &stash)
+	if tab := stash[0]; tab.UpgradeID != 55 || len(tab.Inventory) != 3 || tab.Inventory[1] != nil || tab.Inventory[0].ID != 19721 {
+		t.Errorf("stash tab = %+v; want an empty middle slot", tab)
+	}
+
+	var treasury []GuildTreasuryItem
+	decodeStrict(t, "guild_treasury.json", &treasury)
+	if item := treasury[0]; item.ItemID != 19721 || len(item.NeededBy) != 2 || item.NeededBy[1].UpgradeID != 382 {
+		t.Errorf("treasury item = %+v", item)
+	}
+
+	var upgrades []GuildUpgrade
+	decodeStrict(t, "guild_upgrades.json", &upgrades)
+	if bag := upgrades[0]; bag.Type != "BankBag" || bag.BagMaxItems != 50 {
+		t.Errorf("bank bag upgrade = %+v", bag)
+	}
+	tavern := upgrades[1]
+	if len(tavern.Prerequisites) != 1 || len(tavern.Costs) != 3 || tavern.Costs[1].Type != "Collectible" || tavern.Costs[1].ItemID != 70957 {
+		t.Errorf("tavern upgrade = %+v", tavern)
+	}
+}
+
+// roundTripFunc serves HTTP requests from a function instead of the network
+type roundTripFunc func(*http.Request) *http.Response
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+// fakeGuildLog serves a guild log holding entries 1 to *newest. Without ?since= it returns
+// the latest page; with it, up to a page of the entries after since, newest first.
+func fakeGuildLog(t *testing.T, newest *int, requests *[]string) *http.Client {
+	return &http.Client{Transport: roundTripFunc(func(req *http.Request) *http.Response {
+		since := req.URL.Query().Get("since")
+		*requests = append(*requests, since)
+
+		from := max(1, *newest-guildLogPageSize+1)
+		to := *newest
+		if since != "" {
+			n, err := strconv.Atoi(since)
+			if err != nil {
+				t.Errorf("bad since parameter %q", since)
+			}
+			from, to = n+1, min(*newest, n+guildLogPageSize)
+		}
+		var entries []GuildLogEntry
+		for id := to; id >= from; id-- {
+			entries = append(entries, GuildLogEntry{ID: id, Type: "motd"})
+		}
+		body, _ := json.Marshal(entries)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(body)), Request: req}
+	})}
+}
+
+// fakeFixtures serves testdata fixtures by API path, and 404 for any other path
+func fakeFixtures(t *testing.T, fixtures map[string]string) *http.Client {
+	return &http.Client{Transport: roundTripFunc(func(req *http.Request) *http.Response {
+		name, ok := fixtures[strings.TrimPrefix(req.URL.Path, "/v2")]
+		if !ok {
+			t.Errorf("unexpected request %s", req.URL)
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`{"text":"no such endpoint"}`)), Request: req}
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(readFixture(t, name))), Request: req}
+	})}
+}
+
+func TestGetAccountDailies(t *testing.T) {
+	c := NewClient(cache.NewManager(), nil, log.New(io.Discard), "key")
+	c.httpClient = fakeFixtures(t, map[string]string{
+		"/account/worldbosses": "account_worldbosses.json",
+		"/worldbosses":         "worldbosses.json",
+	})
+	dailies, err := c.GetAccountDailies(context.Background(), "worldbosses")
+	if err != nil {
+		t.Fatalf("GetAccountDailies() error = %v", err)
+	}
+	if want := []string{"admiral_taidha_covington", "shadow_behemoth"}; !reflect.DeepEqual(dailies.Completed, want) {
+		t.Errorf("Completed = %q, want %q", dailies.Completed, want)
+	}
+	if want := []string{"claw_of_jormag", "megadestroyer", "svanir_shaman_chief"}; !reflect.DeepEqual(dailies.Remaining, want) {
+		t.Errorf("Remaining = %q, want %q", dailies.Remaining, want)
+	}
+}
+
+func TestGetCharacterEscapesName(t *testing.T) {
+	const name = "Zoë Wynn Ravenwood"
+	var paths []string
+	c := NewClient(cache.NewManager(), nil, log.New(io.Discard), "key")
+	c.httpClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) *http.Response {
+		paths = append(paths, req.URL.EscapedPath())
+		body := `{"name":"` + name + `","profession":"Ranger","level":80}`
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Request: req}
+	})}
+
+	info, err := c.GetCharacter(context.Background(), name)
+	if err != nil {
+		t.Fatalf("GetCharacter() error = %v", err)
+	}
+	if info.Name != name {
+		t.Errorf("Name = %q, want %q", info.Name, name)
+	}
+	if want := []string{"/v2/characters/Zo%C3%AB%20Wynn%20Ravenwood"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("requested %q, want %q", paths, want)
+	}
+}
+
+func TestGetGuildLog(t *testing.T) {
+	store := cache.NewStore(t.TempDir())
+	newest := 250
+	var requests []string
+
+	c := NewClient(cache.NewManager(), store, log.New(io.Discard), "key")
+	c.httpClient = fakeGuildLog(t, &newest, &requests)
+	history, err := c.GetGuildLog(context.Background(), "guild")
+	if err != nil {
+		t.Fatalf("GetGuildLog() error = %v", err)
+	}
+	if history.Cursor() != 250 || len(history.Entries) != guildLogPageSize {
+		t.Fatalf("first fetch: cursor %d, %d entries; want 250, %d", history.Cursor(), len(history.Entries), guildLogPageSize)
+	}
+	if want := []string{"", "250"}; !reflect.DeepEqual(requests, want) {
+		t.Errorf("first fetch requests = %q, want %q", requests, want)
+	}
+
+	// A restarted server picks up the saved history and pages through the 130 entries
+	// added since, stopping at the short page
+	newest = 380
+	requests = nil
+	c = NewClient(cache.NewManager(), store, log.New(io.Discard), "key")
+	c.httpClient = fakeGuildLog(t, &newest, &requests)
+	history, err = c.GetGuildLog(context.Background(), "guild")
+	if err != nil {
+		t.Fatalf("GetGuildLog() error = %v", err)
+	}
+	if want := []string{"250", "350"}; !reflect.DeepEqual(requests, want) {
+		t.Errorf("second fetch requests = %q, want %q", requests, want)
+	}
+	if history.Cursor() != 380 || len(history.Entries) != 230 || history.GapAfter != 0 {
+		t.Errorf("second fetch: cursor %d, %d entries, gap after %d; want 380, 230, 0",
+			history.Cursor(), len(history.Entries), history.GapAfter)
+	}
+	for i := 1; i < len(history.Entries); i++ {
+		if history.Entries[i].ID != history.Entries[i-1].ID-1 {
+			t.Fatalf("history is not contiguous at %d: %d after %d", i, history.Entries[i].ID, history.Entries[i-1].ID)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package gw2api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ErrorKind classifies a failed GW2 API request. Kinds are errors themselves, so callers
+// can test an error with errors.Is(err, gw2api.ErrNotFound).
+type ErrorKind string
+
+// Error returns the kind's name
+func (k ErrorKind) Error() string { return string(k) }
+
+const (
+	// ErrInvalidKey means no key is configured, or the API rejected it
+	ErrInvalidKey ErrorKind = "invalid_key"
+	// ErrMissingScope means the key is valid but not allowed to read the endpoint
+	ErrMissingScope ErrorKind = "missing_scope"
+	// ErrNotFound means the requested ID, name or character does not exist
+	ErrNotFound ErrorKind = "not_found"
+	// ErrRateLimited means too many requests were made with the key or from this address
+	ErrRateLimited ErrorKind = "rate_limited"
+	// ErrUpstream means the API failed or is disabled; retrying later may work
+	ErrUpstream ErrorKind = "upstream"
+	// ErrPartial means only some of the requested IDs exist. It is returned together with
+	// the data of the IDs that do.
+	ErrPartial ErrorKind = "partial"
+	// ErrBadRequest means the request itself was wrong, such as a malformed parameter;
+	// repeating it will fail the same way
+	ErrBadRequest ErrorKind = "bad_request"
+)
+
+// APIError is a failed GW2 API request with the message from the API's {"text": ...} body
+type APIError struct {
+	Kind   ErrorKind
+	Status int
+	Text   string
+	// Scope is the permission named by a missing-scope error, when the API names one
+	Scope string
+}
+
+// errNoAPIKey is returned by keyed requests when GW2_API_KEY is not set
+var errNoAPIKey = &APIError{Kind: ErrInvalidKey, Text: "GW2_API_KEY environment variable not configured"}
+
+// errPartialContent is returned with the data of a 206 response
+var errPartialContent = &APIError{Kind: ErrPartial, Status: http.StatusPartialContent, Text: "some of the requested IDs do not exist"}
+
+// Error keeps the "API request failed with status" form the fetch helpers always used
+func (e *APIError) Error() string {
+	if e.Status == 0 {
+		return e.Text
+	}
+	return fmt.Sprintf("API request failed with status %d: %s", e.Status, e.Text)
+}
+
+// Unwrap returns the error's kind so errors.Is matches it
+func (e *APIError) Unwrap() error { return e.Kind }
+
+// Retryable reports whether the same request may succeed later
+func (e *APIError) Retryable() bool {
+	return e.Kind == ErrRateLimited || e.Kind == ErrUpstream
+}
+
+// newAPIError classifies a non-OK response from its status and {"text": ...} body
+func newAPIError(status int, body []byte) *APIError {
+	var payload struct {
+		Text string `json:"text"`
+	}
+	text := strings.TrimSpace(string(body))
+	if err := json.Unmarshal(body, &payload); err == nil && payload.Text != "" {
+		text = payload.Text
+	}
+
+	e := &APIError{Status: status, Text: text}
+	lower := strings.ToLower(text)
+	switch {
+	case status == http.StatusUnauthorized:
+		e.Kind = ErrInvalidKey
+	case status == http.StatusForbidden && (strings.Contains(lower, "invalid access token") || strings.Contains(lower, "invalid key")):
+		e.Kind = ErrInvalidKey
+	case status == http.StatusForbidden:
+		e.Kind = ErrMissingScope
+		if _, scope, ok := strings.Cut(lower, "requires scope "); ok && len(strings.Fields(scope)) > 0 {
+			e.Scope = strings.Fields(scope)[0]
+		}
+	case status == http.StatusNotFound:
+		e.Kind = ErrNotFound
+	case status == http.StatusBadRequest && (strings.Contains(lower, "no such") || strings.Contains(lower, "invalid id")):
+		e.Kind = ErrNotFound
+	case status == http.StatusTooManyRequests:
+		e.Kind = ErrRateLimited
+	case status == http.StatusPartialContent:
+		e.Kind = ErrPartial
+	case status >= 400 && status < 500:
+		e.Kind = ErrBadRequest
+	default:
+		e.Kind = ErrUpstream
+	}
+	return e
+}
+
+// readAPIError reads a failed response's body into an *APIError
+func readAPIError(resp *http.Response) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("API request failed with status %d and failed to read body: %w", resp.StatusCode, err)
+	}
+	return newAPIError(resp.StatusCode, body)
+}
+
+// decodeResponse decodes a response's JSON body into dest. A 206 response holds the
+// requested IDs that exist, so its body is decoded too and an ErrPartial error returned
+// with it; callers fetching batches of IDs keep the data and ignore the error.
+func decodeResponse(resp *http.Response, dest interface{}) error {
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return json.NewDecoder(resp.Body).Decode(dest)
+	case http.StatusPartialContent:
+		if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+			return err
+		}
+		return errPartialContent
+	}
+	return readAPIError(resp)
+}
+
+// readResponse reads a response's raw JSON body, returning a 206 body with an ErrPartial
+// error like decodeResponse
+func readResponse(resp *http.Response) (json.RawMessage, error) {
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		return nil, readAPIError(resp)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusPartialContent {
+		return json.RawMessage(body), errPartialContent
+	}
+	return json.RawMessage(body), nil
+}
+
+// ignorePartial drops an ErrPartial error, whose data holds every requested ID that exists
+func ignorePartial(err error) error {
+	if errors.Is(err, ErrPartial) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,107 @@
+package gw2api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNewAPIError(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		wantKind  ErrorKind
+		wantText  string
+		wantScope string
+	}{
+		{"invalid key", 401, `{"text":"Invalid access token"}`, ErrInvalidKey, "Invalid access token", ""},
+		{"invalid key as 403", 403, `{"text":"Invalid access token"}`, ErrInvalidKey, "Invalid access token", ""},
+		{"missing scope", 403, `{"text":"requires scope inventories"}`, ErrMissingScope, "requires scope inventories", "inventories"},
+		{"guild leader only", 403, `{"text":"access restricted to guild leaders"}`, ErrMissingScope, "access restricted to guild leaders", ""},
+		{"no such character", 404, `{"text":"no such character"}`, ErrNotFound, "no such character", ""},
+		{"bad id", 400, `{"text":"invalid id"}`, ErrNotFound, "invalid id", ""},
+		{"rate limited", 429, `{"text":"too many requests"}`, ErrRateLimited, "too many requests", ""},
+		{"partial", 206, `[{"id":1}]`, ErrPartial, `[{"id":1}]`, ""},
+		{"bad parameter", 400, `{"text":"invalid type"}`, ErrBadRequest, "invalid type", ""},
+		{"api disabled", 503, `{"text":"API not active"}`, ErrUpstream, "API not active", ""},
+		{"plain body", 502, "Bad Gateway\n", ErrUpstream, "Bad Gateway", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newAPIError(tt.status, []byte(tt.body))
+			if err.Kind != tt.wantKind || err.Text != tt.wantText || err.Scope != tt.wantScope {
+				t.Errorf("newAPIError() = %+v, want kind %s, text %q, scope %q", err, tt.wantKind, tt.wantText, tt.wantScope)
+			}
+			if err.Status != tt.status {
+				t.Errorf("Status = %d, want %d", err.Status, tt.status)
+			}
+		})
+	}
+}
+
+func TestAPIErrorWrapping(t *testing.T) {
+	err := fmt.Errorf("failed to fetch bank: %w", newAPIError(403, []byte(`{"text":"requires scope inventories"}`)))
+
+	if !errors.Is(err, ErrMissingScope) {
+		t.Error("errors.Is(err, ErrMissingScope) = false, want true")
+	}
+	if errors.Is(err, ErrInvalidKey) {
+		t.Error("errors.Is(err, ErrInvalidKey) = true, want false")
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Scope != "inventories" {
+		t.Errorf("errors.As() = %+v", apiErr)
+	}
+	if want := "failed to fetch bank: API request failed with status 403: requires scope inventories"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+	if errNoAPIKey.Error() != "GW2_API_KEY environment variable not configured" || !errors.Is(errNoAPIKey, ErrInvalidKey) {
+		t.Errorf("errNoAPIKey = %v", errNoAPIKey)
+	}
+}
+
+func TestAPIErrorRetryable(t *testing.T) {
+	for kind, want := range map[ErrorKind]bool{
+		ErrInvalidKey: false, ErrMissingScope: false, ErrNotFound: false,
+		ErrRateLimited: true, ErrUpstream: true, ErrPartial: false, ErrBadRequest: false,
+	} {
+		if got := (&APIError{Kind: kind}).Retryable(); got != want {
+			t.Errorf("%s Retryable() = %v, want %v", kind, got, want)
+		}
+	}
+}
+
+func TestDecodeResponse(t *testing.T) {
+	response := func(status int, body string) *http.Response {
+		return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body))}
+	}
+
+	var items []Item
+	if err := decodeResponse(response(200, `[{"id":1}]`), &items); err != nil || len(items) != 1 {
+		t.Errorf("200: items = %+v, err = %v", items, err)
+	}
+
+	// A 206 body holds the IDs that exist and comes with a partial error
+	items = nil
+	err := decodeResponse(response(206, `[{"id":1},{"id":2}]`), &items)
+	if !errors.Is(err, ErrPartial) || len(items) != 2 {
+		t.Errorf("206: items = %+v, err = %v", items, err)
+	}
+	if ignorePartial(err) != nil {
+		t.Errorf("ignorePartial(%v) should be nil", err)
+	}
+
+	err = decodeResponse(response(400, `{"text":"invalid type"}`), &items)
+	if !errors.Is(err, ErrBadRequest) || ignorePartial(err) == nil {
+		t.Errorf("400: err = %v, want bad_request", err)
+	}
+
+	raw, err := readResponse(response(206, `[{"id":3}]`))
+	if !errors.Is(err, ErrPartial) || string(raw) != `[{"id":3}]` {
+		t.Errorf("readResponse(206) = %s, %v", raw, err)
+	}
+}
@@ -0,0 +1,377 @@
+package gw2api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AlyxPink/gw2-mcp/internal/cache"
+)
+
+// IndexedItem is an item's entry in the item index
+type IndexedItem struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Rarity      string `json:"rarity"`
+	Tradeable   bool   `json:"tradeable"`
+	DefaultSkin int    `json:"default_skin,omitempty"`
+}
+
+// ItemIndex lists every named item of a game build. It is built once from /v2/items and
+// serves both name lookups and the skin-to-item sources.
+type ItemIndex struct {
+	Build int           `json:"build"`
+	Items []IndexedItem `json:"items"`
+}
+
+// SkinSources maps skin IDs to the tradeable items whose default skin they are
+type SkinSources map[int][]int
+
+// SkinSources returns the tradeable items of the index by default skin
+func (idx *ItemIndex) SkinSources() SkinSources {
+	sources := make(SkinSources)
+	for _, item := range idx.Items {
+		if item.DefaultSkin > 0 && item.Tradeable {
+			sources[item.DefaultSkin] = append(sources[item.DefaultSkin], item.ID)
+		}
+	}
+	return sources
+}
+
+// ItemNameFilter restricts item name matches to a rarity and item type; empty fields
+// match anything
+type ItemNameFilter struct {
+	Rarity string
+	Type   string
+}
+
+// Matches reports whether item passes the filter, ignoring case
+func (f ItemNameFilter) Matches(item IndexedItem) bool {
+	return (f.Rarity == "" || strings.EqualFold(item.Rarity, f.Rarity)) &&
+		(f.Type == "" || strings.EqualFold(item.Type, f.Type))
+}
+
+// MatchKind says how closely an item name matches a query, best first
+type MatchKind int
+
+const (
+	// MatchExact names equal the query, ignoring case and spacing
+	MatchExact MatchKind = iota
+	// MatchPrefix names start with the query
+	MatchPrefix
+	// MatchWords names have a word starting with each query word, in order
+	// (e.g. "glob ecto" for "Glob of Ectoplasm")
+	MatchWords
+	// MatchContains names contain the query
+	MatchContains
+	// MatchFuzzy names are a few typos away from the query
+	MatchFuzzy
+)
+
+// ItemNameMatch is an item found by name and how closely it matched
+type ItemNameMatch struct {
+	IndexedItem
+	Kind MatchKind `json:"-"`
+}
+
+// Search returns up to limit items matching query, best match first. Exact, prefix,
+// word and substring matches are tried before typo-tolerant ones. Among equally close
+// matches, shorter names, tradeable items and lower IDs come first.
+func (idx *ItemIndex) Search(query string, filter ItemNameFilter, limit int) []ItemNameMatch {
+	q := normalizeItemName(query)
+	if q == "" {
+		return nil
+	}
+	words := strings.Fields(q)
+	maxTypos := max(1, len(q)/5)
+
+	var matches []ItemNameMatch
+	for _, item := range idx.Items {
+		if !filter.Matches(item) {
+			continue
+		}
+		if kind, ok := matchItemName(normalizeItemName(item.Name), q, words, maxTypos); ok {
+			matches = append(matches, ItemNameMatch{IndexedItem: item, Kind: kind})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if len(a.Name) != len(b.Name) {
+			return len(a.Name) < len(b.Name)
+		}
+		if a.Tradeable != b.Tradeable {
+			return a.Tradeable
+		}
+		return a.ID < b.ID
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// CompleteNames returns up to limit distinct item names that start with prefix, ignoring
+// case, shortest first, and how many names match in total
+func (idx *ItemIndex) CompleteNames(prefix string, filter ItemNameFilter, limit int) ([]string, int) {
+	prefix = normalizeItemName(prefix)
+	seen := make(map[string]bool)
+	var names []string
+	for _, item := range idx.Items {
+		if seen[item.Name] || !filter.Matches(item) || !strings.HasPrefix(normalizeItemName(item.Name), prefix) {
+			continue
+		}
+		seen[item.Name] = true
+		names = append(names, item.Name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if len(names[i]) != len(names[j]) {
+			return len(names[i]) < len(names[j])
+		}
+		return names[i] < names[j]
+	})
+	total := len(names)
+	if limit > 0 && len(names) > limit {
+		names = names[:limit]
+	}
+	return names, total
+}
+
+// normalizeItemName lowercases a name, unifies apostrophes and collapses whitespace
+func normalizeItemName(name string) string {
+	name = strings.ReplaceAll(strings.ToLower(name), "’", "'")
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// matchItemName reports how a normalized name matches a normalized query
+func matchItemName(name, query string, words []string, maxTypos int) (MatchKind, bool) {
+	switch {
+	case name == query:
+		return MatchExact, true
+	case strings.HasPrefix(name, query):
+		return MatchPrefix, true
+	case matchWordPrefixes(strings.Fields(name), words):
+		return MatchWords, true
+	case strings.Contains(name, query):
+		return MatchContains, true
+	}
+	// Names much longer or shorter than the query cannot be within a few typos
+	if diff := len(name) - len(query); diff > maxTypos || -diff > maxTypos {
+		return 0, false
+	}
+	if editDistance(name, query, maxTypos) <= maxTypos {
+		return MatchFuzzy, true
+	}
+	return 0, false
+}
+
+// matchWordPrefixes reports whether each query word starts a name word, in order
+func matchWordPrefixes(nameWords, queryWords []string) bool {
+	i := 0
+	for _, w := range nameWords {
+		if i < len(queryWords) && strings.HasPrefix(w, queryWords[i]) {
+			i++
+		}
+	}
+	return i == len(queryWords)
+}
+
+// editDistance returns the Levenshtein distance between a and b, stopping early with a
+// value above limit once the distance is known to exceed it
+func editDistance(a, b string, limit int) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// itemIndexFile is the name the latest item index is saved under in the data directory
+const itemIndexFile = "item-index"
+
+// LoadItemIndex loads the item index saved in the data directory, if any, so it is
+// ready for its game build and is the base the index of a newer build is updated from
+func (c *Client) LoadItemIndex() error {
+	var index ItemIndex
+	found, err := c.store.Load(itemIndexFile, &index)
+	if err != nil || !found {
+		return err
+	}
+	c.cacheItemIndex(&index)
+	c.logger.Info("Loaded item index", "build", index.Build, "items", len(index.Items))
+	return nil
+}
+
+// ReadyItemIndex returns the index of every item if it is already built for the current
+// game build. Otherwise it starts building the index in the background, since reading
+// every item from /v2/items takes too long to wait for, and reports false. After a patch
+// the previous build's index, in memory or saved in the data directory, is updated with
+// the items added since, so only the very first build reads every item.
+func (c *Client) ReadyItemIndex(ctx context.Context) (*ItemIndex, bool) {
+	build, err := c.GetGameBuild(ctx)
+	if err != nil {
+		c.logger.Warn("Failed to get game build for item index", "error", err)
+		return nil, false
+	}
+	if index, ok := c.cachedItemIndex(build.ID); ok {
+		return index, true
+	}
+
+	if c.itemIndexMu.TryLock() {
+		go func() {
+			defer c.itemIndexMu.Unlock()
+			if _, ok := c.cachedItemIndex(build.ID); ok {
+				return
+			}
+			if _, err := c.updateItemIndex(context.WithoutCancel(ctx), build.ID); err != nil {
+				c.logger.Warn("Failed to build item index", "error", err)
+			}
+		}()
+	}
+	return nil, false
+}
+
+// cachedItemIndex returns the cached item index of a game build. The index holds
+// every item, so it is cached as is rather than as JSON to avoid decoding it per lookup.
+func (c *Client) cachedItemIndex(build int) (*ItemIndex, bool) {
+	cached, ok := c.cache.Get(c.cache.GetItemIndexKey(build))
+	if !ok {
+		return nil, false
+	}
+	index, ok := cached.(*ItemIndex)
+	return index, ok
+}
+
+// updateItemIndex builds the index of a game build from the latest index of an
+// earlier build, reading only the items added since; itemIndexMu must be held
+func (c *Client) updateItemIndex(ctx context.Context, build int) (*ItemIndex, error) {
+	latest := c.latestItemIndex()
+	if latest != nil && latest.Build == build {
+		c.cacheItemIndex(latest)
+		return latest, nil
+	}
+
+	var known map[int]IndexedItem
+	if latest != nil {
+		known = make(map[int]IndexedItem, len(latest.Items))
+		for _, item := range latest.Items {
+			known[item.ID] = item
+		}
+		// The cached ID list may predate the patch
+		c.cache.Delete(c.cache.GetItemIDsKey())
+	}
+
+	ids, err := c.GetItemIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	index := &ItemIndex{Build: build, Items: make([]IndexedItem, 0, len(ids))}
+	var missing []int
+	for _, id := range ids {
+		if item, ok := known[id]; ok {
+			index.Items = append(index.Items, item)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+
+	if known == nil {
+		c.logger.Info("Building item index", "build", build, "items", len(ids))
+	} else {
+		c.logger.Info("Updating item index", "build", build, "new_items", len(missing))
+	}
+	for _, chunk := range chunkIDs(missing, maxIDsPerRequest) {
+		items, err := c.fetchItems(ctx, chunk)
+		if err = ignorePartial(err); err != nil {
+			return nil, fmt.Errorf("failed to fetch items for item index: %w", err)
+		}
+		for _, item := range items {
+			if item.Name != "" {
+				index.Items = append(index.Items, IndexedItem{
+					ID:          item.ID,
+					Name:        item.Name,
+					Type:        item.Type,
+					Rarity:      item.Rarity,
+					Tradeable:   item.Tradeable(),
+					DefaultSkin: item.DefaultSkin(),
+				})
+			}
+		}
+	}
+
+	c.cacheItemIndex(index)
+	if err := c.store.Save(itemIndexFile, index); err != nil {
+		c.logger.Warn("Failed to save item index", "error", err)
+	}
+	return index, nil
+}
+
+// latestItemIndex returns the latest item index of any build: the cached one, or once
+// that has expired, the one saved in the data directory. It returns nil if there is none.
+func (c *Client) latestItemIndex() *ItemIndex {
+	if cached, ok := c.cache.Get(c.cache.GetItemIndexLatestKey()); ok {
+		if latest, ok := cached.(*ItemIndex); ok {
+			return latest
+		}
+	}
+	var saved ItemIndex
+	found, err := c.store.Load(itemIndexFile, &saved)
+	if err != nil {
+		c.logger.Warn("Failed to load saved item index", "error", err)
+		return nil
+	}
+	if !found {
+		return nil
+	}
+	return &saved
+}
+
+// cacheItemIndex caches an item index for its game build and as the latest index
+func (c *Client) cacheItemIndex(index *ItemIndex) {
+	c.cache.Set(c.cache.GetItemIndexKey(index.Build), index, cache.ItemIndexTTL)
+	c.cache.Set(c.cache.GetItemIndexLatestKey(), index, cache.ItemIndexTTL)
+}
+
+// ReadySkinSources returns the tradeable items by default skin if the item index is built
+// for the current game build; otherwise it starts building it like ReadyItemIndex and
+// reports false
+func (c *Client) ReadySkinSources(ctx context.Context) (SkinSources, bool) {
+	index, ok := c.ReadyItemIndex(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	cacheKey := c.cache.GetSkinSourcesKey(index.Build)
+	if cached, ok := c.cache.Get(cacheKey); ok {
+		if sources, ok := cached.(SkinSources); ok {
+			return sources, true
+		}
+	}
+	sources := index.SkinSources()
+	c.cache.Set(cacheKey, sources, cache.ItemIndexTTL)
+	return sources, true
+}
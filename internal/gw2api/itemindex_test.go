@@ -0,0 +1,185 @@
+package gw2api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/AlyxPink/gw2-mcp/internal/cache"
+)
+
+func testItemIndex() *ItemIndex {
+	return &ItemIndex{Items: []IndexedItem{
+		{ID: 1, Name: "Mystic Coin", Type: "Trophy", Rarity: "Rare", Tradeable: true},
+		{ID: 2, Name: "Mystic Clover", Type: "CraftingMaterial", Rarity: "Rare"},
+		{ID: 3, Name: "Mystic Clover", Type: "CraftingMaterial", Rarity: "Rare", Tradeable: true},
+		{ID: 4, Name: "Mystic Forge Stone", Type: "CraftingMaterial", Rarity: "Exotic", Tradeable: true},
+		{ID: 5, Name: "Glob of Ectoplasm", Type: "CraftingMaterial", Rarity: "Exotic", Tradeable: true},
+		{ID: 6, Name: "Twilight", Type: "Weapon", Rarity: "Legendary"},
+		{ID: 7, Name: "Dusk", Type: "Weapon", Rarity: "Exotic", Tradeable: true},
+		{ID: 8, Name: "Gift of Twilight", Type: "Trophy", Rarity: "Legendary"},
+	}}
+}
+
+func TestItemIndexSearch(t *testing.T) {
+	index := testItemIndex()
+	tests := []struct {
+		name     string
+		query    string
+		filter   ItemNameFilter
+		wantID   int
+		wantKind MatchKind
+	}{
+		{"exact ignores case and spacing", "  mystic   COIN ", ItemNameFilter{}, 1, MatchExact},
+		{"exact prefers tradeable duplicates", "Mystic Clover", ItemNameFilter{}, 3, MatchExact},
+		{"prefix", "Mystic Fo", ItemNameFilter{}, 4, MatchPrefix},
+		{"word prefixes", "glob ecto", ItemNameFilter{}, 5, MatchWords},
+		{"later word prefix", "ectoplasm", ItemNameFilter{}, 5, MatchWords},
+		{"contains", "toplasm", ItemNameFilter{}, 5, MatchContains},
+		{"typo", "Mystik Coin", ItemNameFilter{}, 1, MatchFuzzy},
+		{"exact before longer prefix", "twilight", ItemNameFilter{}, 6, MatchExact},
+		{"rarity filter", "twilight", ItemNameFilter{Rarity: "legendary", Type: "Trophy"}, 8, MatchWords},
+		{"type filter excludes", "dusk", ItemNameFilter{Type: "Armor"}, 0, 0},
+		{"no match", "Zhaitan", ItemNameFilter{}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := index.Search(tt.query, tt.filter, 1)
+			if tt.wantID == 0 {
+				if len(matches) != 0 {
+					t.Errorf("Search(%q) = %+v, want no matches", tt.query, matches)
+				}
+				return
+			}
+			if len(matches) != 1 || matches[0].ID != tt.wantID || matches[0].Kind != tt.wantKind {
+				t.Errorf("Search(%q) = %+v, want ID %d with kind %d", tt.query, matches, tt.wantID, tt.wantKind)
+			}
+		})
+	}
+}
+
+func TestItemIndexCompleteNames(t *testing.T) {
+	index := testItemIndex()
+
+	names, total := index.CompleteNames("MYSTIC c", ItemNameFilter{}, 0)
+	if want := []string{"Mystic Coin", "Mystic Clover"}; !reflect.DeepEqual(names, want) || total != 2 {
+		t.Errorf("CompleteNames() = %v, %d, want %v, 2", names, total, want)
+	}
+
+	names, total = index.CompleteNames("mystic", ItemNameFilter{}, 2)
+	if want := []string{"Mystic Coin", "Mystic Clover"}; !reflect.DeepEqual(names, want) || total != 3 {
+		t.Errorf("CompleteNames() = %v, %d, want %v, 3", names, total, want)
+	}
+
+	names, _ = index.CompleteNames("", ItemNameFilter{Rarity: "Legendary"}, 0)
+	if want := []string{"Twilight", "Gift of Twilight"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("CompleteNames() = %v, want %v", names, want)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		limit int
+		want  int
+	}{
+		{"mystic coin", "mystic coin", 2, 0},
+		{"mystik coin", "mystic coin", 2, 1},
+		{"mystic con", "mystic coin", 2, 1},
+		{"dusk", "twilight", 2, 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b, tt.limit); got != tt.want {
+			t.Errorf("editDistance(%q, %q, %d) = %d, want %d", tt.a, tt.b, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestLoadItemIndex(t *testing.T) {
+	store := cache.NewStore(t.TempDir())
+	saved := testItemIndex()
+	saved.Build = 170000
+	if err := store.Save(itemIndexFile, saved); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewClient(cache.NewManager(), store, log.New(io.Discard), "")
+	if err := c.LoadItemIndex(); err != nil {
+		t.Fatalf("LoadItemIndex() error = %v", err)
+	}
+	index, ok := c.cachedItemIndex(saved.Build)
+	if !ok {
+		t.Fatal("Expected the saved index to be cached for its build")
+	}
+	if !reflect.DeepEqual(index.Items, saved.Items) {
+		t.Errorf("loaded items = %v, want %v", index.Items, saved.Items)
+	}
+	latest, ok := c.cache.Get(c.cache.GetItemIndexLatestKey())
+	if !ok || latest.(*ItemIndex).Build != saved.Build {
+		t.Errorf("Expected the saved index to be the latest, got %v", latest)
+	}
+
+	// Without a saved index nothing is loaded
+	empty := NewClient(cache.NewManager(), cache.NewStore(t.TempDir()), log.New(io.Discard), "")
+	if err := empty.LoadItemIndex(); err != nil {
+		t.Fatalf("LoadItemIndex() error = %v", err)
+	}
+	if _, ok := empty.cache.Get(empty.cache.GetItemIndexLatestKey()); ok {
+		t.Error("Expected no index without a saved one")
+	}
+}
+
+func TestUpdateItemIndexFromSavedIndex(t *testing.T) {
+	store := cache.NewStore(t.TempDir())
+	saved := testItemIndex()
+	saved.Build = 170000
+	if err := store.Save(itemIndexFile, saved); err != nil {
+		t.Fatal(err)
+	}
+
+	// The index was loaded at startup, but its cache entries have since expired
+	c := NewClient(cache.NewManager(), store, log.New(io.Discard), "")
+	if err := c.LoadItemIndex(); err != nil {
+		t.Fatalf("LoadItemIndex() error = %v", err)
+	}
+	c.cache.Delete(c.cache.GetItemIndexKey(saved.Build))
+	c.cache.Delete(c.cache.GetItemIndexLatestKey())
+
+	var requests []string
+	c.httpClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) *http.Response {
+		requests = append(requests, req.URL.RequestURI())
+		body := `[1,2,3,4,5,6,7,8,9]`
+		if req.URL.Query().Has("ids") {
+			body = `[{"id":9,"name":"Gift of Dusk","type":"Trophy","rarity":"Legendary"}]`
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Request: req}
+	})}
+
+	// The same build is served from the saved file without any request
+	index, err := c.updateItemIndex(context.Background(), saved.Build)
+	if err != nil {
+		t.Fatalf("updateItemIndex() error = %v", err)
+	}
+	if len(requests) != 0 || len(index.Items) != len(saved.Items) {
+		t.Errorf("same build: %d items after requests %q; want %d items and no requests", len(index.Items), requests, len(saved.Items))
+	}
+
+	// A newer build reads only the item added since
+	c.cache.Delete(c.cache.GetItemIndexKey(saved.Build))
+	c.cache.Delete(c.cache.GetItemIndexLatestKey())
+	index, err = c.updateItemIndex(context.Background(), 170001)
+	if err != nil {
+		t.Fatalf("updateItemIndex() error = %v", err)
+	}
+	if want := []string{"/v2/items", "/v2/items?ids=9"}; !reflect.DeepEqual(requests, want) {
+		t.Errorf("new build requests = %q, want %q", requests, want)
+	}
+	if index.Build != 170001 || len(index.Items) != len(saved.Items)+1 {
+		t.Errorf("new build index has build %d and %d items, want 170001 and %d", index.Build, len(index.Items), len(saved.Items)+1)
+	}
+}
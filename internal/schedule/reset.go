@@ -0,0 +1,27 @@
+package schedule
+
+import "time"
+
+// Weekly reset happens on Monday at 07:30 UTC
+const (
+	weeklyResetDay    = time.Monday
+	weeklyResetHour   = 7
+	weeklyResetMinute = 30
+)
+
+// NextDailyReset returns the next daily reset (00:00 UTC) strictly after now
+func NextDailyReset(now time.Time) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+}
+
+// NextWeeklyReset returns the next weekly reset (Monday 07:30 UTC) strictly after now
+func NextWeeklyReset(now time.Time) time.Time {
+	now = now.UTC()
+	daysAhead := (int(weeklyResetDay) - int(now.Weekday()) + 7) % 7
+	reset := time.Date(now.Year(), now.Month(), now.Day()+daysAhead, weeklyResetHour, weeklyResetMinute, 0, 0, time.UTC)
+	if !reset.After(now) {
+		reset = reset.AddDate(0, 0, 7)
+	}
+	return reset
+}
@@ -0,0 +1,43 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextDailyReset(t *testing.T) {
+	tests := []struct {
+		now  time.Time
+		want time.Time
+	}{
+		{time.Date(2026, 10, 18, 13, 45, 0, 0, time.UTC), time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2026, 12, 31, 23, 59, 0, 0, time.UTC), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2026, 10, 18, 22, 0, 0, 0, time.FixedZone("UTC-3", -3*3600)), time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := NextDailyReset(tt.now); !got.Equal(tt.want) {
+			t.Errorf("NextDailyReset(%v) = %v, want %v", tt.now, got, tt.want)
+		}
+	}
+}
+
+func TestNextWeeklyReset(t *testing.T) {
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{"sunday", time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC), time.Date(2026, 10, 19, 7, 30, 0, 0, time.UTC)},
+		{"monday before reset", time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC), time.Date(2026, 10, 19, 7, 30, 0, 0, time.UTC)},
+		{"monday at reset", time.Date(2026, 10, 19, 7, 30, 0, 0, time.UTC), time.Date(2026, 10, 26, 7, 30, 0, 0, time.UTC)},
+		{"wednesday", time.Date(2026, 10, 21, 3, 0, 0, 0, time.UTC), time.Date(2026, 10, 26, 7, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextWeeklyReset(tt.now); !got.Equal(tt.want) {
+				t.Errorf("NextWeeklyReset(%v) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
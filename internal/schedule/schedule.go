@@ -0,0 +1,151 @@
+// Package schedule provides the bundled timetable of world boss and map meta event
+// spawns and works out which ones come up next.
+package schedule
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Event categories
+const (
+	CategoryWorldBoss = "world_boss"
+	CategoryMeta      = "meta"
+)
+
+const minutesPerDay = 24 * 60
+
+//go:embed timetable.json
+var timetableJSON []byte
+
+// Event is a recurring world boss or map meta event. Spawn times are UTC and either
+// listed explicitly in Times or generated from First every EveryMinutes through the day.
+type Event struct {
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	Map             string   `json:"map"`
+	Category        string   `json:"category"`
+	WorldBossID     string   `json:"worldboss_id,omitempty"`
+	MapChestID      string   `json:"map_chest_id,omitempty"`
+	Times           []string `json:"times,omitempty"`
+	First           string   `json:"first,omitempty"`
+	EveryMinutes    int      `json:"every_minutes,omitempty"`
+	DurationMinutes int      `json:"duration_minutes"`
+
+	starts []int // minutes after 00:00 UTC
+}
+
+// Timetable is a versioned set of recurring events
+type Timetable struct {
+	Version string  `json:"version"`
+	Source  string  `json:"source"`
+	Events  []Event `json:"events"`
+}
+
+// Occurrence is a single spawn of an event
+type Occurrence struct {
+	Event
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Active bool      `json:"active"`
+}
+
+// Load parses the bundled timetable
+func Load() (*Timetable, error) {
+	return Parse(timetableJSON)
+}
+
+// Parse parses and validates a timetable
+func Parse(data []byte) (*Timetable, error) {
+	var t Timetable
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("failed to decode timetable: %w", err)
+	}
+	for i := range t.Events {
+		starts, err := t.Events[i].startMinutes()
+		if err != nil {
+			return nil, fmt.Errorf("event %q: %w", t.Events[i].ID, err)
+		}
+		t.Events[i].starts = starts
+	}
+	return &t, nil
+}
+
+// parseClock parses an "HH:MM" time of day into minutes after midnight
+func parseClock(s string) (int, error) {
+	clock, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q: %w", s, err)
+	}
+	return clock.Hour()*60 + clock.Minute(), nil
+}
+
+// startMinutes expands the event's schedule into sorted spawn minutes within a day
+func (e Event) startMinutes() ([]int, error) {
+	var starts []int
+	switch {
+	case len(e.Times) > 0:
+		for _, s := range e.Times {
+			m, err := parseClock(s)
+			if err != nil {
+				return nil, err
+			}
+			starts = append(starts, m)
+		}
+	case e.First != "" && e.EveryMinutes > 0:
+		first, err := parseClock(e.First)
+		if err != nil {
+			return nil, err
+		}
+		for m := first % e.EveryMinutes; m < minutesPerDay; m += e.EveryMinutes {
+			starts = append(starts, m)
+		}
+	default:
+		return nil, fmt.Errorf("needs either times or first and every_minutes")
+	}
+	sort.Ints(starts)
+	return starts, nil
+}
+
+// Upcoming returns the next n occurrences that have not ended by now, including events
+// already in progress, in start order. Events rejected by keep are skipped.
+func (t *Timetable) Upcoming(now time.Time, n int, keep func(Event) bool) []Occurrence {
+	now = now.UTC()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var occurrences []Occurrence
+	for _, e := range t.Events {
+		if keep != nil && !keep(e) {
+			continue
+		}
+		duration := time.Duration(e.DurationMinutes) * time.Minute
+		// Yesterday's late spawns may still be running; tomorrow's fill the list near midnight
+		for day := -1; day <= 1; day++ {
+			base := midnight.AddDate(0, 0, day)
+			for _, m := range e.starts {
+				start := base.Add(time.Duration(m) * time.Minute)
+				end := start.Add(duration)
+				if !end.After(now) {
+					continue
+				}
+				occurrences = append(occurrences, Occurrence{
+					Event:  e,
+					Start:  start,
+					End:    end,
+					Active: !start.After(now),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].Start.Before(occurrences[j].Start)
+	})
+	if n > 0 && len(occurrences) > n {
+		occurrences = occurrences[:n]
+	}
+	return occurrences
+}
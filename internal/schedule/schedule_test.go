@@ -0,0 +1,169 @@
+package schedule
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	tt, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if tt.Version == "" {
+		t.Error("expected timetable version")
+	}
+	if len(tt.Events) == 0 {
+		t.Fatal("expected bundled events")
+	}
+
+	seen := make(map[string]bool)
+	for _, e := range tt.Events {
+		if seen[e.ID] {
+			t.Errorf("duplicate event ID %q", e.ID)
+		}
+		seen[e.ID] = true
+		if e.Category != CategoryWorldBoss && e.Category != CategoryMeta {
+			t.Errorf("event %q has unknown category %q", e.ID, e.Category)
+		}
+		if e.DurationMinutes <= 0 {
+			t.Errorf("event %q has no duration", e.ID)
+		}
+		if len(e.starts) == 0 {
+			t.Errorf("event %q has no spawn times", e.ID)
+		}
+	}
+}
+
+func TestLoad_SpawnTimes(t *testing.T) {
+	tt, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		id       string
+		category string
+		want     []string
+	}{
+		{"shadow_behemoth", CategoryWorldBoss, []string{"13:45", "15:45", "17:45"}},
+		{"octovine", CategoryMeta, []string{"13:00", "15:00", "17:00"}},
+		{"casino_blitz", CategoryMeta, []string{"12:05", "14:05", "16:05"}},
+		{"dry_top_sandstorm", CategoryMeta, []string{"12:40", "13:40", "14:40"}},
+	}
+	for _, tc := range tests {
+		got := tt.Upcoming(now, 3, func(e Event) bool { return e.ID == tc.id })
+		var starts []string
+		for _, o := range got {
+			starts = append(starts, o.Start.Format("15:04"))
+			if o.Category != tc.category {
+				t.Errorf("%s has category %q, want %q", tc.id, o.Category, tc.category)
+			}
+		}
+		if strings.Join(starts, " ") != strings.Join(tc.want, " ") {
+			t.Errorf("%s spawns at %v, want %v", tc.id, starts, tc.want)
+		}
+	}
+
+	// Every expansion map meta with a Hero's Choice Chest tracks it
+	for _, id := range []string{"verdant_brink_night_bosses", "chak_gerent", "serpents_ire", "maws_of_torment"} {
+		i := slices.IndexFunc(tt.Events, func(e Event) bool { return e.ID == id })
+		if i < 0 || tt.Events[i].MapChestID == "" {
+			t.Errorf("%s is missing or has no map chest", id)
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"bad json", `{`},
+		{"no schedule", `{"events":[{"id":"x","duration_minutes":15}]}`},
+		{"bad time", `{"events":[{"id":"x","times":["25:00"],"duration_minutes":15}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse([]byte(tt.data)); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestEvent_StartMinutes(t *testing.T) {
+	e := Event{First: "01:15", EveryMinutes: 120}
+	starts, err := e.startMinutes()
+	if err != nil {
+		t.Fatalf("startMinutes() error = %v", err)
+	}
+	if len(starts) != 12 || starts[0] != 75 || starts[11] != 23*60+15 {
+		t.Errorf("startMinutes() = %v", starts)
+	}
+
+	e = Event{Times: []string{"19:00", "00:00", "11:30"}}
+	starts, err = e.startMinutes()
+	if err != nil {
+		t.Fatalf("startMinutes() error = %v", err)
+	}
+	if len(starts) != 3 || starts[0] != 0 || starts[1] != 690 || starts[2] != 1140 {
+		t.Errorf("startMinutes() = %v", starts)
+	}
+}
+
+func testTimetable(t *testing.T) *Timetable {
+	t.Helper()
+	tt, err := Parse([]byte(`{
+		"version": "test",
+		"events": [
+			{"id": "hourly", "name": "Hourly", "category": "meta", "first": "00:40", "every_minutes": 60, "duration_minutes": 20},
+			{"id": "late", "name": "Late", "category": "world_boss", "times": ["23:50"], "duration_minutes": 30},
+			{"id": "noon", "name": "Noon", "category": "world_boss", "times": ["12:00"], "duration_minutes": 15}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return tt
+}
+
+func TestTimetable_Upcoming(t *testing.T) {
+	tt := testTimetable(t)
+	now := time.Date(2026, 10, 18, 11, 50, 0, 0, time.UTC)
+
+	got := tt.Upcoming(now, 3, nil)
+	if len(got) != 3 {
+		t.Fatalf("got %d occurrences, want 3", len(got))
+	}
+	if got[0].ID != "hourly" || !got[0].Active || !got[0].Start.Equal(now.Add(-10*time.Minute)) {
+		t.Errorf("first occurrence = %+v, want active hourly started at 11:40", got[0])
+	}
+	if got[1].ID != "noon" || got[1].Active {
+		t.Errorf("second occurrence = %+v, want upcoming noon", got[1])
+	}
+	if got[2].ID != "hourly" || got[2].Start.Hour() != 12 {
+		t.Errorf("third occurrence = %+v, want hourly at 12:40", got[2])
+	}
+}
+
+func TestTimetable_Upcoming_AcrossMidnight(t *testing.T) {
+	tt := testTimetable(t)
+	now := time.Date(2026, 10, 19, 0, 5, 0, 0, time.UTC)
+
+	got := tt.Upcoming(now, 0, func(e Event) bool { return e.Category == CategoryWorldBoss })
+	if len(got) == 0 || got[0].ID != "late" || !got[0].Active {
+		t.Fatalf("first occurrence = %+v, want yesterday's late spawn still active", got)
+	}
+	if got[0].Start.Day() != 18 {
+		t.Errorf("late spawn started on day %d, want 18", got[0].Start.Day())
+	}
+	for _, o := range got {
+		if o.Category != CategoryWorldBoss {
+			t.Errorf("filter let through %q", o.ID)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package schedule
+
+// Strike mission campaigns
+const (
+	CampaignIcebroodSaga     = "The Icebrood Saga"
+	CampaignEndOfDragons     = "End of Dragons"
+	CampaignSecretsOfObscure = "Secrets of the Obscure"
+)
+
+// StrikeMission is a strike mission whose rewards reset weekly. The GW2 API does not
+// list strike missions or report which ones an account has cleared, so they are bundled.
+type StrikeMission struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Campaign string `json:"campaign"`
+}
+
+// StrikeMissions lists every strike mission in release order
+var StrikeMissions = []StrikeMission{
+	{ID: "shiverpeak_pass", Name: "Shiverpeak Pass", Campaign: CampaignIcebroodSaga},
+	{ID: "voice_and_claw", Name: "Voice of the Fallen and Claw of the Fallen", Campaign: CampaignIcebroodSaga},
+	{ID: "fraenir_of_jormag", Name: "Fraenir of Jormag", Campaign: CampaignIcebroodSaga},
+	{ID: "boneskinner", Name: "Boneskinner", Campaign: CampaignIcebroodSaga},
+	{ID: "whisper_of_jormag", Name: "Whisper of Jormag", Campaign: CampaignIcebroodSaga},
+	{ID: "forging_steel", Name: "Forging Steel", Campaign: CampaignIcebroodSaga},
+	{ID: "cold_war", Name: "Cold War", Campaign: CampaignIcebroodSaga},
+	{ID: "aetherblade_hideout", Name: "Aetherblade Hideout", Campaign: CampaignEndOfDragons},
+	{ID: "xunlai_jade_junkyard", Name: "Xunlai Jade Junkyard", Campaign: CampaignEndOfDragons},
+	{ID: "kaineng_overlook", Name: "Kaineng Overlook", Campaign: CampaignEndOfDragons},
+	{ID: "harvest_temple", Name: "Harvest Temple", Campaign: CampaignEndOfDragons},
+	{ID: "old_lions_court", Name: "Old Lion's Court", Campaign: CampaignEndOfDragons},
+	{ID: "cosmic_observatory", Name: "Cosmic Observatory", Campaign: CampaignSecretsOfObscure},
+	{ID: "temple_of_febe", Name: "Temple of Febe", Campaign: CampaignSecretsOfObscure},
+}
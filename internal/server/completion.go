@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/AlyxPink/gw2-mcp/internal/gw2api"
+)
+
+// maxCompletions is the most values a completion may return
+const maxCompletions = 100
+
+// handleComplete suggests values for prompt and resource template arguments. MCP only
+// completes those, not tool arguments, so each by-name tool has a prompt or resource
+// template taking the same kind of name.
+func (s *MCPServer) handleComplete(ctx context.Context, req *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+	ref := req.Params.Ref.Name
+	if req.Params.Ref.Type == "ref/resource" {
+		ref = req.Params.Ref.URI
+	}
+	arg := req.Params.Argument
+
+	values, total := s.completionValues(ctx, ref, arg.Name, arg.Value)
+	return &mcp.CompleteResult{
+		Completion: mcp.CompletionResultDetails{
+			Values:  values,
+			Total:   total,
+			HasMore: total > len(values),
+		},
+	}, nil
+}
+
+// completionValues returns up to maxCompletions values for an argument of a prompt or
+// resource template, and how many values match in total. Lookups that fail, such as
+// account data without a key, give no values rather than an error.
+func (s *MCPServer) completionValues(ctx context.Context, ref, arg, value string) ([]string, int) {
+	switch {
+	case arg == "item" || arg == "legendary":
+		index, ok := s.gw2API.ReadyItemIndex(ctx)
+		if !ok {
+			return nil, 0
+		}
+		var filter gw2api.ItemNameFilter
+		if arg == "legendary" {
+			filter.Rarity = "Legendary"
+		}
+		return index.CompleteNames(value, filter, maxCompletions)
+	case ref == "gw2://characters/{name}":
+		names, err := s.gw2API.GetCharacters(ctx)
+		if err != nil {
+			s.logger.Debug("No character names to complete", "error", err)
+			return nil, 0
+		}
+		return completePrefix(names, value)
+	case ref == "gw2://guilds/{name}":
+		return completePrefix(s.accountGuildNames(ctx), value)
+	case ref == "gw2://account/unlocks/{+type}":
+		return completePrefix(gw2api.UnlockTypes(), value)
+	case ref == "gw2://account/progress/{+type}":
+		return completePrefix(gw2api.ProgressTypes(), value)
+	case ref == "gw2://account/dailies/{type}":
+		return completePrefix(gw2api.DailyTypes(), value)
+	}
+	return nil, 0
+}
+
+// accountGuildNames returns the names of the guilds the account is a member of
+func (s *MCPServer) accountGuildNames(ctx context.Context) []string {
+	account, err := s.gw2API.GetAccount(ctx)
+	if err != nil {
+		s.logger.Debug("No guild names to complete", "error", err)
+		return nil
+	}
+	var names []string
+	for _, id := range account.Guilds {
+		guild, err := s.gw2API.GetGuild(ctx, id)
+		if err != nil {
+			s.logger.Warn("Failed to get guild for completion", "id", id, "error", err)
+			continue
+		}
+		names = append(names, guild.Name)
+	}
+	return names
+}
+
+// completePrefix returns the values that start with prefix, ignoring case, and how many
+// there are
+func completePrefix(values []string, prefix string) ([]string, int) {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	var matches []string
+	for _, v := range values {
+		if strings.HasPrefix(strings.ToLower(v), prefix) {
+			matches = append(matches, v)
+		}
+	}
+	total := len(matches)
+	if len(matches) > maxCompletions {
+		matches = matches[:maxCompletions]
+	}
+	return matches, total
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/AlyxPink/gw2-mcp/internal/cache"
+	"github.com/AlyxPink/gw2-mcp/internal/gw2api"
+)
+
+func TestCompletePrefix(t *testing.T) {
+	values, total := completePrefix([]string{"skins", "skiffs", "dyes", "Skyscale"}, " SK")
+	if want := []string{"skins", "skiffs", "Skyscale"}; !reflect.DeepEqual(values, want) || total != 3 {
+		t.Errorf("completePrefix() = %v, %d, want %v, 3", values, total, want)
+	}
+
+	many := make([]string, maxCompletions+5)
+	values, total = completePrefix(many, "")
+	if len(values) != maxCompletions || total != maxCompletions+5 {
+		t.Errorf("completePrefix() returned %d of %d values, want %d of %d", len(values), total, maxCompletions, maxCompletions+5)
+	}
+}
+
+func TestComplete(t *testing.T) {
+	s := newTestServer(t)
+	if err := s.cache.SetJSON(s.cache.GetGameBuildKey(), gw2api.BuildInfo{ID: 1}, cache.GameBuildTTL); err != nil {
+		t.Fatal(err)
+	}
+	s.cache.Set(s.cache.GetItemIndexKey(1), &gw2api.ItemIndex{Build: 1, Items: []gw2api.IndexedItem{
+		{ID: 19675, Name: "Mystic Clover"},
+		{ID: 19976, Name: "Mystic Coin"},
+		{ID: 19721, Name: "Glob of Ectoplasm"},
+		{ID: 30704, Name: "Twilight", Rarity: "Legendary"},
+		{ID: 19648, Name: "Gift of Twilight", Rarity: "Legendary"},
+		{ID: 71, Name: "Twilight Arbor Token"},
+	}}, cache.ItemIndexTTL)
+	session := connectTestClient(t, s)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		ref  *mcp.CompleteReference
+		arg  mcp.CompleteParamsArgument
+		want []string
+	}{
+		{
+			"item names",
+			&mcp.CompleteReference{Type: "ref/prompt", Name: "craft_or_buy"},
+			mcp.CompleteParamsArgument{Name: "item", Value: "myst"},
+			[]string{"Mystic Coin", "Mystic Clover"},
+		},
+		{
+			"legendary names",
+			&mcp.CompleteReference{Type: "ref/prompt", Name: "legendary_progress"},
+			mcp.CompleteParamsArgument{Name: "legendary", Value: "twi"},
+			[]string{"Twilight"},
+		},
+		{
+			"unlock types",
+			&mcp.CompleteReference{Type: "ref/resource", URI: "gw2://account/unlocks/{+type}"},
+			mcp.CompleteParamsArgument{Name: "type", Value: "mounts"},
+			[]string{"mounts/skins", "mounts/types"},
+		},
+		{
+			"daily types",
+			&mcp.CompleteReference{Type: "ref/resource", URI: "gw2://account/dailies/{type}"},
+			mcp.CompleteParamsArgument{Name: "type", Value: "w"},
+			[]string{"worldbosses"},
+		},
+		{
+			"characters without a key",
+			&mcp.CompleteReference{Type: "ref/resource", URI: "gw2://characters/{name}"},
+			mcp.CompleteParamsArgument{Name: "name", Value: "a"},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := session.Complete(ctx, &mcp.CompleteParams{Ref: tt.ref, Argument: tt.arg})
+			if err != nil {
+				t.Fatalf("Complete() error = %v", err)
+			}
+			if len(res.Completion.Values) != len(tt.want) || (len(tt.want) > 0 && !reflect.DeepEqual(res.Completion.Values, tt.want)) {
+				t.Errorf("Complete() = %v, want %v", res.Completion.Values, tt.want)
+			}
+		})
+	}
+}
@@ -3,12 +3,24 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"net/url"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
+	"github.com/AlyxPink/gw2-mcp/internal/cache"
+	"github.com/AlyxPink/gw2-mcp/internal/colors"
+	"github.com/AlyxPink/gw2-mcp/internal/crafting"
+	"github.com/AlyxPink/gw2-mcp/internal/fractals"
 	"github.com/AlyxPink/gw2-mcp/internal/gw2api"
+	"github.com/AlyxPink/gw2-mcp/internal/schedule"
 	"github.com/AlyxPink/gw2-mcp/internal/wiki"
 )
 
@@ -27,13 +39,61 @@ func errResult(msg string) (*mcp.CallToolResult, any, error) {
 	}, nil, nil
 }
 
-// jsonResult marshals v to indented JSON and returns it as a text result.
+// apiErrResult returns an error result for a failed request. GW2 API errors get a hint
+// for what to do about them and are described in the result's _meta: gw2/error is the
+// error kind, gw2/status the HTTP status, gw2/retryable whether trying again may work and
+// gw2/scope the missing scope, when the API names one.
+func apiErrResult(msg string, err error) (*mcp.CallToolResult, any, error) {
+	var apiErr *gw2api.APIError
+	if !errors.As(err, &apiErr) {
+		return errResult(fmt.Sprintf("%s: %v", msg, err))
+	}
+
+	var hint string
+	switch apiErr.Kind {
+	case gw2api.ErrInvalidKey:
+		hint = "Check GW2_API_KEY, or create a new key at " + apiKeyManagementURL
+	case gw2api.ErrMissingScope:
+		if apiErr.Scope != "" {
+			hint = fmt.Sprintf("The API key is missing the `%s` scope; create a key with it at %s", apiErr.Scope, apiKeyManagementURL)
+		} else {
+			hint = "The API key is not allowed to read this; guild details need a key from the guild leader"
+		}
+	case gw2api.ErrNotFound:
+		hint = "Check the ID or name; the GW2 API does not know it"
+	case gw2api.ErrRateLimited:
+		hint = "The GW2 API rate limit was hit; wait a minute and try again"
+	case gw2api.ErrUpstream:
+		hint = "The GW2 API failed or is temporarily disabled; try again later"
+	case gw2api.ErrPartial:
+		hint = "Some of the requested IDs do not exist; check them and try again"
+	case gw2api.ErrBadRequest:
+		hint = "The GW2 API rejected the request's parameters; check them before trying again"
+	}
+
+	result, _, _ := errResult(fmt.Sprintf("%s: %v. %s.", msg, err, hint))
+	result.Meta = mcp.Meta{
+		"gw2/error":     string(apiErr.Kind),
+		"gw2/retryable": apiErr.Retryable(),
+	}
+	if apiErr.Status != 0 {
+		result.Meta["gw2/status"] = apiErr.Status
+	}
+	if apiErr.Scope != "" {
+		result.Meta["gw2/scope"] = apiErr.Scope
+	}
+	return result, nil, nil
+}
+
+// jsonResult marshals v to indented JSON and returns it as a text result, with v as the
+// result's structured content.
 func jsonResult(v any) (*mcp.CallToolResult, any, error) {
 	data, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to format response: %v", err))
+		return apiErrResult("Failed to format response", err)
 	}
-	return textResult(string(data))
+	result, _, _ := textResult(string(data))
+	return result, v, nil
 }
 
 // handleWikiSearch handles wiki search requests
@@ -51,7 +111,7 @@ func (s *MCPServer) handleWikiSearch(ctx context.Context, _ *mcp.CallToolRequest
 
 	results, err := s.wiki.Search(ctx, args.Query, limit)
 	if err != nil {
-		return errResult(fmt.Sprintf("Wiki search failed: %v", err))
+		return apiErrResult("Wiki search failed", err)
 	}
 
 	return jsonResult(results)
@@ -63,7 +123,7 @@ func (s *MCPServer) handleGetWallet(ctx context.Context, _ *mcp.CallToolRequest,
 
 	wallet, err := s.gw2API.GetWallet(ctx)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get wallet: %v", err))
+		return apiErrResult("Failed to get wallet", err)
 	}
 
 	return jsonResult(wallet)
@@ -75,7 +135,7 @@ func (s *MCPServer) handleGetCurrencies(ctx context.Context, _ *mcp.CallToolRequ
 
 	currencies, err := s.gw2API.GetCurrencies(ctx, args.IDs)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get currencies: %v", err))
+		return apiErrResult("Failed to get currencies", err)
 	}
 
 	return jsonResult(currencies)
@@ -91,7 +151,7 @@ func (s *MCPServer) handleGetTPPrices(ctx context.Context, _ *mcp.CallToolReques
 
 	prices, err := s.gw2API.GetPrices(ctx, args.ItemIDs)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get trading post prices: %v", err))
+		return apiErrResult("Failed to get trading post prices", err)
 	}
 
 	return jsonResult(prices)
@@ -107,7 +167,7 @@ func (s *MCPServer) handleGetTPListings(ctx context.Context, _ *mcp.CallToolRequ
 
 	listings, err := s.gw2API.GetListings(ctx, args.ItemIDs)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get trading post listings: %v", err))
+		return apiErrResult("Failed to get trading post listings", err)
 	}
 
 	return jsonResult(listings)
@@ -126,7 +186,7 @@ func (s *MCPServer) handleGetGemExchange(ctx context.Context, _ *mcp.CallToolReq
 
 	exchange, err := s.gw2API.GetGemExchange(ctx, args.Direction, args.Quantity)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get gem exchange rate: %v", err))
+		return apiErrResult("Failed to get gem exchange rate", err)
 	}
 
 	return jsonResult(exchange)
@@ -138,7 +198,7 @@ func (s *MCPServer) handleGetTPDelivery(ctx context.Context, _ *mcp.CallToolRequ
 
 	delivery, err := s.gw2API.GetDelivery(ctx)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get trading post delivery: %v", err))
+		return apiErrResult("Failed to get trading post delivery", err)
 	}
 
 	return jsonResult(delivery)
@@ -154,7 +214,7 @@ func (s *MCPServer) handleGetTPTransactions(ctx context.Context, _ *mcp.CallTool
 
 	transactions, err := s.gw2API.GetTransactions(ctx, args.Type)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get trading post transactions: %v", err))
+		return apiErrResult("Failed to get trading post transactions", err)
 	}
 
 	return jsonResult(transactions)
@@ -168,7 +228,7 @@ func (s *MCPServer) handleGetAccount(ctx context.Context, _ *mcp.CallToolRequest
 
 	account, err := s.gw2API.GetAccount(ctx)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get account: %v", err))
+		return apiErrResult("Failed to get account", err)
 	}
 
 	return jsonResult(account)
@@ -180,7 +240,7 @@ func (s *MCPServer) handleGetBank(ctx context.Context, _ *mcp.CallToolRequest, _
 
 	bank, err := s.gw2API.GetBank(ctx)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get bank: %v", err))
+		return apiErrResult("Failed to get bank", err)
 	}
 
 	return jsonResult(bank)
@@ -192,7 +252,7 @@ func (s *MCPServer) handleGetMaterials(ctx context.Context, _ *mcp.CallToolReque
 
 	materials, err := s.gw2API.GetMaterials(ctx)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get materials: %v", err))
+		return apiErrResult("Failed to get materials", err)
 	}
 
 	return jsonResult(materials)
@@ -204,30 +264,37 @@ func (s *MCPServer) handleGetInventory(ctx context.Context, _ *mcp.CallToolReque
 
 	inventory, err := s.gw2API.GetSharedInventory(ctx)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get shared inventory: %v", err))
+		return apiErrResult("Failed to get shared inventory", err)
 	}
 
 	return jsonResult(inventory)
 }
 
+// CharactersResult is the response for get_characters: the character names when no name
+// is given, otherwise the named character's details
+type CharactersResult struct {
+	Characters []string              `json:"characters,omitempty"`
+	Character  *gw2api.CharacterInfo `json:"character,omitempty"`
+}
+
 // handleGetCharacters handles character list/detail requests
 func (s *MCPServer) handleGetCharacters(ctx context.Context, _ *mcp.CallToolRequest, args GetCharactersArgs) (*mcp.CallToolResult, any, error) {
 	if args.Name != "" {
 		s.logger.Debug("Character detail request", "name", args.Name)
 		character, err := s.gw2API.GetCharacter(ctx, args.Name)
 		if err != nil {
-			return errResult(fmt.Sprintf("Failed to get character: %v", err))
+			return apiErrResult("Failed to get character", err)
 		}
-		return jsonResult(character)
+		return jsonResult(CharactersResult{Character: character})
 	}
 
 	s.logger.Debug("Characters list request")
 	characters, err := s.gw2API.GetCharacters(ctx)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get characters: %v", err))
+		return apiErrResult("Failed to get characters", err)
 	}
 
-	return jsonResult(characters)
+	return jsonResult(CharactersResult{Characters: characters})
 }
 
 // --- Account Unlocks, Progress, Dailies Handlers ---
@@ -242,10 +309,10 @@ func (s *MCPServer) handleGetAccountUnlocks(ctx context.Context, _ *mcp.CallTool
 
 	unlocks, err := s.gw2API.GetAccountUnlocks(ctx, args.Type)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get account unlocks: %v", err))
+		return apiErrResult("Failed to get account unlocks", err)
 	}
 
-	return textResult(string(unlocks))
+	return jsonResult(unlocks)
 }
 
 // handleGetAccountProgress handles account progress requests
@@ -258,10 +325,10 @@ func (s *MCPServer) handleGetAccountProgress(ctx context.Context, _ *mcp.CallToo
 
 	progress, err := s.gw2API.GetAccountProgress(ctx, args.Type)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get account progress: %v", err))
+		return apiErrResult("Failed to get account progress", err)
 	}
 
-	return textResult(string(progress))
+	return jsonResult(progress)
 }
 
 // handleGetAccountDailies handles account dailies requests
@@ -274,10 +341,10 @@ func (s *MCPServer) handleGetAccountDailies(ctx context.Context, _ *mcp.CallTool
 
 	dailies, err := s.gw2API.GetAccountDailies(ctx, args.Type)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get account dailies: %v", err))
+		return apiErrResult("Failed to get account dailies", err)
 	}
 
-	return textResult(string(dailies))
+	return jsonResult(dailies)
 }
 
 // --- Wizard's Vault Handlers ---
@@ -288,10 +355,10 @@ func (s *MCPServer) handleGetWizardsVault(ctx context.Context, _ *mcp.CallToolRe
 
 	data, err := s.gw2API.GetWizardsVault(ctx)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get wizard's vault: %v", err))
+		return apiErrResult("Failed to get wizard's vault", err)
 	}
 
-	return textResult(string(data))
+	return jsonResult(data)
 }
 
 // handleGetWizardsVaultObjectives handles wizard's vault objectives requests
@@ -304,10 +371,10 @@ func (s *MCPServer) handleGetWizardsVaultObjectives(ctx context.Context, _ *mcp.
 
 	data, err := s.gw2API.GetWizardsVaultObjectives(ctx, args.Type)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get wizard's vault objectives: %v", err))
+		return apiErrResult("Failed to get wizard's vault objectives", err)
 	}
 
-	return textResult(string(data))
+	return jsonResult(data)
 }
 
 // handleGetWizardsVaultListings handles wizard's vault listings requests
@@ -316,10 +383,10 @@ func (s *MCPServer) handleGetWizardsVaultListings(ctx context.Context, _ *mcp.Ca
 
 	data, err := s.gw2API.GetWizardsVaultListings(ctx)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get wizard's vault listings: %v", err))
+		return apiErrResult("Failed to get wizard's vault listings", err)
 	}
 
-	return textResult(string(data))
+	return jsonResult(data)
 }
 
 // --- Game Data Handlers ---
@@ -334,7 +401,7 @@ func (s *MCPServer) handleGetItems(ctx context.Context, _ *mcp.CallToolRequest,
 
 	items, err := s.gw2API.GetItems(ctx, args.IDs)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get items: %v", err))
+		return apiErrResult("Failed to get items", err)
 	}
 
 	return jsonResult(items)
@@ -350,7 +417,7 @@ func (s *MCPServer) handleGetSkins(ctx context.Context, _ *mcp.CallToolRequest,
 
 	skins, err := s.gw2API.GetSkins(ctx, args.IDs)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get skins: %v", err))
+		return apiErrResult("Failed to get skins", err)
 	}
 
 	return jsonResult(skins)
@@ -366,7 +433,7 @@ func (s *MCPServer) handleGetRecipes(ctx context.Context, _ *mcp.CallToolRequest
 
 	recipes, err := s.gw2API.GetRecipes(ctx, args.IDs)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get recipes: %v", err))
+		return apiErrResult("Failed to get recipes", err)
 	}
 
 	return jsonResult(recipes)
@@ -382,10 +449,26 @@ func (s *MCPServer) handleSearchRecipes(ctx context.Context, _ *mcp.CallToolRequ
 
 	ids, err := s.gw2API.SearchRecipes(ctx, args.Input, args.Output)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to search recipes: %v", err))
+		return apiErrResult("Failed to search recipes", err)
 	}
 
-	return jsonResult(ids)
+	result := RecipeSearchResult{Input: args.Input, Output: args.Output, Recipes: []RecipeSearchEntry{}}
+	for _, id := range ids {
+		result.Recipes = append(result.Recipes, RecipeSearchEntry{Source: recipeSourceAPI, RecipeID: id})
+	}
+
+	// Mystic Forge recipes are only indexed by output on the wiki
+	if args.Output != 0 {
+		forgeRecipes, err := s.mysticForgeRecipesForItem(ctx, args.Output)
+		if err != nil {
+			s.logger.Warn("Failed to get Mystic Forge recipes", "item_id", args.Output, "error", err)
+		}
+		for i := range forgeRecipes {
+			result.Recipes = append(result.Recipes, RecipeSearchEntry{Source: recipeSourceMysticForge, Recipe: &forgeRecipes[i]})
+		}
+	}
+
+	return jsonResult(result)
 }
 
 // handleGetAchievements handles achievement lookup requests
@@ -398,7 +481,7 @@ func (s *MCPServer) handleGetAchievements(ctx context.Context, _ *mcp.CallToolRe
 
 	achievements, err := s.gw2API.GetAchievements(ctx, args.IDs)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get achievements: %v", err))
+		return apiErrResult("Failed to get achievements", err)
 	}
 
 	return jsonResult(achievements)
@@ -410,7 +493,7 @@ func (s *MCPServer) handleGetDailyAchievements(ctx context.Context, _ *mcp.CallT
 
 	dailies, err := s.gw2API.GetDailyAchievements(ctx)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get daily achievements: %v", err))
+		return apiErrResult("Failed to get daily achievements", err)
 	}
 
 	return jsonResult(dailies)
@@ -428,7 +511,7 @@ func (s *MCPServer) handleGetGuild(ctx context.Context, _ *mcp.CallToolRequest,
 
 	guild, err := s.gw2API.GetGuild(ctx, args.ID)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get guild: %v", err))
+		return apiErrResult("Failed to get guild", err)
 	}
 
 	return jsonResult(guild)
@@ -444,12 +527,26 @@ func (s *MCPServer) handleSearchGuild(ctx context.Context, _ *mcp.CallToolReques
 
 	ids, err := s.gw2API.SearchGuild(ctx, args.Name)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to search guild: %v", err))
+		return apiErrResult("Failed to search guild", err)
 	}
 
 	return jsonResult(ids)
 }
 
+// GuildDetailsResult is the response for get_guild_details; only the field for the
+// requested type is set
+type GuildDetailsResult struct {
+	Type     string                     `json:"type"`
+	Log      []gw2api.GuildLogEntry     `json:"log,omitempty"`
+	Members  []gw2api.GuildMember       `json:"members,omitempty"`
+	Ranks    []gw2api.GuildRank         `json:"ranks,omitempty"`
+	Stash    []gw2api.GuildStashSection `json:"stash,omitempty"`
+	Storage  []gw2api.GuildStorageItem  `json:"storage,omitempty"`
+	Treasury []gw2api.GuildTreasuryItem `json:"treasury,omitempty"`
+	Teams    []gw2api.GuildTeam         `json:"teams,omitempty"`
+	Upgrades []CompletedGuildUpgrade    `json:"upgrades,omitempty"`
+}
+
 // handleGetGuildDetails handles authenticated guild detail requests
 func (s *MCPServer) handleGetGuildDetails(ctx context.Context, _ *mcp.CallToolRequest, args GetGuildDetailsArgs) (*mcp.CallToolResult, any, error) {
 	if args.ID == "" {
@@ -461,12 +558,40 @@ func (s *MCPServer) handleGetGuildDetails(ctx context.Context, _ *mcp.CallToolRe
 
 	s.logger.Debug("Guild detail request", "id", args.ID, "type", args.Type)
 
-	details, err := s.gw2API.GetGuildDetails(ctx, args.ID, args.Type)
+	result := GuildDetailsResult{Type: args.Type}
+	var err error
+	switch args.Type {
+	case "log":
+		result.Log, err = s.gw2API.GetGuildLogEntries(ctx, args.ID)
+	case "members":
+		result.Members, err = s.gw2API.GetGuildMembers(ctx, args.ID)
+	case "ranks":
+		result.Ranks, err = s.gw2API.GetGuildRanks(ctx, args.ID)
+	case "stash":
+		result.Stash, err = s.gw2API.GetGuildStash(ctx, args.ID)
+	case "storage":
+		result.Storage, err = s.gw2API.GetGuildStorage(ctx, args.ID)
+	case "treasury":
+		result.Treasury, err = s.gw2API.GetGuildTreasury(ctx, args.ID)
+	case "teams":
+		result.Teams, err = s.gw2API.GetGuildTeams(ctx, args.ID)
+	case "upgrades":
+		var ids []int
+		if ids, err = s.gw2API.GetGuildUpgradeIDs(ctx, args.ID); err == nil {
+			upgrades, upgradesErr := s.gw2API.GetGuildUpgrades(ctx)
+			if upgradesErr != nil {
+				s.logger.Warn("Failed to get guild upgrade names", "error", upgradesErr)
+			}
+			result.Upgrades = completedGuildUpgrades(upgrades, ids)
+		}
+	default:
+		return errResult(fmt.Sprintf("invalid guild detail type %q: must be log, members, ranks, stash, storage, treasury, teams or upgrades", args.Type))
+	}
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get guild details: %v", err))
+		return apiErrResult("Failed to get guild details", err)
 	}
 
-	return textResult(string(details))
+	return jsonResult(result)
 }
 
 // --- Game Metadata Handlers ---
@@ -481,7 +606,7 @@ func (s *MCPServer) handleGetColors(ctx context.Context, _ *mcp.CallToolRequest,
 
 	colors, err := s.gw2API.GetColors(ctx, args.IDs)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get colors: %v", err))
+		return apiErrResult("Failed to get colors", err)
 	}
 
 	return jsonResult(colors)
@@ -497,29 +622,48 @@ func (s *MCPServer) handleGetMinis(ctx context.Context, _ *mcp.CallToolRequest,
 
 	minis, err := s.gw2API.GetMinis(ctx, args.IDs)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get minis: %v", err))
+		return apiErrResult("Failed to get minis", err)
 	}
 
 	return jsonResult(minis)
 }
 
+// MountsInfoResult is the response for get_mounts_info; only the field for the requested
+// type is set
+type MountsInfoResult struct {
+	Type  string             `json:"type"`
+	Skins []gw2api.MountSkin `json:"skins,omitempty"`
+	Types []gw2api.MountType `json:"types,omitempty"`
+}
+
 // handleGetMountsInfo handles mount info requests
 func (s *MCPServer) handleGetMountsInfo(ctx context.Context, _ *mcp.CallToolRequest, args GetMountsInfoArgs) (*mcp.CallToolResult, any, error) {
-	if args.Type == "" {
+	switch args.Type {
+	case "":
 		return errResult("type parameter is required")
-	}
-	if len(args.IDs) == 0 {
-		return errResult("ids parameter is required and must not be empty")
+	case "skins":
+		if len(args.IDs) == 0 {
+			return errResult("ids parameter is required and must not be empty")
+		}
+	case "types":
+	default:
+		return errResult(fmt.Sprintf("invalid mount type %q: must be 'skins' or 'types'", args.Type))
 	}
 
 	s.logger.Debug("Mounts info request", "type", args.Type, "ids", args.IDs)
 
-	data, err := s.gw2API.GetMountsInfo(ctx, args.Type, args.IDs)
+	result := MountsInfoResult{Type: args.Type}
+	var err error
+	if args.Type == "skins" {
+		result.Skins, err = s.gw2API.GetMountSkins(ctx, args.IDs)
+	} else {
+		result.Types, err = s.gw2API.GetMountTypes(ctx)
+	}
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get mount info: %v", err))
+		return apiErrResult("Failed to get mount info", err)
 	}
 
-	return textResult(string(data))
+	return jsonResult(result)
 }
 
 // handleGetGameBuild handles game build number requests
@@ -528,7 +672,7 @@ func (s *MCPServer) handleGetGameBuild(ctx context.Context, _ *mcp.CallToolReque
 
 	build, err := s.gw2API.GetGameBuild(ctx)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get game build: %v", err))
+		return apiErrResult("Failed to get game build", err)
 	}
 
 	return jsonResult(build)
@@ -540,38 +684,243 @@ func (s *MCPServer) handleGetTokenInfo(ctx context.Context, _ *mcp.CallToolReque
 
 	info, err := s.gw2API.GetTokenInfo(ctx)
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get token info: %v", err))
+		return apiErrResult("Failed to get token info", err)
 	}
 
 	return jsonResult(info)
 }
 
+// DungeonsAndRaidsResult is the response for get_dungeons_and_raids; Unknown lists
+// requested IDs that are not a dungeon or raid of the requested type
+type DungeonsAndRaidsResult struct {
+	Type     string           `json:"type"`
+	Dungeons []gw2api.Dungeon `json:"dungeons,omitempty"`
+	Raids    []gw2api.Raid    `json:"raids,omitempty"`
+	Unknown  []string         `json:"unknown,omitempty"`
+}
+
 // handleGetDungeonsAndRaids handles dungeon/raid metadata requests
 func (s *MCPServer) handleGetDungeonsAndRaids(ctx context.Context, _ *mcp.CallToolRequest, args GetDungeonsAndRaidsArgs) (*mcp.CallToolResult, any, error) {
 	if args.Type == "" {
 		return errResult("type parameter is required")
 	}
+	if args.Type != "dungeons" && args.Type != "raids" {
+		return errResult(fmt.Sprintf("invalid type %q: must be 'dungeons' or 'raids'", args.Type))
+	}
 	if len(args.IDs) == 0 {
 		return errResult("ids parameter is required and must not be empty")
 	}
 
 	s.logger.Debug("Dungeons/raids request", "type", args.Type, "ids", args.IDs)
 
-	data, err := s.gw2API.GetDungeonsAndRaids(ctx, args.Type, args.IDs)
-	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get %s: %v", args.Type, err))
+	result := DungeonsAndRaidsResult{Type: args.Type}
+	if args.Type == "dungeons" {
+		dungeons, err := s.gw2API.GetDungeons(ctx)
+		if err != nil {
+			return apiErrResult("Failed to get dungeons", err)
+		}
+		for _, id := range args.IDs {
+			i := slices.IndexFunc(dungeons, func(d gw2api.Dungeon) bool { return d.ID == id })
+			if i < 0 {
+				result.Unknown = append(result.Unknown, id)
+				continue
+			}
+			result.Dungeons = append(result.Dungeons, dungeons[i])
+		}
+	} else {
+		raids, err := s.gw2API.GetRaids(ctx)
+		if err != nil {
+			return apiErrResult("Failed to get raids", err)
+		}
+		for _, id := range args.IDs {
+			i := slices.IndexFunc(raids, func(r gw2api.Raid) bool { return r.ID == id })
+			if i < 0 {
+				result.Unknown = append(result.Unknown, id)
+				continue
+			}
+			result.Raids = append(result.Raids, raids[i])
+		}
 	}
 
-	return textResult(string(data))
+	return jsonResult(result)
 }
 
 // --- Composite Tool Handlers ---
 
-// EnrichedRecipe wraps a Recipe with resolved item names
+// Recipe sources reported on recipes returned by the recipe tools
+const (
+	recipeSourceAPI         = "api"
+	recipeSourceMysticForge = "mystic_forge"
+)
+
+// EnrichedRecipe wraps a Recipe with resolved item names and the source it came from.
+// Mystic Forge recipes have no recipe ID; ingredients the wiki names but that could
+// not be resolved to an item ID are listed in UnresolvedIngredients.
 type EnrichedRecipe struct {
 	gw2api.Recipe
-	OutputItemName  string         `json:"output_item_name"`
-	IngredientNames map[int]string `json:"ingredient_names"`
+	Source                string         `json:"source"`
+	OutputItemName        string         `json:"output_item_name"`
+	IngredientNames       map[int]string `json:"ingredient_names"`
+	UnresolvedIngredients []string       `json:"unresolved_ingredients,omitempty"`
+	WikiURL               string         `json:"wiki_url,omitempty"`
+}
+
+// RecipeSearchEntry is a single search_recipes match. API recipes carry only their ID
+// (use get_recipes for details); Mystic Forge recipes are returned in full.
+type RecipeSearchEntry struct {
+	Source   string          `json:"source"`
+	RecipeID int             `json:"recipe_id,omitempty"`
+	Recipe   *EnrichedRecipe `json:"recipe,omitempty"`
+}
+
+// RecipeSearchResult is the response for search_recipes
+type RecipeSearchResult struct {
+	Input   int                 `json:"input,omitempty"`
+	Output  int                 `json:"output,omitempty"`
+	Recipes []RecipeSearchEntry `json:"recipes"`
+}
+
+// mysticForgeToEnriched converts a wiki Mystic Forge recipe into the API recipe shape.
+// Ingredient names from the wiki are kept as a fallback for item name resolution.
+func mysticForgeToEnriched(mf wiki.MysticForgeRecipe) EnrichedRecipe {
+	recipe := EnrichedRecipe{
+		Recipe: gw2api.Recipe{
+			Type:            "MysticForge",
+			OutputItemID:    mf.OutputItemID,
+			OutputItemCount: mf.OutputCount,
+			Disciplines:     []string{"Mystic Forge"},
+			Ingredients:     []gw2api.RecipeIngredient{},
+		},
+		Source:          recipeSourceMysticForge,
+		OutputItemName:  mf.Output,
+		IngredientNames: make(map[int]string),
+		WikiURL:         mf.URL,
+	}
+	for _, ing := range mf.Ingredients {
+		if ing.ItemID == 0 {
+			recipe.UnresolvedIngredients = append(recipe.UnresolvedIngredients, fmt.Sprintf("%d %s", ing.Count, ing.Name))
+			continue
+		}
+		recipe.Ingredients = append(recipe.Ingredients, gw2api.RecipeIngredient{ItemID: ing.ItemID, Count: ing.Count})
+		recipe.IngredientNames[ing.ItemID] = ing.Name
+	}
+	return recipe
+}
+
+// filterMysticForgeRecipes keeps the Mystic Forge recipes producing itemID, including
+// those whose output could not be resolved. An itemID of 0 keeps every recipe.
+func filterMysticForgeRecipes(recipes []wiki.MysticForgeRecipe, itemID int) []wiki.MysticForgeRecipe {
+	if itemID == 0 {
+		return recipes
+	}
+	var result []wiki.MysticForgeRecipe
+	for _, r := range recipes {
+		if r.OutputItemID == 0 || r.OutputItemID == itemID {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// enrichRecipeNames resolves output and ingredient item names for the given recipes,
+// keeping any names already present when the API does not know the item
+func (s *MCPServer) enrichRecipeNames(ctx context.Context, recipes []EnrichedRecipe) {
+	itemIDSet := make(map[int]bool)
+	for _, r := range recipes {
+		if r.OutputItemID != 0 {
+			itemIDSet[r.OutputItemID] = true
+		}
+		for _, ing := range r.Ingredients {
+			itemIDSet[ing.ItemID] = true
+		}
+	}
+	if len(itemIDSet) == 0 {
+		return
+	}
+	allItemIDs := make([]int, 0, len(itemIDSet))
+	for id := range itemIDSet {
+		allItemIDs = append(allItemIDs, id)
+	}
+
+	itemMap, err := s.gw2API.GetItems(ctx, allItemIDs)
+	if err != nil {
+		s.logger.Warn("Failed to resolve item names for recipes", "error", err)
+		return
+	}
+
+	for i := range recipes {
+		if recipes[i].IngredientNames == nil {
+			recipes[i].IngredientNames = make(map[int]string)
+		}
+		for _, ing := range recipes[i].Ingredients {
+			if item, ok := itemMap[ing.ItemID]; ok {
+				recipes[i].IngredientNames[ing.ItemID] = item.Name
+			}
+		}
+		if item, ok := itemMap[recipes[i].OutputItemID]; ok {
+			recipes[i].OutputItemName = item.Name
+		}
+	}
+}
+
+// mysticForgeRecipesForItem returns the Mystic Forge recipes producing an item, looked up
+// on the wiki page named after the item
+func (s *MCPServer) mysticForgeRecipesForItem(ctx context.Context, itemID int) ([]EnrichedRecipe, error) {
+	items, err := s.gw2API.GetItems(ctx, []int{itemID})
+	if err != nil {
+		return nil, err
+	}
+	forgeRecipes, err := s.wikiForgeRecipesForItem(ctx, items[itemID])
+	if err != nil {
+		return nil, err
+	}
+
+	var result []EnrichedRecipe
+	for _, mf := range forgeRecipes {
+		recipe := mysticForgeToEnriched(mf)
+		recipe.OutputItemID = itemID
+		result = append(result, recipe)
+	}
+	s.enrichRecipeNames(ctx, result)
+	return result, nil
+}
+
+// wikiForgeRecipesForItem returns the wiki's Mystic Forge recipes producing an item, as
+// parsed from the page named after the item. Results are cached per item, including
+// finding none, since each lookup reads a wiki page per ingredient.
+func (s *MCPServer) wikiForgeRecipesForItem(ctx context.Context, item gw2api.Item) ([]wiki.MysticForgeRecipe, error) {
+	if item.Name == "" {
+		return nil, nil
+	}
+	cacheKey := s.cache.GetMysticForgeRecipesKey(item.ID)
+	var forgeRecipes []wiki.MysticForgeRecipe
+	if s.cache.GetJSON(cacheKey, &forgeRecipes) {
+		return forgeRecipes, nil
+	}
+
+	all, err := s.wiki.GetMysticForgeRecipes(ctx, item.Name)
+	if err != nil {
+		return nil, err
+	}
+	forgeRecipes = filterMysticForgeRecipes(all, item.ID)
+	if forgeRecipes == nil {
+		forgeRecipes = []wiki.MysticForgeRecipe{}
+	}
+	if err := s.cache.SetJSON(cacheKey, forgeRecipes, cache.WikiDataTTL); err != nil {
+		s.logger.Warn("Failed to cache Mystic Forge recipes", "item_id", item.ID, "error", err)
+	}
+	return forgeRecipes, nil
+}
+
+// forgeOutputCandidate reports whether the crafting planner should look on the wiki for a
+// Mystic Forge recipe for an item without an API recipe. Tradeable materials and trophies,
+// such as ores, T6 materials and Mystic Coins, are bought rather than forged, so they stay
+// leaves of the plan; account bound ones, such as Mystic Clovers and gifts, may be forged.
+func forgeOutputCandidate(item gw2api.Item) bool {
+	if item.Type == "CraftingMaterial" || item.Type == "Trophy" {
+		return !item.Tradeable()
+	}
+	return true
 }
 
 // ItemRecipeResult is the response for get_item_recipe_by_name
@@ -611,33 +960,85 @@ func extractRecipeIDsFromWikiResult(result wiki.SearchResult) ([]int, error) {
 	return ids, nil
 }
 
-// handleGetItemByName handles item lookup by name via wiki search
-func (s *MCPServer) handleGetItemByName(ctx context.Context, _ *mcp.CallToolRequest, args GetItemByNameArgs) (*mcp.CallToolResult, any, error) {
-	if args.Name == "" {
-		return errResult("name parameter is required")
+// resolvedItem is the item a name refers to. Wiki is the search result the ID was read
+// from when the name was resolved through the wiki rather than the item index.
+type resolvedItem struct {
+	ID   int
+	Wiki *wiki.SearchResult
+}
+
+// maxItemCandidates is the most items listed when a name does not pick out one item
+const maxItemCandidates = 5
+
+// findIndexedItem finds the item a name refers to in the item index. Only a name that
+// exactly matches one item is taken; if the best matches are partial, typo-tolerant or
+// several items share the name, the error lists them so the caller can pick one. It
+// returns nil if nothing matches.
+func findIndexedItem(index *gw2api.ItemIndex, name string, filter gw2api.ItemNameFilter) (*gw2api.ItemNameMatch, error) {
+	matches := index.Search(name, filter, maxItemCandidates)
+	if len(matches) == 0 {
+		return nil, nil
 	}
+	if matches[0].Kind == gw2api.MatchExact && (len(matches) == 1 || matches[1].Kind != gw2api.MatchExact) {
+		return &matches[0], nil
+	}
+
+	candidates := make([]string, len(matches))
+	for i, m := range matches {
+		candidates[i] = fmt.Sprintf("%s (ID %d, %s %s)", m.Name, m.ID, m.Rarity, m.Type)
+	}
+	return nil, fmt.Errorf("%q does not name exactly one item; closest matches: %s. Use one of these names, the rarity and type filters, or look the item up by ID",
+		name, strings.Join(candidates, "; "))
+}
 
-	s.logger.Debug("Item by name request", "name", args.Name)
+// resolveItemName finds the item a name refers to. The item index is tried first;
+// while it is still building, or when nothing in it matches, the wiki is searched instead.
+func (s *MCPServer) resolveItemName(ctx context.Context, name string, filter gw2api.ItemNameFilter) (*resolvedItem, error) {
+	if index, ok := s.gw2API.ReadyItemIndex(ctx); ok {
+		match, err := findIndexedItem(index, name, filter)
+		if err != nil {
+			return nil, err
+		}
+		if match != nil {
+			return &resolvedItem{ID: match.ID}, nil
+		}
+		s.logger.Debug("No item index match, searching the wiki", "name", name)
+	}
 
-	// Search wiki for the item
-	results, err := s.wiki.Search(ctx, args.Name, 1)
+	results, err := s.wiki.Search(ctx, name, 1)
 	if err != nil {
-		return errResult(fmt.Sprintf("Wiki search failed: %v", err))
+		return nil, fmt.Errorf("wiki search failed: %w", err)
 	}
 	if len(results.Results) == 0 {
-		return errResult(fmt.Sprintf("No wiki results found for %q", args.Name))
+		return nil, fmt.Errorf("no item or wiki page found for %q", name)
+	}
+	result := results.Results[0]
+	id, err := extractItemIDFromWikiResult(result)
+	if err != nil {
+		return nil, err
+	}
+	return &resolvedItem{ID: id, Wiki: &result}, nil
+}
+
+// handleGetItemByName handles item lookup by name
+func (s *MCPServer) handleGetItemByName(ctx context.Context, _ *mcp.CallToolRequest, args GetItemByNameArgs) (*mcp.CallToolResult, any, error) {
+	if args.Name == "" {
+		return errResult("name parameter is required")
 	}
 
-	// Extract item ID from infobox
-	id, err := extractItemIDFromWikiResult(results.Results[0])
+	s.logger.Debug("Item by name request", "name", args.Name, "rarity", args.Rarity, "type", args.Type)
+
+	filter := gw2api.ItemNameFilter{Rarity: args.Rarity, Type: args.Type}
+	resolved, err := s.resolveItemName(ctx, args.Name, filter)
 	if err != nil {
-		return errResult(err.Error())
+		return apiErrResult("Failed to find item", err)
 	}
+	id := resolved.ID
 
 	// Fetch full item details
 	items, err := s.gw2API.GetItems(ctx, []int{id})
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get item details: %v", err))
+		return apiErrResult("Failed to get item details", err)
 	}
 
 	item, ok := items[id]
@@ -645,6 +1046,11 @@ func (s *MCPServer) handleGetItemByName(ctx context.Context, _ *mcp.CallToolRequ
 		return errResult(fmt.Sprintf("Item ID %d not found in API response", id))
 	}
 
+	// The wiki fallback does not filter, so check its match here
+	if !filter.Matches(gw2api.IndexedItem{Rarity: item.Rarity, Type: item.Type}) {
+		return errResult(fmt.Sprintf("%q resolved to %s (%s %s), which does not match the rarity and type filters", args.Name, item.Name, item.Rarity, item.Type))
+	}
+
 	return jsonResult(item)
 }
 
@@ -656,89 +1062,95 @@ func (s *MCPServer) handleGetItemRecipeByName(ctx context.Context, _ *mcp.CallTo
 
 	s.logger.Debug("Item recipe by name request", "name", args.Name)
 
-	// Search wiki for the item
-	results, err := s.wiki.Search(ctx, args.Name, 1)
-	if err != nil {
-		return errResult(fmt.Sprintf("Wiki search failed: %v", err))
-	}
-	if len(results.Results) == 0 {
-		return errResult(fmt.Sprintf("No wiki results found for %q", args.Name))
-	}
-
-	wikiResult := results.Results[0]
-
-	// Try to get recipe IDs from wiki result
-	recipeIDs, _ := extractRecipeIDsFromWikiResult(wikiResult)
-
-	// If no wiki recipes, try fallback via API using item ID
-	itemID := 0
-	if len(recipeIDs) == 0 {
-		id, err := extractItemIDFromWikiResult(wikiResult)
+	// Resolve the item from the item index, then from the wiki, whose pages also
+	// list recipes for items without an ID in their infobox
+	var (
+		itemID    int
+		title     string
+		recipeIDs []int
+		itemErr   error
+	)
+	if index, ok := s.gw2API.ReadyItemIndex(ctx); ok {
+		match, err := findIndexedItem(index, args.Name, gw2api.ItemNameFilter{})
 		if err != nil {
-			return errResult(fmt.Sprintf("No recipes found in wiki and %v", err))
+			return apiErrResult("Failed to find item", err)
 		}
-		itemID = id
-
-		apiRecipeIDs, err := s.gw2API.SearchRecipes(ctx, 0, itemID)
+		if match != nil {
+			itemID, title = match.ID, match.Name
+		}
+	}
+	if itemID == 0 {
+		results, err := s.wiki.Search(ctx, args.Name, 1)
 		if err != nil {
-			return errResult(fmt.Sprintf("Failed to search recipes by output item: %v", err))
+			return apiErrResult("Wiki search failed", err)
 		}
-		if len(apiRecipeIDs) == 0 {
-			return errResult(fmt.Sprintf("No recipes found for %q (item ID %d)", args.Name, itemID))
+		if len(results.Results) == 0 {
+			return errResult(fmt.Sprintf("No item or wiki page found for %q", args.Name))
+		}
+
+		wikiResult := results.Results[0]
+		title = wikiResult.Title
+		recipeIDs, _ = extractRecipeIDsFromWikiResult(wikiResult)
+		itemID, itemErr = extractItemIDFromWikiResult(wikiResult)
+		if itemErr != nil {
+			itemID = 0
 		}
-		recipeIDs = apiRecipeIDs
-	} else if id, err := extractItemIDFromWikiResult(wikiResult); err == nil {
-		itemID = id
 	}
 
-	// Fetch full recipe details
-	recipes, err := s.gw2API.GetRecipes(ctx, recipeIDs)
-	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get recipe details: %v", err))
+	// Mystic Forge recipes are listed on the item's wiki page but have no recipe ID
+	var forgeRecipes []wiki.MysticForgeRecipe
+	if mf, err := s.wiki.GetMysticForgeRecipes(ctx, title); err != nil {
+		s.logger.Warn("Failed to get Mystic Forge recipes", "title", title, "error", err)
+	} else {
+		forgeRecipes = filterMysticForgeRecipes(mf, itemID)
 	}
 
-	// Collect all item IDs for name resolution
-	itemIDSet := make(map[int]bool)
-	for _, r := range recipes {
-		itemIDSet[r.OutputItemID] = true
-		for _, ing := range r.Ingredients {
-			itemIDSet[ing.ItemID] = true
+	// Without wiki recipes, find the recipes that output the item through the API
+	if len(recipeIDs) == 0 && itemID > 0 {
+		apiRecipeIDs, err := s.gw2API.SearchRecipes(ctx, 0, itemID)
+		if err != nil {
+			return apiErrResult("Failed to search recipes by output item", err)
 		}
+		recipeIDs = apiRecipeIDs
 	}
-	allItemIDs := make([]int, 0, len(itemIDSet))
-	for id := range itemIDSet {
-		allItemIDs = append(allItemIDs, id)
+
+	if len(recipeIDs) == 0 && len(forgeRecipes) == 0 {
+		if itemErr != nil {
+			return errResult(fmt.Sprintf("No recipes found in wiki and %v", itemErr))
+		}
+		return errResult(fmt.Sprintf("No recipes found for %q (item ID %d)", args.Name, itemID))
 	}
 
-	// Resolve item names
-	itemMap, err := s.gw2API.GetItems(ctx, allItemIDs)
-	if err != nil {
-		s.logger.Warn("Failed to resolve item names for recipes", "error", err)
-		itemMap = make(map[int]gw2api.Item)
+	// Fetch full recipe details
+	var recipes []gw2api.Recipe
+	if len(recipeIDs) > 0 {
+		var err error
+		recipes, err = s.gw2API.GetRecipes(ctx, recipeIDs)
+		if err != nil {
+			return apiErrResult("Failed to get recipe details", err)
+		}
 	}
 
 	// Build enriched recipes
-	enriched := make([]EnrichedRecipe, len(recipes))
-	for i, r := range recipes {
-		ingNames := make(map[int]string)
-		for _, ing := range r.Ingredients {
-			if item, ok := itemMap[ing.ItemID]; ok {
-				ingNames[ing.ItemID] = item.Name
-			}
-		}
-		outputName := ""
-		if item, ok := itemMap[r.OutputItemID]; ok {
-			outputName = item.Name
-		}
-		enriched[i] = EnrichedRecipe{
+	enriched := make([]EnrichedRecipe, 0, len(recipes)+len(forgeRecipes))
+	for _, r := range recipes {
+		enriched = append(enriched, EnrichedRecipe{
 			Recipe:          r,
-			OutputItemName:  outputName,
-			IngredientNames: ingNames,
+			Source:          recipeSourceAPI,
+			IngredientNames: make(map[int]string),
+		})
+	}
+	for _, mf := range forgeRecipes {
+		recipe := mysticForgeToEnriched(mf)
+		if recipe.OutputItemID == 0 {
+			recipe.OutputItemID = itemID
 		}
+		enriched = append(enriched, recipe)
 	}
+	s.enrichRecipeNames(ctx, enriched)
 
 	result := ItemRecipeResult{
-		ItemName: wikiResult.Title,
+		ItemName: title,
 		ItemID:   itemID,
 		Recipes:  enriched,
 	}
@@ -754,25 +1166,16 @@ func (s *MCPServer) handleGetTPPriceByName(ctx context.Context, _ *mcp.CallToolR
 
 	s.logger.Debug("TP price by name request", "name", args.Name)
 
-	// Search wiki for the item
-	results, err := s.wiki.Search(ctx, args.Name, 1)
-	if err != nil {
-		return errResult(fmt.Sprintf("Wiki search failed: %v", err))
-	}
-	if len(results.Results) == 0 {
-		return errResult(fmt.Sprintf("No wiki results found for %q", args.Name))
-	}
-
-	// Extract item ID from infobox
-	id, err := extractItemIDFromWikiResult(results.Results[0])
+	resolved, err := s.resolveItemName(ctx, args.Name, gw2api.ItemNameFilter{})
 	if err != nil {
-		return errResult(err.Error())
+		return apiErrResult("Failed to find item", err)
 	}
+	id := resolved.ID
 
 	// Fetch trading post prices
 	prices, err := s.gw2API.GetPrices(ctx, []int{id})
 	if err != nil {
-		return errResult(fmt.Sprintf("Failed to get trading post prices: %v", err))
+		return apiErrResult("Failed to get trading post prices", err)
 	}
 	if len(prices) == 0 {
 		return errResult(fmt.Sprintf("No trading post data found for item ID %d", id))
@@ -781,27 +1184,4059 @@ func (s *MCPServer) handleGetTPPriceByName(ctx context.Context, _ *mcp.CallToolR
 	return jsonResult(prices[0])
 }
 
-// handleCurrencyListResource handles the currency list resource
-func (s *MCPServer) handleCurrencyListResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
-	s.logger.Debug("Currency list resource request")
+// ItemLocation describes a stack of a matching item found on the account
+type ItemLocation struct {
+	Source    string `json:"source"`
+	Character string `json:"character,omitempty"`
+	Slot      string `json:"slot,omitempty"`
+	ItemID    int    `json:"item_id"`
+	ItemName  string `json:"item_name,omitempty"`
+	Count     int    `json:"count"`
+}
 
-	currencies, err := s.gw2API.GetCurrencies(ctx, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get currencies: %w", err)
+// FindItemResult is the response for find_item_on_account
+type FindItemResult struct {
+	Query      string         `json:"query"`
+	Locations  []ItemLocation `json:"locations"`
+	TotalCount int            `json:"total_count"`
+	Skipped    []string       `json:"skipped,omitempty"`
+}
+
+// itemMatcher matches item stacks by exact ID or by case-insensitive partial name
+type itemMatcher struct {
+	id   int
+	name string
+}
+
+// newItemMatcher builds a matcher; the ID takes precedence over the name when both are set
+func newItemMatcher(id int, name string) itemMatcher {
+	if id > 0 {
+		return itemMatcher{id: id}
+	}
+	return itemMatcher{name: strings.ToLower(strings.TrimSpace(name))}
+}
+
+// matches reports whether an item stack with the given ID and resolved name matches
+func (m itemMatcher) matches(id int, name string) bool {
+	if m.id > 0 {
+		return id == m.id
+	}
+	return m.name != "" && strings.Contains(strings.ToLower(name), m.name)
+}
+
+// locateInBags returns the matching stacks in a character's bags
+func locateInBags(m itemMatcher, character string, bags []*gw2api.CharacterBag) []ItemLocation {
+	var locations []ItemLocation
+	for i, bag := range bags {
+		if bag == nil {
+			continue
+		}
+		for _, slot := range bag.Inventory {
+			if slot != nil && m.matches(slot.ID, slot.ItemName) {
+				locations = append(locations, ItemLocation{
+					Source:    "character_bags",
+					Character: character,
+					Slot:      fmt.Sprintf("bag %d", i+1),
+					ItemID:    slot.ID,
+					ItemName:  slot.ItemName,
+					Count:     slot.Count,
+				})
+			}
+		}
+	}
+	return locations
+}
+
+// locateInEquipment returns the matching items equipped on a character
+func locateInEquipment(m itemMatcher, character string, equipment []gw2api.EquipmentItem) []ItemLocation {
+	var locations []ItemLocation
+	for _, eq := range equipment {
+		if !m.matches(eq.ID, eq.ItemName) {
+			continue
+		}
+		count := eq.Count
+		if count == 0 {
+			count = 1
+		}
+		locations = append(locations, ItemLocation{
+			Source:    "character_equipment",
+			Character: character,
+			Slot:      eq.Slot,
+			ItemID:    eq.ID,
+			ItemName:  eq.ItemName,
+			Count:     count,
+		})
+	}
+	return locations
+}
+
+// handleFindItemOnAccount searches every account storage location for an item
+func (s *MCPServer) handleFindItemOnAccount(ctx context.Context, _ *mcp.CallToolRequest, args FindItemOnAccountArgs) (*mcp.CallToolResult, any, error) {
+	if args.ItemID <= 0 && strings.TrimSpace(args.Name) == "" {
+		return errResult("either item_id or name must be provided")
+	}
+
+	if s.gw2API.APIKey() == "" {
+		return errResult("Failed to search account: GW2_API_KEY environment variable not configured")
+	}
+
+	s.logger.Debug("Find item on account request", "item_id", args.ItemID, "name", args.Name)
+
+	m := newItemMatcher(args.ItemID, args.Name)
+	result := FindItemResult{Query: args.Name, Locations: []ItemLocation{}}
+	if args.ItemID > 0 {
+		result.Query = strconv.Itoa(args.ItemID)
+	}
+
+	if bank, err := s.gw2API.GetBank(ctx); err != nil {
+		result.Skipped = append(result.Skipped, fmt.Sprintf("bank: %v", err))
+	} else {
+		for i, slot := range bank.Slots {
+			if slot != nil && m.matches(slot.ID, slot.ItemName) {
+				result.Locations = append(result.Locations, ItemLocation{
+					Source: "bank", Slot: fmt.Sprintf("slot %d", i+1),
+					ItemID: slot.ID, ItemName: slot.ItemName, Count: slot.Count,
+				})
+			}
+		}
 	}
 
-	currenciesJSON, err := json.MarshalIndent(currencies, "", "  ")
+	if materials, err := s.gw2API.GetMaterials(ctx); err != nil {
+		result.Skipped = append(result.Skipped, fmt.Sprintf("materials: %v", err))
+	} else {
+		for _, mat := range materials.Materials {
+			if mat.Count > 0 && m.matches(mat.ID, mat.ItemName) {
+				result.Locations = append(result.Locations, ItemLocation{
+					Source: "materials", ItemID: mat.ID, ItemName: mat.ItemName, Count: mat.Count,
+				})
+			}
+		}
+	}
+
+	if shared, err := s.gw2API.GetSharedInventory(ctx); err != nil {
+		result.Skipped = append(result.Skipped, fmt.Sprintf("shared inventory: %v", err))
+	} else {
+		for i, slot := range shared.Slots {
+			if slot != nil && m.matches(slot.ID, slot.ItemName) {
+				result.Locations = append(result.Locations, ItemLocation{
+					Source: "shared_inventory", Slot: fmt.Sprintf("slot %d", i+1),
+					ItemID: slot.ID, ItemName: slot.ItemName, Count: slot.Count,
+				})
+			}
+		}
+	}
+
+	if delivery, err := s.gw2API.GetDelivery(ctx); err != nil {
+		result.Skipped = append(result.Skipped, fmt.Sprintf("trading post delivery: %v", err))
+	} else {
+		for _, di := range delivery.Items {
+			if m.matches(di.ID, di.ItemName) {
+				result.Locations = append(result.Locations, ItemLocation{
+					Source: "tp_delivery", ItemID: di.ID, ItemName: di.ItemName, Count: di.Count,
+				})
+			}
+		}
+	}
+
+	characters, err := s.gw2API.GetCharacters(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to format currencies: %w", err)
+		result.Skipped = append(result.Skipped, fmt.Sprintf("characters: %v", err))
+	}
+	for _, name := range characters {
+		if inv, err := s.gw2API.GetCharacterInventory(ctx, name); err != nil {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s bags: %v", name, err))
+		} else {
+			result.Locations = append(result.Locations, locateInBags(m, name, inv.Bags)...)
+		}
+		if eq, err := s.gw2API.GetCharacterEquipment(ctx, name); err != nil {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s equipment: %v", name, err))
+		} else {
+			result.Locations = append(result.Locations, locateInEquipment(m, name, eq.Equipment)...)
+		}
 	}
 
-	return &mcp.ReadResourceResult{
-		Contents: []*mcp.ResourceContents{
-			{
-				URI:      "gw2://currencies",
-				MIMEType: "application/json",
-				Text:     string(currenciesJSON),
-			},
-		},
-	}, nil
+	for _, loc := range result.Locations {
+		result.TotalCount += loc.Count
+	}
+
+	return jsonResult(result)
+}
+
+// LegendarySlotSummary groups Legendary Armory entries for one equipment slot
+type LegendarySlotSummary struct {
+	Slot     string   `json:"slot"`
+	Unlocked int      `json:"unlocked"`
+	Total    int      `json:"total"`
+	Owned    []string `json:"owned,omitempty"`
+	Missing  []string `json:"missing,omitempty"`
+}
+
+// LegendaryPlan is the crafting plan for a target legendary
+type LegendaryPlan struct {
+	ItemID             int            `json:"item_id"`
+	ItemName           string         `json:"item_name"`
+	Count              int            `json:"count"`
+	ArmoryCount        int            `json:"armory_count"`
+	ArmoryMaxCount     int            `json:"armory_max_count,omitempty"`
+	Plan               *crafting.Plan `json:"plan"`
+	TotalCost          int            `json:"total_cost"`
+	TotalCostFormatted string         `json:"total_cost_formatted"`
+	UntradeableMissing int            `json:"untradeable_missing"`
+	UnresolvedMissing  int            `json:"unresolved_missing"`
+}
+
+// LegendaryPlannerResult is the response for legendary_planner
+type LegendaryPlannerResult struct {
+	Unlocked int                    `json:"unlocked"`
+	Total    int                    `json:"total"`
+	Slots    []LegendarySlotSummary `json:"slots"`
+	Target   *LegendaryPlan         `json:"target,omitempty"`
+	Skipped  []string               `json:"skipped,omitempty"`
+}
+
+// legendarySlot derives the armory slot label for a legendary item
+func legendarySlot(item gw2api.Item) string {
+	detail := item.DetailType()
+	switch item.Type {
+	case "Armor", "Weapon", "Trinket":
+		if detail != "" {
+			return item.Type + ": " + detail
+		}
+		return item.Type
+	case "UpgradeComponent":
+		if detail != "" {
+			return detail
+		}
+		return "Upgrade Component"
+	default:
+		return item.Type
+	}
+}
+
+// summarizeLegendarySlots groups armory entries by slot with owned and missing names
+func summarizeLegendarySlots(entries []gw2api.LegendaryArmoryEntry, items map[int]gw2api.Item, owned map[int]int) []LegendarySlotSummary {
+	bySlot := make(map[string]*LegendarySlotSummary)
+	for _, entry := range entries {
+		item, ok := items[entry.ID]
+		slot, name := "Unknown", fmt.Sprintf("Item %d", entry.ID)
+		if ok {
+			slot, name = legendarySlot(item), item.Name
+		}
+		summary, ok := bySlot[slot]
+		if !ok {
+			summary = &LegendarySlotSummary{Slot: slot}
+			bySlot[slot] = summary
+		}
+		summary.Total++
+		if owned[entry.ID] > 0 {
+			summary.Unlocked++
+			summary.Owned = append(summary.Owned, name)
+		} else {
+			summary.Missing = append(summary.Missing, name)
+		}
+	}
+
+	slots := make([]LegendarySlotSummary, 0, len(bySlot))
+	for _, summary := range bySlot {
+		sort.Strings(summary.Owned)
+		sort.Strings(summary.Missing)
+		slots = append(slots, *summary)
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i].Slot < slots[j].Slot })
+	return slots
+}
+
+// findItemIDByName returns the ID of the item whose name matches, preferring exact
+// (case-insensitive) matches over partial ones. It returns 0 when nothing matches.
+func findItemIDByName(items map[int]gw2api.Item, name string) int {
+	query := strings.ToLower(strings.TrimSpace(name))
+	if query == "" {
+		return 0
+	}
+	partial := 0
+	for id, item := range items {
+		itemName := strings.ToLower(item.Name)
+		if itemName == query {
+			return id
+		}
+		if strings.Contains(itemName, query) && (partial == 0 || id < partial) {
+			partial = id
+		}
+	}
+	return partial
+}
+
+// recipeLookup resolves a craftable recipe for an item for use by the crafting planner
+func (s *MCPServer) recipeLookup(ctx context.Context, itemID int) (*crafting.Recipe, error) {
+	ids, err := s.gw2API.SearchRecipes(ctx, 0, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return s.mysticForgeLookup(ctx, itemID)
+	}
+
+	recipes, err := s.gw2API.GetRecipes(ctx, ids[:1])
+	if err != nil {
+		return nil, err
+	}
+	if len(recipes) == 0 {
+		return nil, nil
+	}
+
+	r := recipes[0]
+	recipe := &crafting.Recipe{
+		ID:          r.ID,
+		Source:      recipeSourceAPI,
+		OutputID:    r.OutputItemID,
+		OutputCount: r.OutputItemCount,
+	}
+	for _, ing := range r.Ingredients {
+		recipe.Ingredients = append(recipe.Ingredients, crafting.Ingredient{Kind: crafting.KindItem, ID: ing.ItemID, Count: ing.Count})
+	}
+	return recipe, nil
+}
+
+// mysticForgeLookup resolves a Mystic Forge recipe for an item for use by the crafting planner.
+// Ingredients the wiki could not resolve to an item ID stay in the tree as unresolved
+// requirements under their wiki names.
+func (s *MCPServer) mysticForgeLookup(ctx context.Context, itemID int) (*crafting.Recipe, error) {
+	items, err := s.gw2API.GetItems(ctx, []int{itemID})
+	if err != nil {
+		return nil, err
+	}
+	item, ok := items[itemID]
+	if !ok || !forgeOutputCandidate(item) {
+		return nil, nil
+	}
+
+	forgeRecipes, err := s.wikiForgeRecipesForItem(ctx, item)
+	if err != nil {
+		s.logger.Warn("Failed to get Mystic Forge recipes", "item_id", itemID, "error", err)
+		return nil, nil
+	}
+	if len(forgeRecipes) == 0 {
+		return nil, nil
+	}
+
+	r := forgeRecipes[0]
+	recipe := &crafting.Recipe{
+		Source:      recipeSourceMysticForge,
+		OutputID:    itemID,
+		OutputCount: r.OutputCount,
+	}
+	for _, ing := range r.Ingredients {
+		if ing.ItemID == 0 {
+			recipe.Ingredients = append(recipe.Ingredients, crafting.Ingredient{Kind: crafting.KindUnresolved, Name: ing.Name, Count: ing.Count})
+			continue
+		}
+		recipe.Ingredients = append(recipe.Ingredients, crafting.Ingredient{Kind: crafting.KindItem, ID: ing.ItemID, Count: ing.Count})
+	}
+	return recipe, nil
+}
+
+// accountInventory totals owned items and currencies from material storage, bank,
+// shared inventory and wallet. Sources that fail are reported in the returned list.
+func (s *MCPServer) accountInventory(ctx context.Context) (crafting.Inventory, []string) {
+	inv := crafting.Inventory{}
+	var skipped []string
+
+	if materials, err := s.gw2API.GetMaterials(ctx); err != nil {
+		skipped = append(skipped, fmt.Sprintf("materials: %v", err))
+	} else {
+		for _, m := range materials.Materials {
+			inv.Add(crafting.KindItem, m.ID, m.Count)
+		}
+	}
+
+	if bank, err := s.gw2API.GetBank(ctx); err != nil {
+		skipped = append(skipped, fmt.Sprintf("bank: %v", err))
+	} else {
+		for _, slot := range bank.Slots {
+			if slot != nil {
+				inv.Add(crafting.KindItem, slot.ID, slot.Count)
+			}
+		}
+	}
+
+	if shared, err := s.gw2API.GetSharedInventory(ctx); err != nil {
+		skipped = append(skipped, fmt.Sprintf("shared inventory: %v", err))
+	} else {
+		for _, slot := range shared.Slots {
+			if slot != nil {
+				inv.Add(crafting.KindItem, slot.ID, slot.Count)
+			}
+		}
+	}
+
+	if wallet, err := s.gw2API.GetWallet(ctx); err != nil {
+		skipped = append(skipped, fmt.Sprintf("wallet: %v", err))
+	} else {
+		for _, entry := range wallet.Entries {
+			inv.Add(crafting.KindCurrency, entry.ID, entry.Value)
+		}
+	}
+
+	return inv, skipped
+}
+
+// enrichPlan resolves item and currency names for a plan and prices the missing
+// tradeable items at their current sell listing. It returns the total cost in copper.
+func (s *MCPServer) enrichPlan(ctx context.Context, plan *crafting.Plan) int {
+	var itemIDs, currencyIDs []int
+	plan.Root.Walk(func(n *crafting.Node) {
+		switch n.Kind {
+		case crafting.KindCurrency:
+			currencyIDs = append(currencyIDs, n.ID)
+		case crafting.KindItem:
+			itemIDs = append(itemIDs, n.ID)
+		}
+	})
+
+	items, err := s.gw2API.GetItems(ctx, itemIDs)
+	if err != nil {
+		s.logger.Warn("Failed to resolve item names for plan", "error", err)
+		items = make(map[int]gw2api.Item)
+	}
+	currencies := make(map[int]gw2api.Currency)
+	if len(currencyIDs) > 0 {
+		if c, err := s.gw2API.GetCurrencies(ctx, currencyIDs); err != nil {
+			s.logger.Warn("Failed to resolve currency names for plan", "error", err)
+		} else {
+			currencies = c
+		}
+	}
+
+	// Unresolved ingredients keep their wiki names
+	nameOf := func(kind string, id int, name string) string {
+		switch kind {
+		case crafting.KindCurrency:
+			return currencies[id].Name
+		case crafting.KindItem:
+			return items[id].Name
+		}
+		return name
+	}
+	plan.Root.Walk(func(n *crafting.Node) { n.Name = nameOf(n.Kind, n.ID, n.Name) })
+
+	var missingItemIDs []int
+	for i, req := range plan.Missing {
+		plan.Missing[i].Name = nameOf(req.Kind, req.ID, req.Name)
+		if req.Kind == crafting.KindItem {
+			missingItemIDs = append(missingItemIDs, req.ID)
+		}
+	}
+
+	prices := make(map[int]int)
+	if len(missingItemIDs) > 0 {
+		priceInfo, err := s.gw2API.GetPrices(ctx, missingItemIDs)
+		if err != nil {
+			s.logger.Warn("Failed to get prices for plan", "error", err)
+		}
+		for _, p := range priceInfo {
+			prices[p.ID] = p.Sells.UnitPrice
+		}
+	}
+
+	total := plan.ApplyPrices(prices)
+	for i, req := range plan.Missing {
+		if req.TotalCost > 0 {
+			plan.Missing[i].FormattedCost = gw2api.FormatCoins(req.TotalCost)
+		}
+	}
+	return total
+}
+
+// handleLegendaryPlanner reports Legendary Armory progress and plans a target legendary
+func (s *MCPServer) handleLegendaryPlanner(ctx context.Context, _ *mcp.CallToolRequest, args LegendaryPlannerArgs) (*mcp.CallToolResult, any, error) {
+	count := args.Count
+	if count <= 0 {
+		count = 1
+	}
+
+	s.logger.Debug("Legendary planner request", "target", args.Target, "target_id", args.TargetID, "count", count)
+
+	entries, err := s.gw2API.GetLegendaryArmory(ctx)
+	if err != nil {
+		return apiErrResult("Failed to get legendary armory", err)
+	}
+
+	armoryIDs := make([]int, len(entries))
+	maxCounts := make(map[int]int, len(entries))
+	for i, e := range entries {
+		armoryIDs[i] = e.ID
+		maxCounts[e.ID] = e.MaxCount
+	}
+	items, err := s.gw2API.GetItems(ctx, armoryIDs)
+	if err != nil {
+		return apiErrResult("Failed to get legendary item details", err)
+	}
+
+	result := LegendaryPlannerResult{Total: len(entries)}
+
+	owned := make(map[int]int)
+	if unlocked, err := s.gw2API.GetAccountLegendaryArmory(ctx); err != nil {
+		result.Skipped = append(result.Skipped, fmt.Sprintf("account legendary armory: %v", err))
+	} else {
+		for _, u := range unlocked {
+			owned[u.ID] = u.Count
+			if u.Count > 0 {
+				result.Unlocked++
+			}
+		}
+	}
+	result.Slots = summarizeLegendarySlots(entries, items, owned)
+
+	if args.TargetID <= 0 && strings.TrimSpace(args.Target) == "" {
+		return jsonResult(result)
+	}
+
+	// Resolve the target: explicit ID, then armory names, then legendaries in the item
+	// name index and the wiki
+	targetID := args.TargetID
+	if targetID <= 0 {
+		targetID = findItemIDByName(items, args.Target)
+	}
+	if targetID <= 0 {
+		resolved, err := s.resolveItemName(ctx, args.Target, gw2api.ItemNameFilter{Rarity: "Legendary"})
+		if err != nil {
+			return apiErrResult("Failed to find legendary", err)
+		}
+		targetID = resolved.ID
+	}
+
+	inv, skipped := s.accountInventory(ctx)
+	result.Skipped = append(result.Skipped, skipped...)
+
+	plan, err := crafting.NewPlanner(s.recipeLookup).Plan(ctx, targetID, count, inv)
+	if err != nil {
+		// Armory legendaries are named even when only target_id was given
+		name := items[targetID].Name
+		if name == "" {
+			name = strings.TrimSpace(args.Target)
+		}
+		if name == "" {
+			return apiErrResult(fmt.Sprintf("Failed to plan item %d", targetID), err)
+		}
+		return apiErrResult(fmt.Sprintf("Failed to plan %q", name), err)
+	}
+	total := s.enrichPlan(ctx, plan)
+
+	target := &LegendaryPlan{
+		ItemID:             targetID,
+		ItemName:           plan.Root.Name,
+		Count:              count,
+		ArmoryCount:        owned[targetID],
+		ArmoryMaxCount:     maxCounts[targetID],
+		Plan:               plan,
+		TotalCost:          total,
+		TotalCostFormatted: gw2api.FormatCoins(total),
+	}
+	for _, req := range plan.Missing {
+		if !req.Tradeable {
+			target.UntradeableMissing++
+		}
+		if req.Kind == crafting.KindUnresolved {
+			target.UnresolvedMissing++
+		}
+	}
+	result.Target = target
+
+	return jsonResult(result)
+}
+
+// handleCurrencyListResource handles the currency list resource
+func (s *MCPServer) handleCurrencyListResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	s.logger.Debug("Currency list resource request")
+
+	currencies, err := s.gw2API.GetCurrencies(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get currencies: %w", err)
+	}
+
+	return jsonResource("gw2://currencies", currencies)
+}
+
+// jsonResource returns v as the JSON contents of the resource at uri
+func jsonResource(uri string, v any) (*mcp.ReadResourceResult, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to format %s: %w", uri, err)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      uri,
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		},
+	}, nil
+}
+
+// resourceID parses the numeric ID of a resource URI such as gw2://items/19721
+func resourceID(uri, prefix string) (int, bool) {
+	rest, ok := strings.CutPrefix(uri, prefix)
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.Atoi(rest)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+// readEntityResource reads a gw2://<kind>/{id} resource with get, which returns nil for
+// IDs that do not exist
+func (s *MCPServer) readEntityResource(ctx context.Context, req *mcp.ReadResourceRequest, prefix string, get func(context.Context, int) (any, error)) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	id, ok := resourceID(uri, prefix)
+	if !ok {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+
+	s.logger.Debug("Entity resource request", "uri", uri)
+
+	v, err := get(ctx, id)
+	if errors.Is(err, gw2api.ErrNotFound) || (err == nil && v == nil) {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return jsonResource(uri, v)
+}
+
+// firstOrNil returns the first element of a lookup by ID, or nil when nothing was found
+func firstOrNil[T any](found []T, err error) (any, error) {
+	if err != nil || len(found) == 0 {
+		return nil, err
+	}
+	return found[0], nil
+}
+
+// handleItemResource handles gw2://items/{id}
+func (s *MCPServer) handleItemResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	return s.readEntityResource(ctx, req, "gw2://items/", func(ctx context.Context, id int) (any, error) {
+		items, err := s.gw2API.GetItems(ctx, []int{id})
+		if err != nil {
+			return nil, err
+		}
+		if item, ok := items[id]; ok {
+			return item, nil
+		}
+		return nil, nil
+	})
+}
+
+// handleRecipeResource handles gw2://recipes/{id}
+func (s *MCPServer) handleRecipeResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	return s.readEntityResource(ctx, req, "gw2://recipes/", func(ctx context.Context, id int) (any, error) {
+		return firstOrNil(s.gw2API.GetRecipes(ctx, []int{id}))
+	})
+}
+
+// handleSkinResource handles gw2://skins/{id}
+func (s *MCPServer) handleSkinResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	return s.readEntityResource(ctx, req, "gw2://skins/", func(ctx context.Context, id int) (any, error) {
+		return firstOrNil(s.gw2API.GetSkins(ctx, []int{id}))
+	})
+}
+
+// handleAchievementResource handles gw2://achievements/{id}
+func (s *MCPServer) handleAchievementResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	return s.readEntityResource(ctx, req, "gw2://achievements/", func(ctx context.Context, id int) (any, error) {
+		return firstOrNil(s.gw2API.GetAchievements(ctx, []int{id}))
+	})
+}
+
+// handleWikiPageResource handles gw2://wiki/{title}
+func (s *MCPServer) handleWikiPageResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	return s.readNamedResource(ctx, req, "gw2://wiki/", func(ctx context.Context, title string) (any, error) {
+		page, err := s.wiki.GetPage(ctx, title)
+		if errors.Is(err, wiki.ErrPageNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get wiki page: %w", err)
+		}
+		return page, nil
+	})
+}
+
+// readNamedResource reads a gw2://<kind>/{name} resource with get, where name is the rest
+// of the URI unescaped
+func (s *MCPServer) readNamedResource(ctx context.Context, req *mcp.ReadResourceRequest, prefix string, get func(context.Context, string) (any, error)) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	escaped, _ := strings.CutPrefix(uri, prefix)
+	name, err := url.PathUnescape(escaped)
+	if err != nil || strings.TrimSpace(name) == "" {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+
+	s.logger.Debug("Named resource request", "uri", uri)
+
+	v, err := get(ctx, name)
+	if errors.Is(err, gw2api.ErrNotFound) || (err == nil && v == nil) {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return jsonResource(uri, v)
+}
+
+// handleCharacterResource handles gw2://characters/{name}
+func (s *MCPServer) handleCharacterResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	return s.readNamedResource(ctx, req, "gw2://characters/", func(ctx context.Context, name string) (any, error) {
+		return s.gw2API.GetCharacter(ctx, name)
+	})
+}
+
+// handleAccountUnlocksResource handles gw2://account/unlocks/{+type}
+func (s *MCPServer) handleAccountUnlocksResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	return s.readNamedResource(ctx, req, "gw2://account/unlocks/", func(ctx context.Context, unlockType string) (any, error) {
+		if !slices.Contains(gw2api.UnlockTypes(), unlockType) {
+			return nil, nil
+		}
+		return s.gw2API.GetAccountUnlocks(ctx, unlockType)
+	})
+}
+
+// handleAccountProgressResource handles gw2://account/progress/{+type}
+func (s *MCPServer) handleAccountProgressResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	return s.readNamedResource(ctx, req, "gw2://account/progress/", func(ctx context.Context, progressType string) (any, error) {
+		if !slices.Contains(gw2api.ProgressTypes(), progressType) {
+			return nil, nil
+		}
+		return s.gw2API.GetAccountProgress(ctx, progressType)
+	})
+}
+
+// handleAccountDailiesResource handles gw2://account/dailies/{type}
+func (s *MCPServer) handleAccountDailiesResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	return s.readNamedResource(ctx, req, "gw2://account/dailies/", func(ctx context.Context, dailyType string) (any, error) {
+		if !slices.Contains(gw2api.DailyTypes(), dailyType) {
+			return nil, nil
+		}
+		return s.gw2API.GetAccountDailies(ctx, dailyType)
+	})
+}
+
+// handleGuildResource handles gw2://guilds/{name}
+func (s *MCPServer) handleGuildResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	return s.readNamedResource(ctx, req, "gw2://guilds/", func(ctx context.Context, name string) (any, error) {
+		ids, err := s.gw2API.SearchGuild(ctx, name)
+		if err != nil || len(ids) == 0 {
+			return nil, err
+		}
+		return s.gw2API.GetGuild(ctx, ids[0])
+	})
+}
+
+// handleBankResource handles gw2://account/bank
+func (s *MCPServer) handleBankResource(ctx context.Context, _ *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	s.logger.Debug("Bank resource request")
+
+	bank, err := s.gw2API.GetBank(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bank: %w", err)
+	}
+	return jsonResource("gw2://account/bank", bank)
+}
+
+// AchievementCategoryProgress summarizes completion of a single achievement category
+type AchievementCategoryProgress struct {
+	ID              int    `json:"id"`
+	Name            string `json:"name"`
+	Group           string `json:"group,omitempty"`
+	Total           int    `json:"total"`
+	Done            int    `json:"done"`
+	PointsEarned    int    `json:"points_earned"`
+	PointsRemaining int    `json:"points_remaining"`
+}
+
+// AchievementProgressEntry is a single achievement joined with the account's progress
+type AchievementProgressEntry struct {
+	ID              int      `json:"id"`
+	Name            string   `json:"name"`
+	Category        string   `json:"category"`
+	Requirement     string   `json:"requirement,omitempty"`
+	Current         int      `json:"current"`
+	Max             int      `json:"max"`
+	Percent         float64  `json:"percent"`
+	Done            bool     `json:"done"`
+	PointsEarned    int      `json:"points_earned"`
+	PointsRemaining int      `json:"points_remaining"`
+	BitsDone        []string `json:"bits_done,omitempty"`
+	BitsRemaining   []string `json:"bits_remaining,omitempty"`
+}
+
+// AchievementProgressResult is the response for achievement_progress
+type AchievementProgressResult struct {
+	PointsEarned    int                           `json:"points_earned"`
+	PointsRemaining int                           `json:"points_remaining"`
+	Categories      []AchievementCategoryProgress `json:"categories"`
+	Nearest         []AchievementProgressEntry    `json:"nearest_to_completion"`
+	Achievements    []AchievementProgressEntry    `json:"achievements,omitempty"`
+}
+
+// hasFlag reports whether flags contains flag
+func hasFlag(flags []string, flag string) bool {
+	for _, f := range flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// isRotatingAchievement reports whether an achievement resets periodically and so never
+// contributes lasting points
+func isRotatingAchievement(ach gw2api.Achievement) bool {
+	return hasFlag(ach.Flags, "Daily") || hasFlag(ach.Flags, "Weekly") || hasFlag(ach.Flags, "Monthly")
+}
+
+// achievementPoints returns the points earned so far and the points possible for an
+// achievement. Repeatable achievements with a point cap can earn up to that cap.
+func achievementPoints(ach gw2api.Achievement, progress *gw2api.AccountAchievement) (earned, possible int) {
+	tierTotal := 0
+	for _, tier := range ach.Tiers {
+		tierTotal += tier.Points
+	}
+	possible = tierTotal
+	if hasFlag(ach.Flags, "Repeatable") && ach.PointCap > 0 {
+		possible = ach.PointCap
+	}
+	if progress == nil {
+		return 0, possible
+	}
+
+	if progress.Done {
+		earned = tierTotal
+	} else {
+		for _, tier := range ach.Tiers {
+			if progress.Current >= tier.Count {
+				earned += tier.Points
+			}
+		}
+	}
+	earned += progress.Repeated * tierTotal
+	if earned > possible {
+		earned = possible
+	}
+	return earned, possible
+}
+
+// newAchievementProgressEntry joins an achievement definition with the account's progress
+func newAchievementProgressEntry(ach gw2api.Achievement, category string, progress *gw2api.AccountAchievement) AchievementProgressEntry {
+	entry := AchievementProgressEntry{
+		ID:          ach.ID,
+		Name:        ach.Name,
+		Category:    category,
+		Requirement: ach.Requirement,
+	}
+	if len(ach.Tiers) > 0 {
+		entry.Max = ach.Tiers[len(ach.Tiers)-1].Count
+	}
+	if progress != nil {
+		entry.Current = progress.Current
+		entry.Done = progress.Done
+		if progress.Max > 0 {
+			entry.Max = progress.Max
+		}
+	}
+	if entry.Done {
+		entry.Current = entry.Max
+		entry.Percent = 100
+	} else if entry.Max > 0 {
+		entry.Percent = math.Round(float64(entry.Current)/float64(entry.Max)*1000) / 10
+	}
+
+	earned, possible := achievementPoints(ach, progress)
+	entry.PointsEarned = earned
+	if !isRotatingAchievement(ach) {
+		entry.PointsRemaining = possible - earned
+	}
+	return entry
+}
+
+// matchAchievementCategories returns the categories matching a filter by ID or name.
+// An exact name match wins over partial matches; an empty filter matches everything.
+func matchAchievementCategories(categories []gw2api.AchievementCategory, filter string) []gw2api.AchievementCategory {
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	if filter == "" {
+		return categories
+	}
+	if id, err := strconv.Atoi(filter); err == nil {
+		for _, c := range categories {
+			if c.ID == id {
+				return []gw2api.AchievementCategory{c}
+			}
+		}
+		return nil
+	}
+
+	var partial []gw2api.AchievementCategory
+	for _, c := range categories {
+		name := strings.ToLower(c.Name)
+		if name == filter {
+			return []gw2api.AchievementCategory{c}
+		}
+		if strings.Contains(name, filter) {
+			partial = append(partial, c)
+		}
+	}
+	return partial
+}
+
+// buildAchievementProgress joins categories, groups, achievement definitions and account
+// progress. Achievements are listed in full only when a category filter is given.
+func buildAchievementProgress(groups []gw2api.AchievementGroup, categories []gw2api.AchievementCategory,
+	achievements map[int]gw2api.Achievement, progress map[int]*gw2api.AccountAchievement, detailed bool, limit int,
+) AchievementProgressResult {
+	groupNames := make(map[int]string)
+	for _, g := range groups {
+		for _, id := range g.Categories {
+			groupNames[id] = g.Name
+		}
+	}
+
+	result := AchievementProgressResult{
+		Categories: []AchievementCategoryProgress{},
+		Nearest:    []AchievementProgressEntry{},
+	}
+	var candidates []AchievementProgressEntry
+	seen := make(map[int]bool)
+
+	for _, c := range categories {
+		summary := AchievementCategoryProgress{ID: c.ID, Name: c.Name, Group: groupNames[c.ID]}
+		for _, id := range c.Achievements {
+			ach, ok := achievements[id]
+			if !ok {
+				continue
+			}
+			entry := newAchievementProgressEntry(ach, c.Name, progress[id])
+
+			summary.Total++
+			if entry.Done {
+				summary.Done++
+			}
+			summary.PointsEarned += entry.PointsEarned
+			summary.PointsRemaining += entry.PointsRemaining
+			if detailed {
+				result.Achievements = append(result.Achievements, entry)
+			}
+
+			// Achievements can appear in more than one category; count totals once
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			result.PointsEarned += entry.PointsEarned
+			result.PointsRemaining += entry.PointsRemaining
+			if !entry.Done && entry.Current > 0 && entry.Max > 0 &&
+				!isRotatingAchievement(ach) && !hasFlag(ach.Flags, "IgnoreNearlyComplete") {
+				candidates = append(candidates, entry)
+			}
+		}
+		result.Categories = append(result.Categories, summary)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Percent != candidates[j].Percent {
+			return candidates[i].Percent > candidates[j].Percent
+		}
+		return candidates[i].PointsRemaining > candidates[j].PointsRemaining
+	})
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	result.Nearest = append(result.Nearest, candidates...)
+	return result
+}
+
+// achievementBitNames holds resolved names for item, skin and miniature achievement bits
+type achievementBitNames struct {
+	items map[int]string
+	skins map[int]string
+	minis map[int]string
+}
+
+// bitName returns a display name for an achievement bit
+func (n achievementBitNames) bitName(bit gw2api.AchievementBit) string {
+	var name string
+	switch bit.Type {
+	case "Text":
+		return bit.Text
+	case "Item":
+		name = n.items[bit.ID]
+	case "Skin":
+		name = n.skins[bit.ID]
+	case "Minipet":
+		name = n.minis[bit.ID]
+	}
+	if name == "" {
+		return fmt.Sprintf("%s %d", bit.Type, bit.ID)
+	}
+	return name
+}
+
+// achievementBits splits an achievement's bits into done and remaining display names
+func achievementBits(ach gw2api.Achievement, progress *gw2api.AccountAchievement, names achievementBitNames) (done, remaining []string) {
+	doneIdx := make(map[int]bool)
+	allDone := false
+	if progress != nil {
+		allDone = progress.Done
+		for _, idx := range progress.Bits {
+			doneIdx[idx] = true
+		}
+	}
+	for i, bit := range ach.Bits {
+		if allDone || doneIdx[i] {
+			done = append(done, names.bitName(bit))
+		} else {
+			remaining = append(remaining, names.bitName(bit))
+		}
+	}
+	return done, remaining
+}
+
+// resolveAchievementBitNames fetches names for the item, skin and miniature bits of the given
+// achievements. Lookups that fail leave the bits with a generic name.
+func (s *MCPServer) resolveAchievementBitNames(ctx context.Context, achievements []gw2api.Achievement) achievementBitNames {
+	names := achievementBitNames{items: map[int]string{}, skins: map[int]string{}, minis: map[int]string{}}
+	var itemIDs, skinIDs, miniIDs []int
+	for _, ach := range achievements {
+		for _, bit := range ach.Bits {
+			switch bit.Type {
+			case "Item":
+				itemIDs = append(itemIDs, bit.ID)
+			case "Skin":
+				skinIDs = append(skinIDs, bit.ID)
+			case "Minipet":
+				miniIDs = append(miniIDs, bit.ID)
+			}
+		}
+	}
+
+	if len(itemIDs) > 0 {
+		if items, err := s.gw2API.GetItems(ctx, itemIDs); err != nil {
+			s.logger.Warn("Failed to resolve achievement item bits", "error", err)
+		} else {
+			for id, item := range items {
+				names.items[id] = item.Name
+			}
+		}
+	}
+	if len(skinIDs) > 0 {
+		if skins, err := s.gw2API.GetSkins(ctx, skinIDs); err != nil {
+			s.logger.Warn("Failed to resolve achievement skin bits", "error", err)
+		} else {
+			for _, skin := range skins {
+				names.skins[skin.ID] = skin.Name
+			}
+		}
+	}
+	if len(miniIDs) > 0 {
+		if minis, err := s.gw2API.GetMinis(ctx, miniIDs); err != nil {
+			s.logger.Warn("Failed to resolve achievement miniature bits", "error", err)
+		} else {
+			for _, mini := range minis {
+				names.minis[mini.ID] = mini.Name
+			}
+		}
+	}
+	return names
+}
+
+// handleAchievementProgress handles achievement progress requests
+func (s *MCPServer) handleAchievementProgress(ctx context.Context, _ *mcp.CallToolRequest, args AchievementProgressArgs) (*mcp.CallToolResult, any, error) {
+	if s.gw2API.APIKey() == "" {
+		return errResult("Failed to get achievement progress: GW2_API_KEY environment variable not configured")
+	}
+
+	limit := args.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+
+	s.logger.Debug("Achievement progress request", "category", args.Category, "limit", limit)
+
+	categories, err := s.gw2API.GetAchievementCategories(ctx)
+	if err != nil {
+		return apiErrResult("Failed to get achievement categories", err)
+	}
+	groups, err := s.gw2API.GetAchievementGroups(ctx)
+	if err != nil {
+		return apiErrResult("Failed to get achievement groups", err)
+	}
+
+	categories = matchAchievementCategories(categories, args.Category)
+	if len(categories) == 0 {
+		return errResult(fmt.Sprintf("No achievement category matches %q", args.Category))
+	}
+
+	var ids []int
+	for _, c := range categories {
+		ids = append(ids, c.Achievements...)
+	}
+	defs, err := s.gw2API.GetAchievements(ctx, ids)
+	if err != nil {
+		return apiErrResult("Failed to get achievements", err)
+	}
+	achievements := make(map[int]gw2api.Achievement, len(defs))
+	for _, ach := range defs {
+		achievements[ach.ID] = ach
+	}
+
+	accountProgress, err := s.gw2API.GetAccountAchievements(ctx)
+	if err != nil {
+		return apiErrResult("Failed to get account achievements", err)
+	}
+	progress := make(map[int]*gw2api.AccountAchievement, len(accountProgress))
+	for i := range accountProgress {
+		progress[accountProgress[i].ID] = &accountProgress[i]
+	}
+
+	detailed := strings.TrimSpace(args.Category) != ""
+	result := buildAchievementProgress(groups, categories, achievements, progress, detailed, limit)
+
+	// Resolve bit names only for the achievements being shown
+	lists := [][]AchievementProgressEntry{result.Nearest, result.Achievements}
+	var shown []gw2api.Achievement
+	for _, list := range lists {
+		for _, entry := range list {
+			shown = append(shown, achievements[entry.ID])
+		}
+	}
+	names := s.resolveAchievementBitNames(ctx, shown)
+	for _, list := range lists {
+		for i := range list {
+			list[i].BitsDone, list[i].BitsRemaining = achievementBits(achievements[list[i].ID], progress[list[i].ID], names)
+		}
+	}
+
+	return jsonResult(result)
+}
+
+// Collection piece statuses reported by collection_status
+const (
+	pieceDone    = "done"
+	pieceOwned   = "owned"
+	pieceMissing = "missing"
+)
+
+// CollectionPiece is a single bit of a collection achievement and what the account has of it
+type CollectionPiece struct {
+	Index          int    `json:"index"`
+	Type           string `json:"type"`
+	ID             int    `json:"id,omitempty"`
+	Name           string `json:"name"`
+	Status         string `json:"status"`
+	ItemID         int    `json:"item_id,omitempty"`
+	UnitPrice      int    `json:"unit_price,omitempty"`
+	PriceFormatted string `json:"price_formatted,omitempty"`
+}
+
+// CollectionStatusResult is the response for collection_status
+type CollectionStatusResult struct {
+	ID            int               `json:"id"`
+	Name          string            `json:"name"`
+	Requirement   string            `json:"requirement,omitempty"`
+	Done          int               `json:"done"`
+	Owned         int               `json:"owned"`
+	Missing       int               `json:"missing"`
+	Pieces        []CollectionPiece `json:"pieces"`
+	CostToFinish  int               `json:"cost_to_finish"`
+	CostFormatted string            `json:"cost_to_finish_formatted"`
+	Unpriced      []string          `json:"unpriced,omitempty"`
+	Skipped       []string          `json:"skipped,omitempty"`
+}
+
+// findAchievementByName returns the achievement whose name matches query exactly
+// (case-insensitive) or, failing that, the only partial match. When several achievements
+// partially match, their names are returned instead.
+func findAchievementByName(achievements []gw2api.Achievement, query string) (*gw2api.Achievement, []string) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil, nil
+	}
+
+	var partial []int
+	for i, ach := range achievements {
+		name := strings.ToLower(ach.Name)
+		if name == query {
+			return &achievements[i], nil
+		}
+		if strings.Contains(name, query) {
+			partial = append(partial, i)
+		}
+	}
+	if len(partial) == 1 {
+		return &achievements[partial[0]], nil
+	}
+
+	names := make([]string, 0, len(partial))
+	for _, i := range partial {
+		names = append(names, achievements[i].Name)
+	}
+	sort.Strings(names)
+	return nil, names
+}
+
+// collectionPieces works out the status of every bit of a collection achievement. A bit is
+// done when the achievement has registered it or the matching skin or miniature is unlocked,
+// owned when a matching item, or for a skin any item in sources that unlocks it, sits in
+// account storage, and missing otherwise.
+func collectionPieces(ach gw2api.Achievement, progress *gw2api.AccountAchievement, unlockedSkins, unlockedMinis map[int]bool,
+	inv crafting.Inventory, minis map[int]gw2api.Mini, sources gw2api.SkinSources, names achievementBitNames,
+) []CollectionPiece {
+	doneIdx := make(map[int]bool)
+	if progress != nil {
+		for _, idx := range progress.Bits {
+			doneIdx[idx] = true
+		}
+	}
+
+	pieces := make([]CollectionPiece, 0, len(ach.Bits))
+	for i, bit := range ach.Bits {
+		piece := CollectionPiece{Index: i, Type: bit.Type, ID: bit.ID, Name: names.bitName(bit), Status: pieceMissing}
+		switch bit.Type {
+		case "Item":
+			piece.ItemID = bit.ID
+		case "Minipet":
+			piece.ItemID = minis[bit.ID].ItemID
+		case "Skin":
+			for _, itemID := range sources[bit.ID] {
+				if inv[crafting.Key(crafting.KindItem, itemID)] > 0 {
+					piece.ItemID = itemID
+					break
+				}
+			}
+		}
+
+		switch {
+		case (progress != nil && progress.Done) || doneIdx[i]:
+			piece.Status = pieceDone
+		case bit.Type == "Skin" && unlockedSkins[bit.ID]:
+			piece.Status = pieceDone
+		case bit.Type == "Minipet" && unlockedMinis[bit.ID]:
+			piece.Status = pieceDone
+		case piece.ItemID != 0 && inv[crafting.Key(crafting.KindItem, piece.ItemID)] > 0:
+			piece.Status = pieceOwned
+		}
+		pieces = append(pieces, piece)
+	}
+	return pieces
+}
+
+// priceCollection prices the missing pieces at their sell listing and returns the total
+// cost in copper along with the names of missing pieces that could not be priced. A
+// missing skin is priced at the cheapest listed item in sources that unlocks it, which
+// becomes its item ID.
+func priceCollection(pieces []CollectionPiece, prices map[int]int, sources gw2api.SkinSources) (int, []string) {
+	total := 0
+	var unpriced []string
+	for i := range pieces {
+		p := &pieces[i]
+		if p.Status != pieceMissing {
+			continue
+		}
+		if p.Type == "Skin" && p.ItemID == 0 {
+			for _, itemID := range sources[p.ID] {
+				if price := prices[itemID]; price > 0 && (p.ItemID == 0 || price < prices[p.ItemID]) {
+					p.ItemID = itemID
+				}
+			}
+		}
+		price, ok := prices[p.ItemID]
+		if p.ItemID == 0 || !ok || price == 0 {
+			unpriced = append(unpriced, p.Name)
+			continue
+		}
+		p.UnitPrice = price
+		p.PriceFormatted = gw2api.FormatCoins(price)
+		total += price
+	}
+	return total, unpriced
+}
+
+// handleCollectionStatus handles collection status requests
+func (s *MCPServer) handleCollectionStatus(ctx context.Context, _ *mcp.CallToolRequest, args CollectionStatusArgs) (*mcp.CallToolResult, any, error) {
+	if args.AchievementID <= 0 && strings.TrimSpace(args.Name) == "" {
+		return errResult("either achievement_id or name must be provided")
+	}
+	if s.gw2API.APIKey() == "" {
+		return errResult("Failed to get collection status: GW2_API_KEY environment variable not configured")
+	}
+
+	s.logger.Debug("Collection status request", "achievement_id", args.AchievementID, "name", args.Name)
+
+	var ach *gw2api.Achievement
+	if args.AchievementID > 0 {
+		achs, err := s.gw2API.GetAchievements(ctx, []int{args.AchievementID})
+		if err != nil {
+			return apiErrResult("Failed to get achievement", err)
+		}
+		if len(achs) == 0 {
+			return errResult(fmt.Sprintf("Achievement ID %d not found", args.AchievementID))
+		}
+		ach = &achs[0]
+	} else {
+		categories, err := s.gw2API.GetAchievementCategories(ctx)
+		if err != nil {
+			return apiErrResult("Failed to get achievement categories", err)
+		}
+		var ids []int
+		for _, c := range categories {
+			ids = append(ids, c.Achievements...)
+		}
+		achs, err := s.gw2API.GetAchievements(ctx, ids)
+		if err != nil {
+			return apiErrResult("Failed to get achievements", err)
+		}
+		var candidates []string
+		ach, candidates = findAchievementByName(achs, args.Name)
+		if ach == nil {
+			if len(candidates) == 0 {
+				return errResult(fmt.Sprintf("No achievement matches %q", args.Name))
+			}
+			if len(candidates) > 10 {
+				candidates = candidates[:10]
+			}
+			return errResult(fmt.Sprintf("Several achievements match %q, be more specific: %s", args.Name, strings.Join(candidates, "; ")))
+		}
+	}
+	if len(ach.Bits) == 0 {
+		return errResult(fmt.Sprintf("Achievement %q has no collection pieces", ach.Name))
+	}
+
+	result := CollectionStatusResult{ID: ach.ID, Name: ach.Name, Requirement: ach.Requirement}
+
+	var progress *gw2api.AccountAchievement
+	if all, err := s.gw2API.GetAccountAchievements(ctx); err != nil {
+		result.Skipped = append(result.Skipped, fmt.Sprintf("achievement progress: %v", err))
+	} else {
+		for i := range all {
+			if all[i].ID == ach.ID {
+				progress = &all[i]
+				break
+			}
+		}
+	}
+
+	unlockedSkins, err := s.gw2API.GetAccountUnlockIDs(ctx, "skins")
+	if err != nil {
+		result.Skipped = append(result.Skipped, fmt.Sprintf("skin unlocks: %v", err))
+	}
+	unlockedMinis, err := s.gw2API.GetAccountUnlockIDs(ctx, "minis")
+	if err != nil {
+		result.Skipped = append(result.Skipped, fmt.Sprintf("miniature unlocks: %v", err))
+	}
+	inv, skipped := s.accountInventory(ctx)
+	result.Skipped = append(result.Skipped, skipped...)
+
+	var miniIDs []int
+	for _, bit := range ach.Bits {
+		if bit.Type == "Minipet" {
+			miniIDs = append(miniIDs, bit.ID)
+		}
+	}
+	minis := make(map[int]gw2api.Mini)
+	if len(miniIDs) > 0 {
+		fetched, err := s.gw2API.GetMinis(ctx, miniIDs)
+		if err != nil {
+			s.logger.Warn("Failed to get miniatures for collection", "error", err)
+		}
+		for _, m := range fetched {
+			minis[m.ID] = m
+		}
+	}
+
+	// Skins are unlocked by items; the item index knows which
+	var sources gw2api.SkinSources
+	if slices.ContainsFunc(ach.Bits, func(bit gw2api.AchievementBit) bool { return bit.Type == "Skin" }) {
+		var ok bool
+		if sources, ok = s.gw2API.ReadySkinSources(ctx); !ok {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("skin sources: %v", errItemIndexBuilding))
+		}
+	}
+
+	names := s.resolveAchievementBitNames(ctx, []gw2api.Achievement{*ach})
+	result.Pieces = collectionPieces(*ach, progress, unlockedSkins, unlockedMinis, inv, minis, sources, names)
+
+	var missingItems []int
+	for _, p := range result.Pieces {
+		switch p.Status {
+		case pieceDone:
+			result.Done++
+		case pieceOwned:
+			result.Owned++
+		default:
+			result.Missing++
+			if p.ItemID != 0 {
+				missingItems = append(missingItems, p.ItemID)
+			} else if p.Type == "Skin" {
+				missingItems = append(missingItems, sources[p.ID]...)
+			}
+		}
+	}
+
+	prices := make(map[int]int)
+	if len(missingItems) > 0 {
+		priceInfo, err := s.gw2API.GetPrices(ctx, missingItems)
+		if err != nil {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("trading post prices: %v", err))
+		}
+		for _, p := range priceInfo {
+			prices[p.ID] = p.Sells.UnitPrice
+		}
+	}
+	result.CostToFinish, result.Unpriced = priceCollection(result.Pieces, prices, sources)
+	result.CostFormatted = gw2api.FormatCoins(result.CostToFinish)
+
+	return jsonResult(result)
+}
+
+// Continent floors searched by the map tools: Tyria and the Mists
+var searchedFloors = []struct{ continent, floor int }{{1, 1}, {2, 1}}
+
+// LocationMatch is a named location on a map
+type LocationMatch struct {
+	Name      string     `json:"name"`
+	Type      string     `json:"type"`
+	ID        int        `json:"id"`
+	ChatLink  string     `json:"chat_link,omitempty"`
+	Level     int        `json:"level,omitempty"`
+	Map       string     `json:"map"`
+	MapID     int        `json:"map_id"`
+	Region    string     `json:"region"`
+	Continent string     `json:"continent"`
+	Coord     [2]float64 `json:"coord"`
+}
+
+// FindLocationResult is the response for find_location
+type FindLocationResult struct {
+	Query   string          `json:"query"`
+	Matches []LocationMatch `json:"matches"`
+	Total   int             `json:"total"`
+	Skipped []string        `json:"skipped,omitempty"`
+}
+
+// mapLocations flattens the points of interest, hearts and sectors of a map into locations
+func mapLocations(m *gw2api.ContinentMap, region, continent string) []LocationMatch {
+	var locations []LocationMatch
+	base := LocationMatch{Map: m.Name, MapID: m.ID, Region: region, Continent: continent}
+
+	for _, poi := range m.PointsOfInterest {
+		loc := base
+		loc.Name, loc.Type, loc.ID, loc.ChatLink, loc.Coord = poi.Name, poi.Type, poi.ID, poi.ChatLink, poi.Coord
+		locations = append(locations, loc)
+	}
+	for _, task := range m.Tasks {
+		loc := base
+		loc.Name, loc.Type, loc.ID, loc.ChatLink, loc.Level, loc.Coord = task.Objective, "heart", task.ID, task.ChatLink, task.Level, task.Coord
+		locations = append(locations, loc)
+	}
+	for _, sector := range m.Sectors {
+		loc := base
+		loc.Name, loc.Type, loc.ID, loc.ChatLink, loc.Level, loc.Coord = sector.Name, "sector", sector.ID, sector.ChatLink, sector.Level, sector.Coord
+		locations = append(locations, loc)
+	}
+
+	sort.Slice(locations, func(i, j int) bool {
+		if locations[i].Type != locations[j].Type {
+			return locations[i].Type < locations[j].Type
+		}
+		return locations[i].Name < locations[j].Name
+	})
+	return locations
+}
+
+// searchLocations finds named locations on a floor matching query and, if set, a location type.
+// Exact name matches sort first.
+func searchLocations(floor *gw2api.ContinentFloor, continent, query, locType string) []LocationMatch {
+	query = strings.ToLower(strings.TrimSpace(query))
+	locType = strings.ToLower(strings.TrimSpace(locType))
+
+	var matches []LocationMatch
+	for _, region := range floor.Regions {
+		for _, m := range region.Maps {
+			for _, loc := range mapLocations(&m, region.Name, continent) {
+				if locType != "" && loc.Type != locType {
+					continue
+				}
+				if loc.Name != "" && strings.Contains(strings.ToLower(loc.Name), query) {
+					matches = append(matches, loc)
+				}
+			}
+		}
+	}
+	sortLocationMatches(matches, query)
+	return matches
+}
+
+// sortLocationMatches orders matches with exact name matches first, then by name and map
+func sortLocationMatches(matches []LocationMatch, query string) {
+	sort.SliceStable(matches, func(i, j int) bool {
+		ei := strings.EqualFold(matches[i].Name, query)
+		ej := strings.EqualFold(matches[j].Name, query)
+		if ei != ej {
+			return ei
+		}
+		if matches[i].Name != matches[j].Name {
+			return matches[i].Name < matches[j].Name
+		}
+		return matches[i].MapID < matches[j].MapID
+	})
+}
+
+// continentNames returns continent names by ID, falling back to an empty map on failure
+func (s *MCPServer) continentNames(ctx context.Context) map[int]string {
+	names := make(map[int]string)
+	continents, err := s.gw2API.GetContinents(ctx)
+	if err != nil {
+		s.logger.Warn("Failed to get continents", "error", err)
+		return names
+	}
+	for _, c := range continents {
+		names[c.ID] = c.Name
+	}
+	return names
+}
+
+// handleFindLocation handles location lookup requests
+func (s *MCPServer) handleFindLocation(ctx context.Context, _ *mcp.CallToolRequest, args FindLocationArgs) (*mcp.CallToolResult, any, error) {
+	if strings.TrimSpace(args.Name) == "" {
+		return errResult("name parameter is required")
+	}
+
+	limit := args.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+
+	s.logger.Debug("Find location request", "name", args.Name, "type", args.Type, "limit", limit)
+
+	result := FindLocationResult{Query: args.Name, Matches: []LocationMatch{}}
+	continents := s.continentNames(ctx)
+	for _, f := range searchedFloors {
+		floor, err := s.gw2API.GetContinentFloor(ctx, f.continent, f.floor)
+		if err != nil {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("continent %d floor %d: %v", f.continent, f.floor, err))
+			continue
+		}
+		result.Matches = append(result.Matches, searchLocations(floor, continents[f.continent], args.Name, args.Type)...)
+	}
+	if len(result.Matches) == 0 && len(result.Skipped) == len(searchedFloors) {
+		return errResult(fmt.Sprintf("Failed to search locations: %s", strings.Join(result.Skipped, "; ")))
+	}
+
+	sortLocationMatches(result.Matches, strings.ToLower(strings.TrimSpace(args.Name)))
+	result.Total = len(result.Matches)
+	if len(result.Matches) > limit {
+		result.Matches = result.Matches[:limit]
+	}
+
+	return jsonResult(result)
+}
+
+// MapObjectives lists the map-completion objectives of a map
+type MapObjectives struct {
+	Waypoints        []LocationMatch         `json:"waypoints"`
+	PointsOfInterest []LocationMatch         `json:"points_of_interest"`
+	Vistas           []LocationMatch         `json:"vistas"`
+	Hearts           []LocationMatch         `json:"hearts"`
+	HeroChallenges   []gw2api.SkillChallenge `json:"hero_challenges"`
+}
+
+// CharacterMapProgress reports a character's hero challenge progress on a map
+type CharacterMapProgress struct {
+	Character             string                  `json:"character"`
+	HeroChallengesDone    int                     `json:"hero_challenges_done"`
+	HeroChallengesMissing []gw2api.SkillChallenge `json:"hero_challenges_missing"`
+	Error                 string                  `json:"error,omitempty"`
+}
+
+// MapCompletionResult is the response for map_completion
+type MapCompletionResult struct {
+	Map            string                 `json:"map"`
+	MapID          int                    `json:"map_id"`
+	Region         string                 `json:"region"`
+	Continent      string                 `json:"continent"`
+	MinLevel       int                    `json:"min_level"`
+	MaxLevel       int                    `json:"max_level"`
+	Totals         map[string]int         `json:"totals"`
+	Objectives     MapObjectives          `json:"objectives"`
+	Characters     []CharacterMapProgress `json:"characters,omitempty"`
+	MapChestOpened bool                   `json:"map_chest_opened_today,omitempty"`
+	Note           string                 `json:"note"`
+	Skipped        []string               `json:"skipped,omitempty"`
+}
+
+// mapObjectives groups a map's completion objectives by kind. Points of interest of type
+// "unlock" are not part of map completion and are left out.
+func mapObjectives(m *gw2api.ContinentMap, region, continent string) MapObjectives {
+	objectives := MapObjectives{
+		Waypoints:        []LocationMatch{},
+		PointsOfInterest: []LocationMatch{},
+		Vistas:           []LocationMatch{},
+		Hearts:           []LocationMatch{},
+		HeroChallenges:   append([]gw2api.SkillChallenge{}, m.SkillChallenges...),
+	}
+	for _, loc := range mapLocations(m, region, continent) {
+		switch loc.Type {
+		case "waypoint":
+			objectives.Waypoints = append(objectives.Waypoints, loc)
+		case "landmark":
+			objectives.PointsOfInterest = append(objectives.PointsOfInterest, loc)
+		case "vista":
+			objectives.Vistas = append(objectives.Vistas, loc)
+		case "heart":
+			objectives.Hearts = append(objectives.Hearts, loc)
+		}
+	}
+	sort.Slice(objectives.HeroChallenges, func(i, j int) bool {
+		return objectives.HeroChallenges[i].ID < objectives.HeroChallenges[j].ID
+	})
+	return objectives
+}
+
+// missingHeroChallenges returns the map's hero challenges not in the character's completed list.
+// Challenges without an ID cannot be tracked and are left out.
+func missingHeroChallenges(challenges []gw2api.SkillChallenge, done []string) (int, []gw2api.SkillChallenge) {
+	doneSet := make(map[string]bool, len(done))
+	for _, id := range done {
+		doneSet[id] = true
+	}
+	completed := 0
+	missing := []gw2api.SkillChallenge{}
+	for _, sc := range challenges {
+		switch {
+		case sc.ID == "":
+		case doneSet[sc.ID]:
+			completed++
+		default:
+			missing = append(missing, sc)
+		}
+	}
+	return completed, missing
+}
+
+// mapChestOpened reports whether a Hero's Choice chest for the named map is among the
+// chests opened today. Chest IDs are derived from map names, e.g. "auric_basin_heros_choice_chest".
+func mapChestOpened(mapName string, chests []string) bool {
+	slug := strings.ToLower(mapName)
+	slug = strings.NewReplacer("'", "", ":", "", "-", "_", " ", "_").Replace(slug)
+	for _, chest := range chests {
+		if strings.HasPrefix(chest, slug+"_heros_choice") {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveMap finds a map by ID or name, returning it with its region and continent names
+func (s *MCPServer) resolveMap(ctx context.Context, query string) (*gw2api.ContinentMap, string, string, error) {
+	continents := s.continentNames(ctx)
+
+	if id, err := strconv.Atoi(strings.TrimSpace(query)); err == nil {
+		maps, err := s.gw2API.GetMaps(ctx, []int{id})
+		if err != nil {
+			return nil, "", "", err
+		}
+		if len(maps) == 0 {
+			return nil, "", "", fmt.Errorf("map ID %d not found", id)
+		}
+		info := maps[0]
+		m, err := s.gw2API.GetContinentMap(ctx, info.ContinentID, info.DefaultFloor, info.RegionID, info.ID)
+		if err != nil {
+			return nil, "", "", err
+		}
+		return m, info.RegionName, info.ContinentName, nil
+	}
+
+	type candidate struct {
+		m                 gw2api.ContinentMap
+		region, continent string
+	}
+	needle := strings.ToLower(strings.TrimSpace(query))
+	var partial []candidate
+	for _, f := range searchedFloors {
+		floor, err := s.gw2API.GetContinentFloor(ctx, f.continent, f.floor)
+		if err != nil {
+			s.logger.Warn("Failed to get continent floor", "continent", f.continent, "floor", f.floor, "error", err)
+			continue
+		}
+		for _, region := range floor.Regions {
+			for _, m := range region.Maps {
+				name := strings.ToLower(m.Name)
+				if name == needle {
+					return &m, region.Name, continents[f.continent], nil
+				}
+				if strings.Contains(name, needle) {
+					partial = append(partial, candidate{m, region.Name, continents[f.continent]})
+				}
+			}
+		}
+	}
+
+	switch len(partial) {
+	case 0:
+		return nil, "", "", fmt.Errorf("no map matches %q", query)
+	case 1:
+		return &partial[0].m, partial[0].region, partial[0].continent, nil
+	}
+	names := make([]string, 0, len(partial))
+	for _, c := range partial {
+		names = append(names, fmt.Sprintf("%s (%d)", c.m.Name, c.m.ID))
+	}
+	sort.Strings(names)
+	return nil, "", "", fmt.Errorf("several maps match %q, use a more specific name or an ID: %s", query, strings.Join(names, "; "))
+}
+
+// handleMapCompletion handles map completion requests
+func (s *MCPServer) handleMapCompletion(ctx context.Context, _ *mcp.CallToolRequest, args MapCompletionArgs) (*mcp.CallToolResult, any, error) {
+	if strings.TrimSpace(args.Map) == "" {
+		return errResult("map parameter is required")
+	}
+
+	s.logger.Debug("Map completion request", "map", args.Map, "character", args.Character)
+
+	m, region, continent, err := s.resolveMap(ctx, args.Map)
+	if err != nil {
+		return apiErrResult("Failed to find map", err)
+	}
+
+	result := MapCompletionResult{
+		Map:        m.Name,
+		MapID:      m.ID,
+		Region:     region,
+		Continent:  continent,
+		MinLevel:   m.MinLevel,
+		MaxLevel:   m.MaxLevel,
+		Objectives: mapObjectives(m, region, continent),
+		Note:       "The GW2 API only exposes per-character hero challenge progress; check waypoints, points of interest, vistas and hearts in game.",
+	}
+	result.Totals = map[string]int{
+		"waypoints":          len(result.Objectives.Waypoints),
+		"points_of_interest": len(result.Objectives.PointsOfInterest),
+		"vistas":             len(result.Objectives.Vistas),
+		"hearts":             len(result.Objectives.Hearts),
+		"hero_challenges":    len(result.Objectives.HeroChallenges),
+	}
+
+	if s.gw2API.APIKey() == "" {
+		return jsonResult(result)
+	}
+
+	characters := []string{args.Character}
+	if strings.TrimSpace(args.Character) == "" {
+		names, err := s.gw2API.GetCharacters(ctx)
+		if err != nil {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("characters: %v", err))
+			names = nil
+		}
+		characters = names
+	}
+	for _, name := range characters {
+		progress := CharacterMapProgress{Character: name}
+		done, err := s.gw2API.GetCharacterHeroPoints(ctx, name)
+		if err != nil {
+			progress.Error = err.Error()
+		} else {
+			progress.HeroChallengesDone, progress.HeroChallengesMissing = missingHeroChallenges(m.SkillChallenges, done)
+		}
+		result.Characters = append(result.Characters, progress)
+	}
+
+	if chests, err := s.gw2API.GetAccountMapChests(ctx); err != nil {
+		result.Skipped = append(result.Skipped, fmt.Sprintf("map chests: %v", err))
+	} else {
+		result.MapChestOpened = mapChestOpened(m.Name, chests)
+	}
+
+	return jsonResult(result)
+}
+
+// UpcomingEvent is a single upcoming or running event spawn
+type UpcomingEvent struct {
+	ID              string    `json:"id"`
+	Name            string    `json:"name"`
+	Map             string    `json:"map"`
+	Category        string    `json:"category"`
+	Start           time.Time `json:"start"`
+	End             time.Time `json:"end"`
+	Active          bool      `json:"active"`
+	StartsInMinutes int       `json:"starts_in_minutes"`
+	Countdown       string    `json:"countdown"`
+	CompletedToday  bool      `json:"completed_today,omitempty"`
+}
+
+// UpcomingEventsResult is the response for get_upcoming_events
+type UpcomingEventsResult struct {
+	Now              time.Time       `json:"now"`
+	TimetableVersion string          `json:"timetable_version"`
+	Events           []UpcomingEvent `json:"events"`
+	Skipped          []string        `json:"skipped,omitempty"`
+}
+
+// formatCountdown renders a duration as hours and minutes, e.g. "1h 05m" or "12m"
+func formatCountdown(d time.Duration) string {
+	minutes := int(d.Round(time.Minute).Minutes())
+	if minutes < 0 {
+		minutes = 0
+	}
+	if minutes >= 60 {
+		return fmt.Sprintf("%dh %02dm", minutes/60, minutes%60)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
+
+// eventCompleted reports whether an event's world boss or map chest is in the completed sets
+func eventCompleted(e schedule.Event, bosses, chests map[string]bool) bool {
+	return (e.WorldBossID != "" && bosses[e.WorldBossID]) || (e.MapChestID != "" && chests[e.MapChestID])
+}
+
+// toUpcomingEvent converts a timetable occurrence into its response form. Completion only
+// applies to spawns before the next daily reset.
+func toUpcomingEvent(o schedule.Occurrence, now time.Time, bosses, chests map[string]bool) UpcomingEvent {
+	event := UpcomingEvent{
+		ID:       o.ID,
+		Name:     o.Name,
+		Map:      o.Map,
+		Category: o.Category,
+		Start:    o.Start,
+		End:      o.End,
+		Active:   o.Active,
+	}
+	if o.Active {
+		event.Countdown = "active, ends in " + formatCountdown(o.End.Sub(now))
+	} else {
+		event.StartsInMinutes = int(o.Start.Sub(now).Round(time.Minute).Minutes())
+		event.Countdown = "in " + formatCountdown(o.Start.Sub(now))
+	}
+
+	event.CompletedToday = o.Start.Before(schedule.NextDailyReset(now)) && eventCompleted(o.Event, bosses, chests)
+	return event
+}
+
+// completedDailies returns the set of daily completion IDs for a type such as "worldbosses"
+func (s *MCPServer) completedDailies(ctx context.Context, dailyType string) (map[string]bool, error) {
+	dailies, err := s.gw2API.GetAccountDailies(ctx, dailyType)
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[string]bool, len(dailies.Completed))
+	for _, id := range dailies.Completed {
+		set[id] = true
+	}
+	return set, nil
+}
+
+// handleGetUpcomingEvents handles upcoming event schedule requests
+func (s *MCPServer) handleGetUpcomingEvents(ctx context.Context, _ *mcp.CallToolRequest, args GetUpcomingEventsArgs) (*mcp.CallToolResult, any, error) {
+	limit := args.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	category := strings.ToLower(strings.TrimSpace(args.Category))
+	if category != "" && category != schedule.CategoryWorldBoss && category != schedule.CategoryMeta {
+		return errResult(fmt.Sprintf("invalid category %q: must be %s or %s", args.Category, schedule.CategoryWorldBoss, schedule.CategoryMeta))
+	}
+	mapFilter := strings.ToLower(strings.TrimSpace(args.Map))
+
+	s.logger.Debug("Upcoming events request", "limit", limit, "category", category, "map", mapFilter)
+
+	now := time.Now().UTC()
+	result := UpcomingEventsResult{Now: now, TimetableVersion: s.events.Version, Events: []UpcomingEvent{}}
+
+	var bosses, chests map[string]bool
+	if s.gw2API.APIKey() != "" {
+		var err error
+		if bosses, err = s.completedDailies(ctx, "worldbosses"); err != nil {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("world bosses: %v", err))
+		}
+		if chests, err = s.completedDailies(ctx, "mapchests"); err != nil {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("map chests: %v", err))
+		}
+	} else if args.ExcludeCompleted {
+		result.Skipped = append(result.Skipped, "completion filter: GW2_API_KEY environment variable not configured")
+	}
+
+	keep := func(e schedule.Event) bool {
+		if category != "" && e.Category != category {
+			return false
+		}
+		return mapFilter == "" || strings.Contains(strings.ToLower(e.Map), mapFilter)
+	}
+
+	// Fetch every remaining spawn so completed ones can be dropped before applying the limit
+	for _, o := range s.events.Upcoming(now, 0, keep) {
+		event := toUpcomingEvent(o, now, bosses, chests)
+		if args.ExcludeCompleted && event.CompletedToday {
+			continue
+		}
+		result.Events = append(result.Events, event)
+		if len(result.Events) == limit {
+			break
+		}
+	}
+
+	return jsonResult(result)
+}
+
+// ChecklistItem is a single resettable task and whether it is done
+type ChecklistItem struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Done   bool   `json:"done"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// ChecklistSection groups the tasks of one kind that share a reset
+type ChecklistSection struct {
+	Name  string          `json:"name"`
+	Reset string          `json:"reset"`
+	Done  int             `json:"done"`
+	Total int             `json:"total"`
+	Items []ChecklistItem `json:"items"`
+	Error string          `json:"error,omitempty"`
+}
+
+// ResetChecklistResult is the response for reset_checklist
+type ResetChecklistResult struct {
+	Now           time.Time          `json:"now"`
+	DailyReset    time.Time          `json:"daily_reset"`
+	DailyResetIn  string             `json:"daily_reset_in"`
+	WeeklyReset   time.Time          `json:"weekly_reset"`
+	WeeklyResetIn string             `json:"weekly_reset_in"`
+	Sections      []ChecklistSection `json:"sections"`
+}
+
+// humanizeID turns an API identifier such as "charged_quartz_crystal" into "Charged Quartz Crystal"
+func humanizeID(id string) string {
+	words := strings.Fields(strings.ReplaceAll(id, "_", " "))
+	for i, w := range words {
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// newChecklistSection builds a section and counts its completed items
+func newChecklistSection(name, reset string, items []ChecklistItem) ChecklistSection {
+	section := ChecklistSection{Name: name, Reset: reset, Items: items, Total: len(items)}
+	if section.Items == nil {
+		section.Items = []ChecklistItem{}
+	}
+	for _, item := range items {
+		if item.Done {
+			section.Done++
+		}
+	}
+	return section
+}
+
+// checklistFromIDs marks every possible entry as done or not from the completed set
+func checklistFromIDs(all []string, done map[string]bool) []ChecklistItem {
+	items := make([]ChecklistItem, 0, len(all))
+	for _, id := range all {
+		items = append(items, ChecklistItem{ID: id, Name: humanizeID(id), Done: done[id]})
+	}
+	return items
+}
+
+// wizardsVaultChecklist converts Wizard's Vault account objectives into checklist items,
+// followed by the meta reward for completing enough of them
+func wizardsVaultChecklist(progress *gw2api.WizardsVaultProgress) []ChecklistItem {
+	items := make([]ChecklistItem, 0, len(progress.Objectives)+1)
+	for _, o := range progress.Objectives {
+		detail := fmt.Sprintf("%s, %d/%d, %d acclaim", o.Track, o.ProgressCurrent, o.ProgressComplete, o.Acclaim)
+		if o.Done() && !o.Claimed {
+			detail += ", not claimed"
+		}
+		items = append(items, ChecklistItem{
+			ID:     strconv.Itoa(o.ID),
+			Name:   o.Title,
+			Done:   o.Done(),
+			Detail: detail,
+		})
+	}
+	if progress.MetaProgressComplete > 0 {
+		items = append(items, ChecklistItem{
+			ID:     "meta",
+			Name:   "Meta reward",
+			Done:   progress.MetaRewardClaimed,
+			Detail: fmt.Sprintf("%d/%d objectives", progress.MetaProgressCurrent, progress.MetaProgressComplete),
+		})
+	}
+	return items
+}
+
+// dungeonChecklist lists every dungeon path, marked done when completed since daily reset
+func dungeonChecklist(dungeons []gw2api.Dungeon, done map[string]bool) []ChecklistItem {
+	var items []ChecklistItem
+	for _, d := range dungeons {
+		for _, p := range d.Paths {
+			items = append(items, ChecklistItem{
+				ID:     p.ID,
+				Name:   humanizeID(d.ID) + ": " + humanizeID(p.ID),
+				Done:   done[p.ID],
+				Detail: p.Type,
+			})
+		}
+	}
+	return items
+}
+
+// raidChecklist lists every raid encounter, marked done when cleared since weekly reset
+func raidChecklist(raids []gw2api.Raid, done map[string]bool) []ChecklistItem {
+	var items []ChecklistItem
+	for _, r := range raids {
+		for _, w := range r.Wings {
+			for _, e := range w.Events {
+				items = append(items, ChecklistItem{
+					ID:     e.ID,
+					Name:   humanizeID(e.ID),
+					Done:   done[e.ID],
+					Detail: humanizeID(r.ID) + ", " + humanizeID(w.ID),
+				})
+			}
+		}
+	}
+	return items
+}
+
+// handleResetChecklist handles daily and weekly reset checklist requests
+func (s *MCPServer) handleResetChecklist(ctx context.Context, _ *mcp.CallToolRequest, _ struct{}) (*mcp.CallToolResult, any, error) {
+	if s.gw2API.APIKey() == "" {
+		return errResult("Failed to build reset checklist: GW2_API_KEY environment variable not configured")
+	}
+
+	s.logger.Debug("Reset checklist request")
+
+	now := time.Now().UTC()
+	daily := schedule.NextDailyReset(now)
+	weekly := schedule.NextWeeklyReset(now)
+	result := ResetChecklistResult{
+		Now:           now,
+		DailyReset:    daily,
+		DailyResetIn:  formatCountdown(daily.Sub(now)),
+		WeeklyReset:   weekly,
+		WeeklyResetIn: formatCountdown(weekly.Sub(now)),
+	}
+
+	// Wizard's Vault objectives
+	for _, period := range []string{"daily", "weekly"} {
+		name := "wizards_vault_" + period
+		progress, err := s.gw2API.GetWizardsVaultObjectives(ctx, period)
+		if err != nil {
+			result.Sections = append(result.Sections, ChecklistSection{Name: name, Reset: period, Items: []ChecklistItem{}, Error: err.Error()})
+			continue
+		}
+		result.Sections = append(result.Sections, newChecklistSection(name, period, wizardsVaultChecklist(progress)))
+	}
+
+	// Daily crafting, map chests and world bosses compared against every possible entry
+	for _, dailyType := range []string{"dailycrafting", "mapchests", "worldbosses"} {
+		all, err := s.gw2API.GetDailyCatalog(ctx, dailyType)
+		if err == nil {
+			var done map[string]bool
+			if done, err = s.completedDailies(ctx, dailyType); err == nil {
+				result.Sections = append(result.Sections, newChecklistSection(dailyType, "daily", checklistFromIDs(all, done)))
+				continue
+			}
+		}
+		result.Sections = append(result.Sections, ChecklistSection{Name: dailyType, Reset: "daily", Items: []ChecklistItem{}, Error: err.Error()})
+	}
+
+	// Dungeon paths (daily)
+	dungeons, err := s.gw2API.GetDungeons(ctx)
+	if err == nil {
+		var done map[string]bool
+		if done, err = s.completedDailies(ctx, "dungeons"); err == nil {
+			result.Sections = append(result.Sections, newChecklistSection("dungeons", "daily", dungeonChecklist(dungeons, done)))
+		}
+	}
+	if err != nil {
+		result.Sections = append(result.Sections, ChecklistSection{Name: "dungeons", Reset: "daily", Items: []ChecklistItem{}, Error: err.Error()})
+	}
+
+	// Raid encounters (weekly)
+	raids, err := s.gw2API.GetRaids(ctx)
+	if err == nil {
+		var done map[string]bool
+		if done, err = s.completedDailies(ctx, "raids"); err == nil {
+			result.Sections = append(result.Sections, newChecklistSection("raids", "weekly", raidChecklist(raids, done)))
+		}
+	}
+	if err != nil {
+		result.Sections = append(result.Sections, ChecklistSection{Name: "raids", Reset: "weekly", Items: []ChecklistItem{}, Error: err.Error()})
+	}
+
+	return jsonResult(result)
+}
+
+// raidEventBoss is the raid event type that awards weekly loot; checkpoints do not
+const raidEventBoss = "Boss"
+
+// EncounterStatus is a raid encounter and whether it was cleared this week
+type EncounterStatus struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Cleared bool   `json:"cleared"`
+}
+
+// RaidWingStatus is a raid wing with its cleared encounter count
+type RaidWingStatus struct {
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	Cleared    int               `json:"cleared"`
+	Total      int               `json:"total"`
+	Encounters []EncounterStatus `json:"encounters"`
+}
+
+// RaidStatus is a raid with the clear status of each wing
+type RaidStatus struct {
+	ID    string           `json:"id"`
+	Name  string           `json:"name"`
+	Wings []RaidWingStatus `json:"wings"`
+}
+
+// DungeonPathStatus is a dungeon path and whether it was completed today
+type DungeonPathStatus struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Done bool   `json:"done"`
+}
+
+// DungeonStatus is a dungeon with the completion status of each path
+type DungeonStatus struct {
+	ID    string              `json:"id"`
+	Name  string              `json:"name"`
+	Paths []DungeonPathStatus `json:"paths"`
+}
+
+// RemainingRewards counts the reward opportunities still open before the next reset
+type RemainingRewards struct {
+	RaidBosses        int `json:"raid_bosses"`
+	RaidBossesTotal   int `json:"raid_bosses_total"`
+	StrikeMissions    int `json:"strike_missions"`
+	DungeonPaths      int `json:"dungeon_paths"`
+	DungeonPathsTotal int `json:"dungeon_paths_total"`
+}
+
+// RaidClearsResult is the response for raid_clears
+type RaidClearsResult struct {
+	WeeklyReset    time.Time                `json:"weekly_reset"`
+	WeeklyResetIn  string                   `json:"weekly_reset_in"`
+	DailyReset     time.Time                `json:"daily_reset"`
+	DailyResetIn   string                   `json:"daily_reset_in"`
+	Raids          []RaidStatus             `json:"raids"`
+	StrikeMissions []schedule.StrikeMission `json:"strike_missions"`
+	Dungeons       []DungeonStatus          `json:"dungeons"`
+	Remaining      RemainingRewards         `json:"remaining"`
+	Notes          []string                 `json:"notes,omitempty"`
+	Skipped        []string                 `json:"skipped,omitempty"`
+}
+
+// buildRaidStatus marks every raid encounter as cleared or not and counts the
+// bosses still awarding loot this week
+func buildRaidStatus(raids []gw2api.Raid, cleared map[string]bool) (status []RaidStatus, remaining, total int) {
+	status = make([]RaidStatus, 0, len(raids))
+	for _, r := range raids {
+		raid := RaidStatus{ID: r.ID, Name: humanizeID(r.ID), Wings: make([]RaidWingStatus, 0, len(r.Wings))}
+		for _, w := range r.Wings {
+			wing := RaidWingStatus{ID: w.ID, Name: humanizeID(w.ID), Total: len(w.Events), Encounters: make([]EncounterStatus, 0, len(w.Events))}
+			for _, e := range w.Events {
+				done := cleared[e.ID]
+				wing.Encounters = append(wing.Encounters, EncounterStatus{ID: e.ID, Name: humanizeID(e.ID), Type: e.Type, Cleared: done})
+				if done {
+					wing.Cleared++
+				}
+				if e.Type == raidEventBoss {
+					total++
+					if !done {
+						remaining++
+					}
+				}
+			}
+			raid.Wings = append(raid.Wings, wing)
+		}
+		status = append(status, raid)
+	}
+	return status, remaining, total
+}
+
+// buildDungeonStatus marks every dungeon path as done or not and counts the paths
+// still awarding their daily bonus
+func buildDungeonStatus(dungeons []gw2api.Dungeon, done map[string]bool) (status []DungeonStatus, remaining, total int) {
+	status = make([]DungeonStatus, 0, len(dungeons))
+	for _, d := range dungeons {
+		dungeon := DungeonStatus{ID: d.ID, Name: humanizeID(d.ID), Paths: make([]DungeonPathStatus, 0, len(d.Paths))}
+		for _, p := range d.Paths {
+			dungeon.Paths = append(dungeon.Paths, DungeonPathStatus{ID: p.ID, Name: humanizeID(p.ID), Type: p.Type, Done: done[p.ID]})
+			total++
+			if !done[p.ID] {
+				remaining++
+			}
+		}
+		status = append(status, dungeon)
+	}
+	return status, remaining, total
+}
+
+// handleRaidClears handles weekly raid, strike and dungeon clear requests
+func (s *MCPServer) handleRaidClears(ctx context.Context, _ *mcp.CallToolRequest, _ struct{}) (*mcp.CallToolResult, any, error) {
+	if s.gw2API.APIKey() == "" {
+		return errResult("Failed to get raid clears: GW2_API_KEY environment variable not configured")
+	}
+
+	s.logger.Debug("Raid clears request")
+
+	raids, err := s.gw2API.GetRaids(ctx)
+	if err != nil {
+		return apiErrResult("Failed to get raids", err)
+	}
+	raidClears, err := s.completedDailies(ctx, "raids")
+	if err != nil {
+		return apiErrResult("Failed to get raid clears", err)
+	}
+
+	now := time.Now().UTC()
+	daily := schedule.NextDailyReset(now)
+	weekly := schedule.NextWeeklyReset(now)
+	result := RaidClearsResult{
+		WeeklyReset:    weekly,
+		WeeklyResetIn:  formatCountdown(weekly.Sub(now)),
+		DailyReset:     daily,
+		DailyResetIn:   formatCountdown(daily.Sub(now)),
+		StrikeMissions: schedule.StrikeMissions,
+		Dungeons:       []DungeonStatus{},
+		Notes: []string{
+			"The GW2 API does not report strike mission clears; every strike mission is listed as a possible remaining reward.",
+			"Raid encounters reset weekly; dungeon paths reset daily.",
+		},
+	}
+	result.Raids, result.Remaining.RaidBosses, result.Remaining.RaidBossesTotal = buildRaidStatus(raids, raidClears)
+	result.Remaining.StrikeMissions = len(schedule.StrikeMissions)
+
+	dungeons, err := s.gw2API.GetDungeons(ctx)
+	if err == nil {
+		var done map[string]bool
+		if done, err = s.completedDailies(ctx, "dungeons"); err == nil {
+			result.Dungeons, result.Remaining.DungeonPaths, result.Remaining.DungeonPathsTotal = buildDungeonStatus(dungeons, done)
+		}
+	}
+	if err != nil {
+		result.Skipped = append(result.Skipped, fmt.Sprintf("dungeons: %v", err))
+	}
+
+	return jsonResult(result)
+}
+
+// Daily fractal rotation sources reported by get_daily_fractals
+const (
+	fractalSourceBundled = "bundled"
+	fractalSourceLearned = "learned"
+	fractalSourceAPI     = "api"
+	fractalSourceNone    = "none"
+)
+
+// learnedFractalsFile is the name the daily fractals learned from the API are saved under
+// in the data directory
+const learnedFractalsFile = "fractal-days"
+
+// dailyFractalsCategory is the achievement category holding the rotating daily fractals
+const dailyFractalsCategory = "Daily Fractals"
+
+// FractalInstabilities lists the Mistlock Instabilities active at a fractal level
+type FractalInstabilities struct {
+	Level         int      `json:"level"`
+	Instabilities []string `json:"instabilities"`
+}
+
+// DailyFractalsResult is the response for get_daily_fractals
+type DailyFractalsResult struct {
+	Date            string                 `json:"date"`
+	Source          string                 `json:"source"`
+	RotationVersion string                 `json:"rotation_version"`
+	CycleDay        int                    `json:"cycle_day"`
+	Tier4           []string               `json:"tier4"`
+	Recommended     []int                  `json:"recommended"`
+	Instabilities   []FractalInstabilities `json:"instabilities"`
+	NextReset       time.Time              `json:"next_reset"`
+	NextResetIn     string                 `json:"next_reset_in"`
+	Notes           []string               `json:"notes,omitempty"`
+}
+
+// dailyFractalsFromAchievements extracts the Tier 4 fractals and recommended scales from
+// daily fractal achievement names; lower tiers repeat the Tier 4 fractals and are skipped
+func dailyFractalsFromAchievements(names []string) (tier4 []string, recommended []int) {
+	tier4, recommended = []string{}, []int{}
+	for _, name := range names {
+		tier, fractal, scale, ok := fractals.ParseDailyAchievement(name)
+		switch {
+		case !ok:
+		case tier == 4:
+			tier4 = append(tier4, fractal)
+		case scale > 0:
+			recommended = append(recommended, scale)
+		}
+	}
+	sort.Ints(recommended)
+	return tier4, recommended
+}
+
+// dayInstabilities lists the scheduled instabilities for a day, optionally for one level only
+func dayInstabilities(day fractals.Day, level int) []FractalInstabilities {
+	result := []FractalInstabilities{}
+	for _, l := range day.Levels() {
+		if level > 0 && l != level {
+			continue
+		}
+		result = append(result, FractalInstabilities{Level: l, Instabilities: day.Instabilities[l]})
+	}
+	return result
+}
+
+// apiDailyFractals reads today's daily fractals from the Daily Fractals achievement category
+func (s *MCPServer) apiDailyFractals(ctx context.Context) (tier4 []string, recommended []int, err error) {
+	categories, err := s.gw2API.GetAchievementCategories(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	matched := matchAchievementCategories(categories, dailyFractalsCategory)
+	if len(matched) != 1 {
+		return nil, nil, fmt.Errorf("achievement category %q not found", dailyFractalsCategory)
+	}
+
+	category, err := s.gw2API.GetAchievementCategory(ctx, matched[0].ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	achievements, err := s.gw2API.GetAchievements(ctx, category.Achievements)
+	if err != nil {
+		return nil, nil, err
+	}
+	names := make([]string, 0, len(achievements))
+	for _, ach := range achievements {
+		names = append(names, ach.Name)
+	}
+	tier4, recommended = dailyFractalsFromAchievements(names)
+	return tier4, recommended, nil
+}
+
+// handleGetDailyFractals handles daily fractal rotation requests
+func (s *MCPServer) handleGetDailyFractals(ctx context.Context, _ *mcp.CallToolRequest, args GetDailyFractalsArgs) (*mcp.CallToolResult, any, error) {
+	now := time.Now().UTC()
+	date := now
+	if args.Date != "" {
+		parsed, err := time.Parse(fractals.DateLayout, args.Date)
+		if err != nil {
+			return errResult(fmt.Sprintf("Invalid date %q: expected YYYY-MM-DD", args.Date))
+		}
+		date = parsed
+	}
+	if args.Level < 0 || args.Level > fractals.MaxTier4Level {
+		return errResult(fmt.Sprintf("Invalid fractal level %d: must be 1 to %d", args.Level, fractals.MaxTier4Level))
+	}
+
+	s.logger.Debug("Daily fractals request", "date", date.Format(fractals.DateLayout), "level", args.Level)
+
+	reset := schedule.NextDailyReset(now)
+	result := DailyFractalsResult{
+		Date:            date.Format(fractals.DateLayout),
+		Source:          fractalSourceNone,
+		RotationVersion: s.fractals.Version,
+		Tier4:           []string{},
+		Recommended:     []int{},
+		Instabilities:   []FractalInstabilities{},
+		NextReset:       reset,
+		NextResetIn:     formatCountdown(reset.Sub(now)),
+	}
+
+	// Today's fractals come from the API, which also teaches the cycle that day
+	day, _ := s.fractals.Day(date)
+	result.CycleDay = day.Position + 1
+	result.Instabilities = dayInstabilities(day, args.Level)
+	if result.Date == now.Format(fractals.DateLayout) {
+		tier4, recommended, err := s.apiDailyFractals(ctx)
+		if err == nil && (len(tier4) > 0 || len(recommended) > 0) {
+			result.Source = fractalSourceAPI
+			result.Tier4 = tier4
+			result.Recommended = recommended
+			s.learnDailyFractals(now, fractals.CycleDay{Tier4: tier4, Recommended: recommended})
+		} else if err != nil {
+			result.Notes = append(result.Notes, fmt.Sprintf("Failed to read daily fractal achievements: %v", err))
+		}
+	}
+
+	if result.Source == fractalSourceNone {
+		switch {
+		case day.Learned:
+			result.Source = fractalSourceLearned
+		case day.Known():
+			result.Source = fractalSourceBundled
+		default:
+			result.Notes = append(result.Notes, fmt.Sprintf("Day %d of the %d-day fractal cycle is not in rotation version %s and has not been read from the API yet; each day the tool runs, it learns that day's fractals.",
+				result.CycleDay, fractals.CycleLength, s.fractals.Version))
+		}
+		result.Tier4 = append(result.Tier4, day.Tier4...)
+		result.Recommended = append(result.Recommended, day.Recommended...)
+	}
+	if len(result.Instabilities) == 0 {
+		when := result.Date
+		if args.Level > 0 {
+			when = fmt.Sprintf("level %d on %s", args.Level, result.Date)
+		}
+		result.Notes = append(result.Notes, fmt.Sprintf("The instability schedule in rotation version %s has no instabilities for %s; the GW2 API does not report them.", s.fractals.Version, when))
+	}
+	return jsonResult(result)
+}
+
+// learnDailyFractals records a day's fractals read from the API and saves the days
+// learned so far to the data directory
+func (s *MCPServer) learnDailyFractals(t time.Time, day fractals.CycleDay) {
+	if !s.fractals.Learn(t, day) {
+		return
+	}
+	if err := s.store.Save(learnedFractalsFile, s.fractals.Learned()); err != nil {
+		s.logger.Warn("Failed to save learned daily fractals", "error", err)
+	}
+}
+
+// MasteryTrackProgress is a mastery track joined with the account's progress
+type MasteryTrackProgress struct {
+	ID            int      `json:"id"`
+	Name          string   `json:"name"`
+	Region        string   `json:"region"`
+	Level         int      `json:"level"`
+	MaxLevel      int      `json:"max_level"`
+	Unlocked      []string `json:"unlocked"`
+	NextLevel     string   `json:"next_level,omitempty"`
+	NextPointCost int      `json:"next_point_cost,omitempty"`
+}
+
+// FractalProgressResult is the response for fractal_progress
+type FractalProgressResult struct {
+	FractalLevel int                       `json:"fractal_level"`
+	Masteries    []MasteryTrackProgress    `json:"masteries"`
+	Achievements AchievementProgressResult `json:"achievements"`
+	Skipped      []string                  `json:"skipped,omitempty"`
+}
+
+// newMasteryTrackProgress joins a mastery track with the account's progress in it
+func newMasteryTrackProgress(m gw2api.Mastery, progress *gw2api.AccountMastery) MasteryTrackProgress {
+	track := MasteryTrackProgress{
+		ID:       m.ID,
+		Name:     m.Name,
+		Region:   m.Region,
+		MaxLevel: len(m.Levels),
+		Unlocked: []string{},
+	}
+	if progress != nil {
+		// The account level is the 0-based index of the highest unlocked level
+		track.Level = min(progress.Level+1, len(m.Levels))
+	}
+	for _, level := range m.Levels[:track.Level] {
+		track.Unlocked = append(track.Unlocked, level.Name)
+	}
+	if track.Level < len(m.Levels) {
+		next := m.Levels[track.Level]
+		track.NextLevel = next.Name
+		track.NextPointCost = next.PointCost
+	}
+	return track
+}
+
+// fractalCategories returns the achievement categories about fractals: those named for
+// fractals and every category in a fractal achievement group
+func fractalCategories(groups []gw2api.AchievementGroup, categories []gw2api.AchievementCategory) []gw2api.AchievementCategory {
+	inGroup := make(map[int]bool)
+	for _, g := range groups {
+		if strings.Contains(strings.ToLower(g.Name), "fractal") {
+			for _, id := range g.Categories {
+				inGroup[id] = true
+			}
+		}
+	}
+
+	var matched []gw2api.AchievementCategory
+	for _, c := range categories {
+		if inGroup[c.ID] || strings.Contains(strings.ToLower(c.Name), "fractal") {
+			matched = append(matched, c)
+		}
+	}
+	return matched
+}
+
+// handleFractalProgress handles fractal achievement and mastery progress requests
+func (s *MCPServer) handleFractalProgress(ctx context.Context, _ *mcp.CallToolRequest, args FractalProgressArgs) (*mcp.CallToolResult, any, error) {
+	if s.gw2API.APIKey() == "" {
+		return errResult("Failed to get fractal progress: GW2_API_KEY environment variable not configured")
+	}
+
+	limit := args.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+
+	s.logger.Debug("Fractal progress request", "limit", limit)
+
+	account, err := s.gw2API.GetAccount(ctx)
+	if err != nil {
+		return apiErrResult("Failed to get account", err)
+	}
+	result := FractalProgressResult{FractalLevel: account.FractalLevel, Masteries: []MasteryTrackProgress{}}
+
+	// Fractal mastery tracks
+	masteries, err := s.gw2API.GetMasteries(ctx)
+	if err == nil {
+		var accountMasteries []gw2api.AccountMastery
+		if accountMasteries, err = s.gw2API.GetAccountMasteries(ctx); err == nil {
+			progress := make(map[int]*gw2api.AccountMastery, len(accountMasteries))
+			for i := range accountMasteries {
+				progress[accountMasteries[i].ID] = &accountMasteries[i]
+			}
+			for _, m := range masteries {
+				if strings.Contains(strings.ToLower(m.Name), "fractal") {
+					result.Masteries = append(result.Masteries, newMasteryTrackProgress(m, progress[m.ID]))
+				}
+			}
+		}
+	}
+	if err != nil {
+		result.Skipped = append(result.Skipped, fmt.Sprintf("masteries: %v", err))
+	}
+
+	// Fractal achievements
+	categories, err := s.gw2API.GetAchievementCategories(ctx)
+	if err != nil {
+		return apiErrResult("Failed to get achievement categories", err)
+	}
+	groups, err := s.gw2API.GetAchievementGroups(ctx)
+	if err != nil {
+		return apiErrResult("Failed to get achievement groups", err)
+	}
+	categories = fractalCategories(groups, categories)
+
+	var ids []int
+	for _, c := range categories {
+		ids = append(ids, c.Achievements...)
+	}
+	defs, err := s.gw2API.GetAchievements(ctx, ids)
+	if err != nil {
+		return apiErrResult("Failed to get achievements", err)
+	}
+	achievements := make(map[int]gw2api.Achievement, len(defs))
+	for _, ach := range defs {
+		achievements[ach.ID] = ach
+	}
+
+	accountProgress, err := s.gw2API.GetAccountAchievements(ctx)
+	if err != nil {
+		return apiErrResult("Failed to get account achievements", err)
+	}
+	progress := make(map[int]*gw2api.AccountAchievement, len(accountProgress))
+	for i := range accountProgress {
+		progress[accountProgress[i].ID] = &accountProgress[i]
+	}
+
+	result.Achievements = buildAchievementProgress(groups, categories, achievements, progress, false, limit)
+	return jsonResult(result)
+}
+
+// masteryRegions maps the region names used by /v2/masteries to the names used by
+// /v2/account/mastery/points, in release order. Unknown regions keep their own name.
+var masteryRegions = []struct{ mastery, points string }{
+	{"Tyria", "Central Tyria"},
+	{"Maguuma", "Heart of Thorns"},
+	{"Desert", "Path of Fire"},
+	{"Tundra", "Icebrood Saga"},
+	{"Jade", "End of Dragons"},
+	{"Sky", "Secrets of the Obscure"},
+	{"Wild", "Janthir Wilds"},
+}
+
+// masteryRegionName returns the display name for a mastery or mastery point region
+func masteryRegionName(region string) string {
+	for _, r := range masteryRegions {
+		if strings.EqualFold(region, r.mastery) || strings.EqualFold(region, r.points) {
+			return r.points
+		}
+	}
+	return region
+}
+
+// masteryRegionOrder sorts known regions in release order ahead of unknown ones
+func masteryRegionOrder(name string) int {
+	for i, r := range masteryRegions {
+		if r.points == name {
+			return i
+		}
+	}
+	return len(masteryRegions)
+}
+
+// MasteryRegionStatus is a mastery region's tracks and point totals
+type MasteryRegionStatus struct {
+	Region           string                 `json:"region"`
+	Earned           int                    `json:"points_earned"`
+	Spent            int                    `json:"points_spent"`
+	Unspent          int                    `json:"points_unspent"`
+	PointsToComplete int                    `json:"points_to_complete"`
+	Tracks           []MasteryTrackProgress `json:"tracks"`
+}
+
+// MasteryStatusResult is the response for mastery_status
+type MasteryStatusResult struct {
+	Earned  int                   `json:"points_earned"`
+	Spent   int                   `json:"points_spent"`
+	Unspent int                   `json:"points_unspent"`
+	Regions []MasteryRegionStatus `json:"regions"`
+}
+
+// buildMasteryStatus groups mastery tracks by region with the account's progress and
+// point totals. A non-empty filter keeps only regions whose name contains it.
+func buildMasteryStatus(masteries []gw2api.Mastery, progress map[int]*gw2api.AccountMastery,
+	totals []gw2api.MasteryPointTotal, filter string,
+) MasteryStatusResult {
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	regions := make(map[string]*MasteryRegionStatus)
+	region := func(name string) *MasteryRegionStatus {
+		name = masteryRegionName(name)
+		if regions[name] == nil {
+			regions[name] = &MasteryRegionStatus{Region: name, Tracks: []MasteryTrackProgress{}}
+		}
+		return regions[name]
+	}
+
+	sorted := append([]gw2api.Mastery(nil), masteries...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Order < sorted[j].Order })
+	for _, m := range sorted {
+		r := region(m.Region)
+		track := newMasteryTrackProgress(m, progress[m.ID])
+		for _, level := range m.Levels[track.Level:] {
+			r.PointsToComplete += level.PointCost
+		}
+		r.Tracks = append(r.Tracks, track)
+	}
+	for _, t := range totals {
+		r := region(t.Region)
+		r.Earned += t.Earned
+		r.Spent += t.Spent
+		r.Unspent = r.Earned - r.Spent
+	}
+
+	result := MasteryStatusResult{Regions: []MasteryRegionStatus{}}
+	for name, r := range regions {
+		if filter != "" && !strings.Contains(strings.ToLower(name), filter) {
+			continue
+		}
+		result.Earned += r.Earned
+		result.Spent += r.Spent
+		result.Regions = append(result.Regions, *r)
+	}
+	result.Unspent = result.Earned - result.Spent
+	sort.Slice(result.Regions, func(i, j int) bool {
+		oi, oj := masteryRegionOrder(result.Regions[i].Region), masteryRegionOrder(result.Regions[j].Region)
+		if oi != oj {
+			return oi < oj
+		}
+		return result.Regions[i].Region < result.Regions[j].Region
+	})
+	return result
+}
+
+// handleMasteryStatus handles mastery track and point status requests
+func (s *MCPServer) handleMasteryStatus(ctx context.Context, _ *mcp.CallToolRequest, args MasteryStatusArgs) (*mcp.CallToolResult, any, error) {
+	if s.gw2API.APIKey() == "" {
+		return errResult("Failed to get mastery status: GW2_API_KEY environment variable not configured")
+	}
+
+	s.logger.Debug("Mastery status request", "region", args.Region)
+
+	masteries, err := s.gw2API.GetMasteries(ctx)
+	if err != nil {
+		return apiErrResult("Failed to get masteries", err)
+	}
+	accountMasteries, err := s.gw2API.GetAccountMasteries(ctx)
+	if err != nil {
+		return apiErrResult("Failed to get account masteries", err)
+	}
+	points, err := s.gw2API.GetAccountMasteryPoints(ctx)
+	if err != nil {
+		return apiErrResult("Failed to get mastery points", err)
+	}
+
+	progress := make(map[int]*gw2api.AccountMastery, len(accountMasteries))
+	for i := range accountMasteries {
+		progress[accountMasteries[i].ID] = &accountMasteries[i]
+	}
+
+	result := buildMasteryStatus(masteries, progress, points.Totals, args.Region)
+	if len(result.Regions) == 0 {
+		return errResult(fmt.Sprintf("No mastery region matches %q", args.Region))
+	}
+	return jsonResult(result)
+}
+
+// WardrobeGroup counts unlocked skins of one type, weight class and subtype
+type WardrobeGroup struct {
+	Type     string `json:"type"`
+	Weight   string `json:"weight,omitempty"`
+	Subtype  string `json:"subtype,omitempty"`
+	Unlocked int    `json:"unlocked"`
+	Total    int    `json:"total"`
+}
+
+// SkinSource is a tradeable item that unlocks a skin, with its lowest sell listing
+type SkinSource struct {
+	ItemID         int    `json:"item_id"`
+	Name           string `json:"name,omitempty"`
+	SellPrice      int    `json:"sell_price,omitempty"`
+	PriceFormatted string `json:"sell_price_formatted,omitempty"`
+}
+
+// MissingSkin is a skin the account has not unlocked and where to buy it
+type MissingSkin struct {
+	ID       int          `json:"id"`
+	Name     string       `json:"name"`
+	Type     string       `json:"type"`
+	Weight   string       `json:"weight,omitempty"`
+	Subtype  string       `json:"subtype,omitempty"`
+	Rarity   string       `json:"rarity,omitempty"`
+	Sources  []SkinSource `json:"sources,omitempty"`
+	Cheapest *SkinSource  `json:"cheapest,omitempty"`
+}
+
+// WardrobeStatusResult is the response for wardrobe_status
+type WardrobeStatusResult struct {
+	Unlocked     int             `json:"unlocked"`
+	Total        int             `json:"total"`
+	Percent      float64         `json:"percent"`
+	Groups       []WardrobeGroup `json:"groups"`
+	MissingTotal int             `json:"missing_total"`
+	Missing      []MissingSkin   `json:"missing"`
+	Skipped      []string        `json:"skipped,omitempty"`
+}
+
+// filterSkins keeps the skins matching a type, subtype and weight class (case-insensitive;
+// empty filters match everything)
+func filterSkins(skins []gw2api.Skin, skinType, subtype, weight string) []gw2api.Skin {
+	var kept []gw2api.Skin
+	for _, skin := range skins {
+		if skinType != "" && !strings.EqualFold(skin.Type, skinType) {
+			continue
+		}
+		if subtype != "" && !strings.EqualFold(skin.DetailType(), subtype) {
+			continue
+		}
+		if weight != "" && !strings.EqualFold(skin.WeightClass(), weight) {
+			continue
+		}
+		kept = append(kept, skin)
+	}
+	return kept
+}
+
+// buildWardrobeStatus groups skins by type, weight class and subtype and lists the missing
+// ones in the same order
+func buildWardrobeStatus(skins []gw2api.Skin, unlocked map[int]bool) WardrobeStatusResult {
+	sorted := append([]gw2api.Skin(nil), skins...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.WeightClass() != b.WeightClass() {
+			return a.WeightClass() < b.WeightClass()
+		}
+		if a.DetailType() != b.DetailType() {
+			return a.DetailType() < b.DetailType()
+		}
+		return a.Name < b.Name
+	})
+
+	result := WardrobeStatusResult{Groups: []WardrobeGroup{}, Missing: []MissingSkin{}}
+	groups := make(map[WardrobeGroup]int)
+	for _, skin := range sorted {
+		key := WardrobeGroup{Type: skin.Type, Weight: skin.WeightClass(), Subtype: skin.DetailType()}
+		idx, ok := groups[key]
+		if !ok {
+			idx = len(result.Groups)
+			groups[key] = idx
+			result.Groups = append(result.Groups, key)
+		}
+		group := &result.Groups[idx]
+		group.Total++
+		result.Total++
+		if unlocked[skin.ID] {
+			group.Unlocked++
+			result.Unlocked++
+			continue
+		}
+		result.Missing = append(result.Missing, MissingSkin{
+			ID:      skin.ID,
+			Name:    skin.Name,
+			Type:    skin.Type,
+			Weight:  key.Weight,
+			Subtype: key.Subtype,
+			Rarity:  skin.Rarity,
+		})
+	}
+	result.MissingTotal = len(result.Missing)
+	if result.Total > 0 {
+		result.Percent = math.Round(float64(result.Unlocked)/float64(result.Total)*1000) / 10
+	}
+	return result
+}
+
+// cheapestSkinSource returns the listed source with the lowest sell price, or nil
+func cheapestSkinSource(sources []SkinSource) *SkinSource {
+	var cheapest *SkinSource
+	for i := range sources {
+		if sources[i].SellPrice == 0 {
+			continue
+		}
+		if cheapest == nil || sources[i].SellPrice < cheapest.SellPrice {
+			cheapest = &sources[i]
+		}
+	}
+	return cheapest
+}
+
+// errItemIndexBuilding is reported while the item index is built in the background
+var errItemIndexBuilding = errors.New("the item index is still being built from /v2/items; try again in a few minutes")
+
+// addSkinSources attaches tradeable source items and their Trading Post prices to missing skins
+func (s *MCPServer) addSkinSources(ctx context.Context, missing []MissingSkin) error {
+	index, ok := s.gw2API.ReadySkinSources(ctx)
+	if !ok {
+		return errItemIndexBuilding
+	}
+
+	var itemIDs []int
+	for _, skin := range missing {
+		itemIDs = append(itemIDs, index[skin.ID]...)
+	}
+	if len(itemIDs) == 0 {
+		return nil
+	}
+	prices, err := s.gw2API.GetPrices(ctx, itemIDs)
+	if err != nil {
+		return err
+	}
+	byID := make(map[int]gw2api.PriceInfo, len(prices))
+	for _, p := range prices {
+		byID[p.ID] = p
+	}
+
+	for i := range missing {
+		for _, itemID := range index[missing[i].ID] {
+			price, listed := byID[itemID]
+			if !listed {
+				continue
+			}
+			missing[i].Sources = append(missing[i].Sources, SkinSource{
+				ItemID:         itemID,
+				Name:           price.ItemName,
+				SellPrice:      price.Sells.UnitPrice,
+				PriceFormatted: price.SellPrice,
+			})
+		}
+		missing[i].Cheapest = cheapestSkinSource(missing[i].Sources)
+	}
+	return nil
+}
+
+// handleWardrobeStatus handles wardrobe completion requests
+func (s *MCPServer) handleWardrobeStatus(ctx context.Context, _ *mcp.CallToolRequest, args WardrobeStatusArgs) (*mcp.CallToolResult, any, error) {
+	if s.gw2API.APIKey() == "" {
+		return errResult("Failed to get wardrobe status: GW2_API_KEY environment variable not configured")
+	}
+
+	limit := args.Limit
+	if limit <= 0 {
+		limit = 25
+	}
+
+	s.logger.Debug("Wardrobe status request", "type", args.Type, "subtype", args.Subtype, "weight", args.Weight)
+
+	unlocked, err := s.gw2API.GetAccountUnlockIDs(ctx, "skins")
+	if err != nil {
+		return apiErrResult("Failed to get unlocked skins", err)
+	}
+	ids, err := s.gw2API.GetSkinIDs(ctx)
+	if err != nil {
+		return apiErrResult("Failed to get skin list", err)
+	}
+	skins, err := s.gw2API.GetSkins(ctx, ids)
+	if err != nil {
+		return apiErrResult("Failed to get skins", err)
+	}
+
+	skins = filterSkins(skins, args.Type, args.Subtype, args.Weight)
+	if len(skins) == 0 {
+		return errResult("No skins match the given type, subtype and weight")
+	}
+
+	result := buildWardrobeStatus(skins, unlocked)
+	if len(result.Missing) > limit {
+		result.Missing = result.Missing[:limit]
+	}
+	if !args.SkipSources && len(result.Missing) > 0 {
+		if err := s.addSkinSources(ctx, result.Missing); err != nil {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("sources: %v", err))
+		}
+	}
+
+	return jsonResult(result)
+}
+
+// dyeMaterials are the armor materials a dye renders differently on
+var dyeMaterials = []string{"cloth", "leather", "metal", "fur"}
+
+// DyeMatch is a dye colour with its rendering on one material
+type DyeMatch struct {
+	ID             int      `json:"id"`
+	Name           string   `json:"name"`
+	Hex            string   `json:"hex"`
+	Distance       *float64 `json:"distance,omitempty"`
+	Hue            string   `json:"hue,omitempty"`
+	Material       string   `json:"material,omitempty"`
+	Rarity         string   `json:"rarity,omitempty"`
+	Unlocked       *bool    `json:"unlocked,omitempty"`
+	ItemID         int      `json:"item_id,omitempty"`
+	SellPrice      int      `json:"sell_price,omitempty"`
+	PriceFormatted string   `json:"sell_price_formatted,omitempty"`
+}
+
+// FindDyesResult is the response for find_dyes
+type FindDyesResult struct {
+	Target       string     `json:"target,omitempty"`
+	Material     string     `json:"material"`
+	TotalMatched int        `json:"total_matched"`
+	Dyes         []DyeMatch `json:"dyes"`
+	Skipped      []string   `json:"skipped,omitempty"`
+}
+
+// dyeCategories splits a colour's categories into hue, material and rarity
+func dyeCategories(c gw2api.Color) (hue, material, rarity string) {
+	parts := append(append([]string(nil), c.Categories...), "", "", "")
+	return parts[0], parts[1], parts[2]
+}
+
+// dyeRGB returns the colour as rendered on a material, falling back to the base colour
+func dyeRGB(c gw2api.Color, material string) colors.RGB {
+	var component *gw2api.ColorComponent
+	switch material {
+	case "cloth":
+		component = c.Cloth
+	case "leather":
+		component = c.Leather
+	case "metal":
+		component = c.Metal
+	case "fur":
+		component = c.Fur
+	}
+	if component == nil {
+		return colors.RGB(c.BaseRGB)
+	}
+	return colors.RGB(component.RGB)
+}
+
+// matchDyes filters dyes by category and unlock state and, when a target colour is given,
+// sorts them by perceptual distance on the material; otherwise by name
+func matchDyes(all []gw2api.Color, args FindDyesArgs, material string, target *colors.RGB, unlocked map[int]bool) []DyeMatch {
+	var matches []DyeMatch
+	var targetLab colors.Lab
+	if target != nil {
+		targetLab = target.Lab()
+	}
+
+	for _, c := range all {
+		hue, mat, rarity := dyeCategories(c)
+		if args.Hue != "" && !strings.EqualFold(hue, args.Hue) {
+			continue
+		}
+		if args.MaterialCategory != "" && !strings.EqualFold(mat, args.MaterialCategory) {
+			continue
+		}
+		if args.Rarity != "" && !strings.EqualFold(rarity, args.Rarity) {
+			continue
+		}
+		if unlocked != nil && args.LockedOnly && unlocked[c.ID] {
+			continue
+		}
+
+		rgb := dyeRGB(c, material)
+		match := DyeMatch{ID: c.ID, Name: c.Name, Hex: rgb.Hex(), Hue: hue, Material: mat, Rarity: rarity, ItemID: c.Item}
+		if target != nil {
+			d := math.Round(colors.DeltaE2000(targetLab, rgb.Lab())*100) / 100
+			match.Distance = &d
+		}
+		if unlocked != nil {
+			u := unlocked[c.ID]
+			match.Unlocked = &u
+		}
+		matches = append(matches, match)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if target != nil && *matches[i].Distance != *matches[j].Distance {
+			return *matches[i].Distance < *matches[j].Distance
+		}
+		return matches[i].Name < matches[j].Name
+	})
+	return matches
+}
+
+// handleFindDyes handles dye search requests
+func (s *MCPServer) handleFindDyes(ctx context.Context, _ *mcp.CallToolRequest, args FindDyesArgs) (*mcp.CallToolResult, any, error) {
+	material := strings.ToLower(strings.TrimSpace(args.Material))
+	if material == "" {
+		material = "cloth"
+	}
+	if !slices.Contains(dyeMaterials, material) {
+		return errResult(fmt.Sprintf("Invalid material %q: must be one of %s", args.Material, strings.Join(dyeMaterials, ", ")))
+	}
+
+	var target *colors.RGB
+	result := FindDyesResult{Material: material, Dyes: []DyeMatch{}}
+	if args.Hex != "" {
+		rgb, err := colors.ParseHex(args.Hex)
+		if err != nil {
+			return errResult(err.Error())
+		}
+		target = &rgb
+		result.Target = rgb.Hex()
+	}
+
+	limit := args.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+
+	s.logger.Debug("Find dyes request", "hex", args.Hex, "material", material, "hue", args.Hue)
+
+	ids, err := s.gw2API.GetColorIDs(ctx)
+	if err != nil {
+		return apiErrResult("Failed to get color list", err)
+	}
+	all, err := s.gw2API.GetColors(ctx, ids)
+	if err != nil {
+		return apiErrResult("Failed to get colors", err)
+	}
+
+	var unlocked map[int]bool
+	if s.gw2API.APIKey() != "" {
+		if unlocked, err = s.gw2API.GetAccountUnlockIDs(ctx, "dyes"); err != nil {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("unlocked dyes: %v", err))
+		}
+	}
+
+	matches := matchDyes(all, args, material, target, unlocked)
+	result.TotalMatched = len(matches)
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	// Trading Post prices for the dye items of the returned colours
+	var itemIDs []int
+	for _, m := range matches {
+		if m.ItemID > 0 {
+			itemIDs = append(itemIDs, m.ItemID)
+		}
+	}
+	if len(itemIDs) > 0 {
+		prices, err := s.gw2API.GetPrices(ctx, itemIDs)
+		if err != nil {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("prices: %v", err))
+		}
+		byID := make(map[int]gw2api.PriceInfo, len(prices))
+		for _, p := range prices {
+			byID[p.ID] = p
+		}
+		for i := range matches {
+			if p, ok := byID[matches[i].ItemID]; ok && p.Sells.UnitPrice > 0 {
+				matches[i].SellPrice = p.Sells.UnitPrice
+				matches[i].PriceFormatted = p.SellPrice
+			}
+		}
+	}
+
+	result.Dyes = append(result.Dyes, matches...)
+	return jsonResult(result)
+}
+
+// objectiveEffort estimates the minutes a Wizard's Vault objective takes from keywords in
+// its title. Rules are checked in order; perUnit costs scale with the remaining progress.
+var objectiveEffort = []struct {
+	keyword string
+	minutes float64
+	perUnit bool
+}{
+	{"log in", 0.5, false},
+	{"world boss", 15, true},
+	{"fractal", 20, true},
+	{"strike", 15, true},
+	{"raid", 30, true},
+	{"dungeon", 25, true},
+	{"meta", 30, true},
+	{"bount", 8, true},
+	{"pvp", 12, true},
+	{"match", 12, true},
+	{"camp", 8, true},
+	{"tower", 10, true},
+	{"keep", 12, true},
+	{"salvage", 0.1, true},
+	{"mystic forge", 0.5, true},
+	{"gather", 0.5, true},
+	{"harvest", 0.5, true},
+	{"kill", 0.3, true},
+	{"defeat", 0.3, true},
+	{"event", 4, true},
+	{"heart", 8, true},
+	{"vista", 3, true},
+	{"craft", 1, true},
+}
+
+// defaultObjectiveMinutes is used for objectives matching no effort rule
+const defaultObjectiveMinutes = 5
+
+// astralAcclaimName is the wallet currency Wizard's Vault rewards are bought with
+const astralAcclaimName = "Astral Acclaim"
+
+// PlannedObjective is an unfinished objective with its estimated time and acclaim rate
+type PlannedObjective struct {
+	ID               int     `json:"id"`
+	Title            string  `json:"title"`
+	Track            string  `json:"track"`
+	Period           string  `json:"period"`
+	Acclaim          int     `json:"acclaim"`
+	Progress         string  `json:"progress"`
+	EstimatedMinutes float64 `json:"estimated_minutes"`
+	AcclaimPerMinute float64 `json:"acclaim_per_minute"`
+}
+
+// PlannedListing is a Wizard's Vault reward with what is left to buy and its Trading Post value
+type PlannedListing struct {
+	ID              int    `json:"id"`
+	ItemID          int    `json:"item_id"`
+	ItemName        string `json:"item_name,omitempty"`
+	ItemCount       int    `json:"item_count"`
+	Type            string `json:"type"`
+	Cost            int    `json:"cost"`
+	Remaining       *int   `json:"remaining,omitempty"`
+	Affordable      int    `json:"affordable"`
+	UnitSellPrice   int    `json:"unit_sell_price,omitempty"`
+	CoinsPerAcclaim int    `json:"coins_per_acclaim,omitempty"`
+	ValueFormatted  string `json:"coins_per_acclaim_formatted,omitempty"`
+}
+
+// WizardsVaultPlanResult is the response for wizards_vault_plan
+type WizardsVaultPlanResult struct {
+	Season           string             `json:"season,omitempty"`
+	SeasonEnds       *time.Time         `json:"season_ends,omitempty"`
+	Balance          int                `json:"astral_acclaim"`
+	AcclaimAvailable int                `json:"acclaim_available_from_objectives"`
+	Objectives       []PlannedObjective `json:"objectives"`
+	Listings         []PlannedListing   `json:"listings"`
+	Notes            []string           `json:"notes,omitempty"`
+	Skipped          []string           `json:"skipped,omitempty"`
+}
+
+// estimateObjectiveMinutes estimates how long the rest of an objective takes
+func estimateObjectiveMinutes(o gw2api.WizardsVaultObjective) float64 {
+	remaining := float64(max(1, o.ProgressComplete-o.ProgressCurrent))
+	title := strings.ToLower(o.Title)
+	for _, rule := range objectiveEffort {
+		if strings.Contains(title, rule.keyword) {
+			if rule.perUnit {
+				return rule.minutes * remaining
+			}
+			return rule.minutes
+		}
+	}
+	return defaultObjectiveMinutes
+}
+
+// planObjectives lists unfinished objectives by acclaim per estimated minute, best first
+func planObjectives(periods map[string]*gw2api.WizardsVaultProgress, track string) []PlannedObjective {
+	planned := []PlannedObjective{}
+	for period, progress := range periods {
+		for _, o := range progress.Objectives {
+			if o.Done() || (track != "" && !strings.EqualFold(o.Track, track)) {
+				continue
+			}
+			minutes := estimateObjectiveMinutes(o)
+			planned = append(planned, PlannedObjective{
+				ID:               o.ID,
+				Title:            o.Title,
+				Track:            o.Track,
+				Period:           period,
+				Acclaim:          o.Acclaim,
+				Progress:         fmt.Sprintf("%d/%d", o.ProgressCurrent, o.ProgressComplete),
+				EstimatedMinutes: math.Round(minutes*10) / 10,
+				AcclaimPerMinute: math.Round(float64(o.Acclaim)/minutes*100) / 100,
+			})
+		}
+	}
+	sort.Slice(planned, func(i, j int) bool {
+		if planned[i].AcclaimPerMinute != planned[j].AcclaimPerMinute {
+			return planned[i].AcclaimPerMinute > planned[j].AcclaimPerMinute
+		}
+		return planned[i].ID < planned[j].ID
+	})
+	return planned
+}
+
+// planListings lists rewards still available to buy, with how many the balance covers and
+// the Trading Post value per acclaim; tradeable rewards come first, best value first
+func planListings(listings []gw2api.WizardsVaultListing, prices map[int]int, balance int) []PlannedListing {
+	planned := []PlannedListing{}
+	for _, l := range listings {
+		remaining := l.Remaining()
+		if remaining == 0 || l.Cost <= 0 {
+			continue
+		}
+		p := PlannedListing{
+			ID:         l.ID,
+			ItemID:     l.ItemID,
+			ItemName:   l.ItemName,
+			ItemCount:  l.ItemCount,
+			Type:       l.Type,
+			Cost:       l.Cost,
+			Affordable: balance / l.Cost,
+		}
+		if remaining > 0 {
+			p.Remaining = &remaining
+			p.Affordable = min(p.Affordable, remaining)
+		}
+		if price := prices[l.ItemID]; price > 0 {
+			p.UnitSellPrice = price
+			p.CoinsPerAcclaim = price * max(1, l.ItemCount) / l.Cost
+			p.ValueFormatted = gw2api.FormatCoins(p.CoinsPerAcclaim)
+		}
+		planned = append(planned, p)
+	}
+	sort.SliceStable(planned, func(i, j int) bool {
+		if planned[i].CoinsPerAcclaim != planned[j].CoinsPerAcclaim {
+			return planned[i].CoinsPerAcclaim > planned[j].CoinsPerAcclaim
+		}
+		return planned[i].Cost < planned[j].Cost
+	})
+	return planned
+}
+
+// astralAcclaimBalance returns the account's Astral Acclaim from its wallet
+func astralAcclaimBalance(wallet *gw2api.WalletInfo) int {
+	for _, entry := range wallet.Entries {
+		if wallet.Currencies[entry.ID].Name == astralAcclaimName {
+			return entry.Value
+		}
+	}
+	return 0
+}
+
+// handleWizardsVaultPlan handles Wizard's Vault planning requests
+func (s *MCPServer) handleWizardsVaultPlan(ctx context.Context, _ *mcp.CallToolRequest, args WizardsVaultPlanArgs) (*mcp.CallToolResult, any, error) {
+	if s.gw2API.APIKey() == "" {
+		return errResult("Failed to plan wizard's vault: GW2_API_KEY environment variable not configured")
+	}
+
+	limit := args.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+
+	s.logger.Debug("Wizard's vault plan request", "track", args.Track, "limit", limit)
+
+	result := WizardsVaultPlanResult{
+		Notes: []string{"Objective times are rough estimates from the objective title and remaining progress."},
+	}
+
+	if season, err := s.gw2API.GetWizardsVault(ctx); err != nil {
+		result.Skipped = append(result.Skipped, fmt.Sprintf("season: %v", err))
+	} else {
+		result.Season = season.Title
+		result.SeasonEnds = &season.End
+	}
+
+	wallet, err := s.gw2API.GetWallet(ctx)
+	if err != nil {
+		return apiErrResult("Failed to get wallet", err)
+	}
+	result.Balance = astralAcclaimBalance(wallet)
+
+	periods := make(map[string]*gw2api.WizardsVaultProgress)
+	for _, period := range []string{"daily", "weekly", "special"} {
+		progress, err := s.gw2API.GetWizardsVaultObjectives(ctx, period)
+		if err != nil {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s objectives: %v", period, err))
+			continue
+		}
+		periods[period] = progress
+	}
+	objectives := planObjectives(periods, args.Track)
+	for _, o := range objectives {
+		result.AcclaimAvailable += o.Acclaim
+	}
+	if len(objectives) > limit {
+		objectives = objectives[:limit]
+	}
+	result.Objectives = objectives
+
+	listings, err := s.gw2API.GetWizardsVaultListings(ctx)
+	if err != nil {
+		return apiErrResult("Failed to get wizard's vault listings", err)
+	}
+	itemIDs := make([]int, 0, len(listings))
+	for _, l := range listings {
+		itemIDs = append(itemIDs, l.ItemID)
+	}
+	prices := make(map[int]int)
+	if tp, err := s.gw2API.GetPrices(ctx, itemIDs); err != nil {
+		result.Skipped = append(result.Skipped, fmt.Sprintf("prices: %v", err))
+	} else {
+		for _, p := range tp {
+			prices[p.ID] = p.Sells.UnitPrice
+		}
+	}
+	result.Listings = planListings(listings, prices, result.Balance)
+
+	return jsonResult(result)
+}
+
+// guildConsumableUpgrades are upgrade types that are crafted repeatedly rather than unlocked
+// once, so they are left out of the upgrade plan
+var guildConsumableUpgrades = map[string]bool{
+	"Claimable":           true,
+	"Consumable":          true,
+	"Decoration":          true,
+	"GuildHallExpedition": true,
+}
+
+// CompletedGuildUpgrade is an upgrade the guild has finished
+type CompletedGuildUpgrade struct {
+	ID   int    `json:"id"`
+	Name string `json:"name,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+// GuildUpgradeCostStatus is one cost of a planned upgrade with what the treasury already holds
+type GuildUpgradeCostStatus struct {
+	Type       string `json:"type"`
+	Name       string `json:"name,omitempty"`
+	ItemID     int    `json:"item_id,omitempty"`
+	Needed     int    `json:"needed"`
+	Deposited  int    `json:"deposited"`
+	Remaining  int    `json:"remaining"`
+	UnitPrice  int    `json:"unit_price,omitempty"`
+	CoinsToBuy int    `json:"coins_to_buy,omitempty"`
+}
+
+// PlannedGuildUpgrade is an upgrade whose prerequisites are all complete
+type PlannedGuildUpgrade struct {
+	ID             int                      `json:"id"`
+	Name           string                   `json:"name"`
+	Type           string                   `json:"type"`
+	RequiredLevel  int                      `json:"required_level"`
+	LevelMet       bool                     `json:"level_met"`
+	BuildTime      int                      `json:"build_time_minutes,omitempty"`
+	Costs          []GuildUpgradeCostStatus `json:"costs"`
+	CoinsToBuy     int                      `json:"coins_to_buy"`
+	CoinsFormatted string                   `json:"coins_to_buy_formatted"`
+	Untradeable    []string                 `json:"untradeable,omitempty"`
+}
+
+// GuildUpgradePlanResult is the response for guild_upgrade_plan
+type GuildUpgradePlanResult struct {
+	Guild          string                     `json:"guild,omitempty"`
+	Level          int                        `json:"level"`
+	CompletedCount int                        `json:"completed_count"`
+	Completed      []CompletedGuildUpgrade    `json:"completed"`
+	Next           []PlannedGuildUpgrade      `json:"next"`
+	Treasury       []gw2api.GuildTreasuryItem `json:"treasury"`
+	Notes          []string                   `json:"notes,omitempty"`
+	Skipped        []string                   `json:"skipped,omitempty"`
+}
+
+// completedGuildUpgrades names the completed upgrade IDs; unknown IDs are kept without a name
+func completedGuildUpgrades(upgrades []gw2api.GuildUpgrade, ids []int) []CompletedGuildUpgrade {
+	byID := make(map[int]gw2api.GuildUpgrade, len(upgrades))
+	for _, u := range upgrades {
+		byID[u.ID] = u
+	}
+	completed := make([]CompletedGuildUpgrade, 0, len(ids))
+	for _, id := range ids {
+		u := byID[id]
+		completed = append(completed, CompletedGuildUpgrade{ID: id, Name: u.Name, Type: u.Type})
+	}
+	return completed
+}
+
+// nextGuildUpgrades returns the permanent upgrades not yet done whose prerequisites are all
+// complete, optionally filtered by a case-insensitive name substring
+func nextGuildUpgrades(upgrades []gw2api.GuildUpgrade, completed map[int]bool, name string) []gw2api.GuildUpgrade {
+	name = strings.ToLower(name)
+	var next []gw2api.GuildUpgrade
+	for _, u := range upgrades {
+		if completed[u.ID] || guildConsumableUpgrades[u.Type] {
+			continue
+		}
+		if name != "" && !strings.Contains(strings.ToLower(u.Name), name) {
+			continue
+		}
+		ready := true
+		for _, pre := range u.Prerequisites {
+			if !completed[pre] {
+				ready = false
+				break
+			}
+		}
+		if ready {
+			next = append(next, u)
+		}
+	}
+	return next
+}
+
+// planGuildUpgrade works out what an upgrade still needs after the treasury deposits and
+// what buying the rest from the Trading Post costs
+func planGuildUpgrade(u gw2api.GuildUpgrade, level int, treasury map[int]int, prices map[int]int) PlannedGuildUpgrade {
+	planned := PlannedGuildUpgrade{
+		ID:            u.ID,
+		Name:          u.Name,
+		Type:          u.Type,
+		RequiredLevel: u.RequiredLevel,
+		LevelMet:      level >= u.RequiredLevel,
+		BuildTime:     u.BuildTime,
+		Costs:         make([]GuildUpgradeCostStatus, 0, len(u.Costs)),
+	}
+	for _, cost := range u.Costs {
+		status := GuildUpgradeCostStatus{
+			Type:      cost.Type,
+			Name:      cost.Name,
+			ItemID:    cost.ItemID,
+			Needed:    cost.Count,
+			Remaining: cost.Count,
+		}
+		if cost.ItemID > 0 {
+			status.Deposited = min(treasury[cost.ItemID], cost.Count)
+			status.Remaining = cost.Count - status.Deposited
+			if price := prices[cost.ItemID]; price > 0 {
+				status.UnitPrice = price
+				status.CoinsToBuy = price * status.Remaining
+				planned.CoinsToBuy += status.CoinsToBuy
+			} else if status.Remaining > 0 {
+				planned.Untradeable = append(planned.Untradeable, cost.Name)
+			}
+		}
+		planned.Costs = append(planned.Costs, status)
+	}
+	planned.CoinsFormatted = gw2api.FormatCoins(planned.CoinsToBuy)
+	return planned
+}
+
+// handleGuildUpgradePlan handles guild upgrade planning requests
+func (s *MCPServer) handleGuildUpgradePlan(ctx context.Context, _ *mcp.CallToolRequest, args GuildUpgradePlanArgs) (*mcp.CallToolResult, any, error) {
+	if args.ID == "" {
+		return errResult("id parameter is required")
+	}
+	if s.gw2API.APIKey() == "" {
+		return errResult("Failed to plan guild upgrades: GW2_API_KEY environment variable not configured")
+	}
+
+	limit := args.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+
+	s.logger.Debug("Guild upgrade plan request", "id", args.ID, "upgrade", args.Upgrade, "limit", limit)
+
+	result := GuildUpgradePlanResult{
+		Notes: []string{"Deposits are counted against each upgrade separately; a treasury item needed by several upgrades is only deposited once."},
+	}
+
+	if guild, err := s.gw2API.GetGuild(ctx, args.ID); err != nil {
+		result.Skipped = append(result.Skipped, fmt.Sprintf("guild info: %v", err))
+	} else {
+		result.Guild = guild.Name
+		result.Level = guild.Level
+	}
+
+	ids, err := s.gw2API.GetGuildUpgradeIDs(ctx, args.ID)
+	if err != nil {
+		return apiErrResult("Failed to get guild upgrades", err)
+	}
+	upgrades, err := s.gw2API.GetGuildUpgrades(ctx)
+	if err != nil {
+		return apiErrResult("Failed to get guild upgrade definitions", err)
+	}
+	result.Completed = completedGuildUpgrades(upgrades, ids)
+	result.CompletedCount = len(ids)
+
+	completed := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		completed[id] = true
+	}
+
+	deposited := make(map[int]int)
+	if treasury, err := s.gw2API.GetGuildTreasury(ctx, args.ID); err != nil {
+		result.Skipped = append(result.Skipped, fmt.Sprintf("treasury: %v", err))
+	} else {
+		result.Treasury = treasury
+		for _, t := range treasury {
+			deposited[t.ItemID] = t.Count
+		}
+	}
+
+	next := nextGuildUpgrades(upgrades, completed, args.Upgrade)
+	var itemIDs []int
+	for _, u := range next {
+		for _, cost := range u.Costs {
+			if cost.ItemID > 0 {
+				itemIDs = append(itemIDs, cost.ItemID)
+			}
+		}
+	}
+	prices := make(map[int]int)
+	if len(itemIDs) > 0 {
+		if tp, err := s.gw2API.GetPrices(ctx, itemIDs); err != nil {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("prices: %v", err))
+		} else {
+			for _, p := range tp {
+				prices[p.ID] = p.Sells.UnitPrice
+			}
+		}
+	}
+
+	result.Next = make([]PlannedGuildUpgrade, 0, len(next))
+	for _, u := range next {
+		result.Next = append(result.Next, planGuildUpgrade(u, result.Level, deposited, prices))
+	}
+	sort.SliceStable(result.Next, func(i, j int) bool {
+		a, b := result.Next[i], result.Next[j]
+		if a.LevelMet != b.LevelMet {
+			return a.LevelMet
+		}
+		if a.CoinsToBuy != b.CoinsToBuy {
+			return a.CoinsToBuy < b.CoinsToBuy
+		}
+		return a.Name < b.Name
+	})
+	if len(result.Next) > limit {
+		result.Next = result.Next[:limit]
+	}
+
+	return jsonResult(result)
+}
+
+const (
+	// unusualWithdrawalFactor is how many times the median withdrawal value a single
+	// withdrawal must reach to be flagged
+	unusualWithdrawalFactor = 3
+	// minUnusualWithdrawal is the smallest value, in copper, a flagged withdrawal can have
+	minUnusualWithdrawal = 10000
+)
+
+// MembershipChange is a join, invite, kick or declined invite
+type MembershipChange struct {
+	Time time.Time `json:"time"`
+	User string    `json:"user"`
+	By   string    `json:"by,omitempty"`
+}
+
+// RankChange is a member moved between ranks
+type RankChange struct {
+	Time    time.Time `json:"time"`
+	User    string    `json:"user"`
+	By      string    `json:"by,omitempty"`
+	OldRank string    `json:"old_rank"`
+	NewRank string    `json:"new_rank"`
+}
+
+// MembershipSummary aggregates roster changes over the report period
+type MembershipSummary struct {
+	Joined   []MembershipChange `json:"joined"`
+	Invited  []MembershipChange `json:"invited"`
+	Kicked   []MembershipChange `json:"kicked"`
+	Declined []MembershipChange `json:"declined"`
+	Ranks    []RankChange       `json:"rank_changes"`
+}
+
+// MemberActivity is one member's stash and treasury activity over the report period;
+// values are coins plus items at the lowest Trading Post sell price
+type MemberActivity struct {
+	User             string `json:"user"`
+	StashDeposits    int    `json:"stash_deposits"`
+	StashWithdrawals int    `json:"stash_withdrawals"`
+	ItemsDeposited   int    `json:"items_deposited"`
+	ItemsWithdrawn   int    `json:"items_withdrawn"`
+	CoinsDeposited   int    `json:"coins_deposited"`
+	CoinsWithdrawn   int    `json:"coins_withdrawn"`
+	ValueDeposited   int    `json:"value_deposited"`
+	ValueWithdrawn   int    `json:"value_withdrawn"`
+	TreasuryItems    int    `json:"treasury_items"`
+	TreasuryValue    int    `json:"treasury_value"`
+}
+
+// UpgradeCompletion is a guild upgrade finished in the report period
+type UpgradeCompletion struct {
+	Time      time.Time `json:"time"`
+	UpgradeID int       `json:"upgrade_id"`
+	Name      string    `json:"name,omitempty"`
+	User      string    `json:"user,omitempty"`
+}
+
+// FlaggedWithdrawal is a stash withdrawal that stands out from the period's usual ones
+type FlaggedWithdrawal struct {
+	Time     time.Time `json:"time"`
+	User     string    `json:"user"`
+	ItemID   int       `json:"item_id,omitempty"`
+	ItemName string    `json:"item_name,omitempty"`
+	Count    int       `json:"count,omitempty"`
+	Coins    int       `json:"coins,omitempty"`
+	Value    int       `json:"value"`
+	Reasons  []string  `json:"reasons"`
+}
+
+// GuildActivityReport is the response for guild_activity_report
+type GuildActivityReport struct {
+	From              time.Time           `json:"from"`
+	To                time.Time           `json:"to"`
+	Entries           int                 `json:"entries"`
+	HistoryStarts     *time.Time          `json:"history_starts,omitempty"`
+	Membership        MembershipSummary   `json:"membership"`
+	Members           []MemberActivity    `json:"members"`
+	UpgradesCompleted []UpgradeCompletion `json:"upgrades_completed"`
+	UpgradesQueued    int                 `json:"upgrades_queued"`
+	MOTDChanges       int                 `json:"motd_changes"`
+	Flagged           []FlaggedWithdrawal `json:"flagged_withdrawals"`
+	Notes             []string            `json:"notes,omitempty"`
+	Skipped           []string            `json:"skipped,omitempty"`
+}
+
+// guildLogValue is the coins of a stash or treasury entry plus its items at Trading Post prices
+func guildLogValue(e gw2api.GuildLogEntry, prices map[int]int) int {
+	return e.Coins + e.Count*prices[e.ItemID]
+}
+
+// entriesSince returns the log entries at or after from, newest first as the log is kept
+func entriesSince(entries []gw2api.GuildLogEntry, from time.Time) []gw2api.GuildLogEntry {
+	var period []gw2api.GuildLogEntry
+	for _, e := range entries {
+		if !e.Time.Before(from) {
+			period = append(period, e)
+		}
+	}
+	return period
+}
+
+// buildGuildActivity aggregates log entries into roster changes, per-member stash and
+// treasury activity, upgrade completions and flagged withdrawals
+func buildGuildActivity(entries []gw2api.GuildLogEntry, prices map[int]int, itemNames, upgradeNames map[int]string) GuildActivityReport {
+	report := GuildActivityReport{
+		Entries: len(entries),
+		Membership: MembershipSummary{
+			Joined: []MembershipChange{}, Invited: []MembershipChange{}, Kicked: []MembershipChange{},
+			Declined: []MembershipChange{}, Ranks: []RankChange{},
+		},
+		UpgradesCompleted: []UpgradeCompletion{},
+		Flagged:           []FlaggedWithdrawal{},
+	}
+
+	members := make(map[string]*MemberActivity)
+	member := func(user string) *MemberActivity {
+		if members[user] == nil {
+			members[user] = &MemberActivity{User: user}
+		}
+		return members[user]
+	}
+
+	var withdrawals []gw2api.GuildLogEntry
+	for _, e := range entries {
+		switch e.Type {
+		case "joined":
+			report.Membership.Joined = append(report.Membership.Joined, MembershipChange{Time: e.Time, User: e.User})
+		case "invited":
+			report.Membership.Invited = append(report.Membership.Invited, MembershipChange{Time: e.Time, User: e.User, By: e.InvitedBy})
+		case "kick":
+			report.Membership.Kicked = append(report.Membership.Kicked, MembershipChange{Time: e.Time, User: e.User, By: e.KickedBy})
+		case "invite_declined":
+			report.Membership.Declined = append(report.Membership.Declined, MembershipChange{Time: e.Time, User: e.User, By: e.DeclinedBy})
+		case "rank_change":
+			report.Membership.Ranks = append(report.Membership.Ranks, RankChange{
+				Time: e.Time, User: e.User, By: e.ChangedBy, OldRank: e.OldRank, NewRank: e.NewRank,
+			})
+		case "stash":
+			m := member(e.User)
+			switch e.Operation {
+			case "deposit":
+				m.StashDeposits++
+				m.ItemsDeposited += e.Count
+				m.CoinsDeposited += e.Coins
+				m.ValueDeposited += guildLogValue(e, prices)
+			case "withdraw":
+				m.StashWithdrawals++
+				m.ItemsWithdrawn += e.Count
+				m.CoinsWithdrawn += e.Coins
+				m.ValueWithdrawn += guildLogValue(e, prices)
+				withdrawals = append(withdrawals, e)
+			}
+		case "treasury":
+			m := member(e.User)
+			m.TreasuryItems += e.Count
+			m.TreasuryValue += guildLogValue(e, prices)
+		case "upgrade":
+			switch e.Action {
+			case "completed":
+				report.UpgradesCompleted = append(report.UpgradesCompleted, UpgradeCompletion{
+					Time: e.Time, UpgradeID: e.UpgradeID, Name: upgradeNames[e.UpgradeID], User: e.User,
+				})
+			case "queued":
+				report.UpgradesQueued++
+			}
+		case "motd":
+			report.MOTDChanges++
+		}
+	}
+
+	report.Members = make([]MemberActivity, 0, len(members))
+	for _, m := range members {
+		report.Members = append(report.Members, *m)
+	}
+	sort.Slice(report.Members, func(i, j int) bool {
+		a, b := report.Members[i], report.Members[j]
+		if a.ValueWithdrawn != b.ValueWithdrawn {
+			return a.ValueWithdrawn > b.ValueWithdrawn
+		}
+		return a.User < b.User
+	})
+
+	report.Flagged = flagWithdrawals(withdrawals, members, prices, itemNames)
+	return report
+}
+
+// flagWithdrawals flags withdrawals worth several times the period's median withdrawal and
+// valuable withdrawals by members who deposited nothing in the period
+func flagWithdrawals(withdrawals []gw2api.GuildLogEntry, members map[string]*MemberActivity, prices map[int]int, itemNames map[int]string) []FlaggedWithdrawal {
+	flagged := []FlaggedWithdrawal{}
+	if len(withdrawals) == 0 {
+		return flagged
+	}
+
+	values := make([]int, len(withdrawals))
+	for i, e := range withdrawals {
+		values[i] = guildLogValue(e, prices)
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	median := sorted[len(sorted)/2]
+
+	for i, e := range withdrawals {
+		value := values[i]
+		if value < minUnusualWithdrawal {
+			continue
+		}
+		var reasons []string
+		if len(withdrawals) > 1 && value >= unusualWithdrawalFactor*median {
+			reasons = append(reasons, fmt.Sprintf("worth %s, over %d times the median withdrawal of %s",
+				gw2api.FormatCoins(value), unusualWithdrawalFactor, gw2api.FormatCoins(median)))
+		}
+		if m := members[e.User]; m.StashDeposits == 0 && m.TreasuryItems == 0 {
+			reasons = append(reasons, "member made no deposits in the period")
+		}
+		if len(reasons) == 0 {
+			continue
+		}
+		flagged = append(flagged, FlaggedWithdrawal{
+			Time: e.Time, User: e.User, ItemID: e.ItemID, ItemName: itemNames[e.ItemID],
+			Count: e.Count, Coins: e.Coins, Value: value, Reasons: reasons,
+		})
+	}
+	return flagged
+}
+
+// handleGuildActivityReport handles guild log analytics requests
+func (s *MCPServer) handleGuildActivityReport(ctx context.Context, _ *mcp.CallToolRequest, args GuildActivityReportArgs) (*mcp.CallToolResult, any, error) {
+	if args.ID == "" {
+		return errResult("id parameter is required")
+	}
+	if s.gw2API.APIKey() == "" {
+		return errResult("Failed to report guild activity: GW2_API_KEY environment variable not configured")
+	}
+
+	days := args.Days
+	if days <= 0 {
+		days = 7
+	}
+
+	s.logger.Debug("Guild activity report request", "id", args.ID, "days", days)
+
+	history, err := s.gw2API.GetGuildLog(ctx, args.ID)
+	if err != nil {
+		return apiErrResult("Failed to get guild log", err)
+	}
+
+	now := time.Now().UTC()
+	from := now.AddDate(0, 0, -days)
+	period := entriesSince(history.Entries, from)
+
+	var skipped []string
+	var itemIDs []int
+	for _, e := range period {
+		if e.ItemID > 0 && (e.Type == "stash" || e.Type == "treasury") {
+			itemIDs = append(itemIDs, e.ItemID)
+		}
+	}
+	prices := make(map[int]int)
+	itemNames := make(map[int]string)
+	if len(itemIDs) > 0 {
+		if tp, err := s.gw2API.GetPrices(ctx, itemIDs); err != nil {
+			skipped = append(skipped, fmt.Sprintf("prices: %v", err))
+		} else {
+			for _, p := range tp {
+				prices[p.ID] = p.Sells.UnitPrice
+			}
+		}
+		if items, err := s.gw2API.GetItems(ctx, itemIDs); err != nil {
+			skipped = append(skipped, fmt.Sprintf("item names: %v", err))
+		} else {
+			for id, item := range items {
+				itemNames[id] = item.Name
+			}
+		}
+	}
+	upgradeNames := make(map[int]string)
+	if upgrades, err := s.gw2API.GetGuildUpgrades(ctx); err != nil {
+		skipped = append(skipped, fmt.Sprintf("upgrade names: %v", err))
+	} else {
+		for _, u := range upgrades {
+			upgradeNames[u.ID] = u.Name
+		}
+	}
+
+	report := buildGuildActivity(period, prices, itemNames, upgradeNames)
+	report.From = from
+	report.To = now
+	report.Skipped = skipped
+	if n := len(history.Entries); n > 0 {
+		oldest := history.Entries[n-1].Time
+		report.HistoryStarts = &oldest
+		if oldest.After(from) {
+			report.Notes = append(report.Notes, "The collected log history starts after the requested period; the API only returns the latest 100 entries, and older ones build up as the report is run regularly.")
+		}
+	}
+	if history.GapAfter > 0 {
+		report.Notes = append(report.Notes, fmt.Sprintf("Entries after log ID %d may be missing: more than 100 entries arrived between two fetches.", history.GapAfter))
+	}
+
+	return jsonResult(report)
+}
+
+// RosterMember is a guild member with the latest activity found for them in the guild log
+type RosterMember struct {
+	Name         string     `json:"name"`
+	Rank         string     `json:"rank"`
+	Joined       *time.Time `json:"joined,omitempty"`
+	LastSeen     *time.Time `json:"last_seen,omitempty"`
+	LastActivity string     `json:"last_activity,omitempty"`
+}
+
+// RankRoster is a guild rank with its permissions and members
+type RankRoster struct {
+	Rank        string         `json:"rank"`
+	Order       int            `json:"order"`
+	Permissions []string       `json:"permissions"`
+	Members     []RosterMember `json:"members"`
+}
+
+// GuildRosterReport is the response for guild_roster_report
+type GuildRosterReport struct {
+	Guild         string         `json:"guild,omitempty"`
+	MemberCount   int            `json:"member_count"`
+	Ranks         []RankRoster   `json:"ranks"`
+	Newest        []RosterMember `json:"newest"`
+	NotSeen       int            `json:"not_seen"`
+	HistoryStarts *time.Time     `json:"history_starts,omitempty"`
+	Notes         []string       `json:"notes,omitempty"`
+	Skipped       []string       `json:"skipped,omitempty"`
+}
+
+// guildLogActors returns the accounts an entry shows as active: the user and whoever
+// invited, kicked, promoted or was declined
+func guildLogActors(e gw2api.GuildLogEntry) []string {
+	var actors []string
+	for _, name := range []string{e.User, e.InvitedBy, e.KickedBy, e.ChangedBy, e.DeclinedBy} {
+		if name != "" {
+			actors = append(actors, name)
+		}
+	}
+	return actors
+}
+
+// lastSeen maps each account to its newest guild log entry
+func lastSeen(entries []gw2api.GuildLogEntry) map[string]gw2api.GuildLogEntry {
+	seen := make(map[string]gw2api.GuildLogEntry)
+	for _, e := range entries {
+		for _, name := range guildLogActors(e) {
+			if prev, ok := seen[name]; !ok || e.Time.After(prev.Time) {
+				seen[name] = e
+			}
+		}
+	}
+	return seen
+}
+
+// newRosterMember adds the member's last guild log activity, if any
+func newRosterMember(m gw2api.GuildMember, seen map[string]gw2api.GuildLogEntry) RosterMember {
+	member := RosterMember{Name: m.Name, Rank: m.Rank, Joined: m.Joined}
+	if e, ok := seen[m.Name]; ok {
+		at := e.Time
+		member.LastSeen = &at
+		member.LastActivity = e.Type
+	}
+	return member
+}
+
+// buildGuildRoster groups members by rank in rank order, most recently seen first; members
+// whose rank is not in ranks are grouped after the known ranks
+func buildGuildRoster(members []gw2api.GuildMember, ranks []gw2api.GuildRank, permissionNames map[string]string, seen map[string]gw2api.GuildLogEntry) []RankRoster {
+	byRank := make(map[string]*RankRoster, len(ranks))
+	roster := make([]*RankRoster, 0, len(ranks))
+	for _, r := range ranks {
+		permissions := make([]string, 0, len(r.Permissions))
+		for _, p := range r.Permissions {
+			if name := permissionNames[p]; name != "" {
+				permissions = append(permissions, name)
+			} else {
+				permissions = append(permissions, p)
+			}
+		}
+		rr := &RankRoster{Rank: r.ID, Order: r.Order, Permissions: permissions, Members: []RosterMember{}}
+		byRank[r.ID] = rr
+		roster = append(roster, rr)
+	}
+	sort.SliceStable(roster, func(i, j int) bool { return roster[i].Order < roster[j].Order })
+
+	for _, m := range members {
+		rr := byRank[m.Rank]
+		if rr == nil {
+			rr = &RankRoster{Rank: m.Rank, Order: math.MaxInt, Permissions: []string{}, Members: []RosterMember{}}
+			byRank[m.Rank] = rr
+			roster = append(roster, rr)
+		}
+		rr.Members = append(rr.Members, newRosterMember(m, seen))
+	}
+
+	result := make([]RankRoster, 0, len(roster))
+	for _, rr := range roster {
+		sort.SliceStable(rr.Members, func(i, j int) bool {
+			a, b := rr.Members[i], rr.Members[j]
+			if (a.LastSeen == nil) != (b.LastSeen == nil) {
+				return a.LastSeen != nil
+			}
+			if a.LastSeen != nil && !a.LastSeen.Equal(*b.LastSeen) {
+				return a.LastSeen.After(*b.LastSeen)
+			}
+			return a.Name < b.Name
+		})
+		result = append(result, *rr)
+	}
+	return result
+}
+
+// newestMembers returns the limit most recently joined members; members without a join
+// date are left out
+func newestMembers(members []gw2api.GuildMember, seen map[string]gw2api.GuildLogEntry, limit int) []RosterMember {
+	var joined []gw2api.GuildMember
+	for _, m := range members {
+		if m.Joined != nil {
+			joined = append(joined, m)
+		}
+	}
+	sort.SliceStable(joined, func(i, j int) bool { return joined[i].Joined.After(*joined[j].Joined) })
+	if len(joined) > limit {
+		joined = joined[:limit]
+	}
+	newest := make([]RosterMember, 0, len(joined))
+	for _, m := range joined {
+		newest = append(newest, newRosterMember(m, seen))
+	}
+	return newest
+}
+
+// handleGuildRosterReport handles guild roster requests
+func (s *MCPServer) handleGuildRosterReport(ctx context.Context, _ *mcp.CallToolRequest, args GuildRosterReportArgs) (*mcp.CallToolResult, any, error) {
+	if args.ID == "" {
+		return errResult("id parameter is required")
+	}
+	if s.gw2API.APIKey() == "" {
+		return errResult("Failed to report guild roster: GW2_API_KEY environment variable not configured")
+	}
+
+	limit := args.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+
+	s.logger.Debug("Guild roster report request", "id", args.ID, "limit", limit)
+
+	members, err := s.gw2API.GetGuildMembers(ctx, args.ID)
+	if err != nil {
+		return apiErrResult("Failed to get guild members", err)
+	}
+	ranks, err := s.gw2API.GetGuildRanks(ctx, args.ID)
+	if err != nil {
+		return apiErrResult("Failed to get guild ranks", err)
+	}
+
+	report := GuildRosterReport{
+		MemberCount: len(members),
+		Notes:       []string{"Last seen is the newest guild log entry involving the member, so members who play without touching the stash, treasury, upgrades or roster show as not seen."},
+	}
+	if guild, err := s.gw2API.GetGuild(ctx, args.ID); err != nil {
+		report.Skipped = append(report.Skipped, fmt.Sprintf("guild info: %v", err))
+	} else {
+		report.Guild = guild.Name
+	}
+
+	permissionNames := make(map[string]string)
+	if permissions, err := s.gw2API.GetGuildPermissions(ctx); err != nil {
+		report.Skipped = append(report.Skipped, fmt.Sprintf("permission names: %v", err))
+	} else {
+		for _, p := range permissions {
+			permissionNames[p.ID] = p.Name
+		}
+	}
+
+	seen := map[string]gw2api.GuildLogEntry{}
+	if history, err := s.gw2API.GetGuildLog(ctx, args.ID); err != nil {
+		report.Skipped = append(report.Skipped, fmt.Sprintf("guild log: %v", err))
+	} else {
+		seen = lastSeen(history.Entries)
+		if n := len(history.Entries); n > 0 {
+			oldest := history.Entries[n-1].Time
+			report.HistoryStarts = &oldest
+		}
+	}
+
+	report.Ranks = buildGuildRoster(members, ranks, permissionNames, seen)
+	report.Newest = newestMembers(members, seen, limit)
+	for _, m := range members {
+		if _, ok := seen[m.Name]; !ok {
+			report.NotSeen++
+		}
+	}
+
+	return jsonResult(report)
 }
@@ -1,8 +1,22 @@
 package server
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/AlyxPink/gw2-mcp/internal/cache"
+	"github.com/AlyxPink/gw2-mcp/internal/colors"
+	"github.com/AlyxPink/gw2-mcp/internal/crafting"
+	"github.com/AlyxPink/gw2-mcp/internal/fractals"
+	"github.com/AlyxPink/gw2-mcp/internal/gw2api"
+	"github.com/AlyxPink/gw2-mcp/internal/schedule"
 	"github.com/AlyxPink/gw2-mcp/internal/wiki"
 )
 
@@ -153,3 +167,1398 @@ func TestExtractRecipeIDsFromWikiResult(t *testing.T) {
 		})
 	}
 }
+
+func TestItemMatcher(t *testing.T) {
+	tests := []struct {
+		name     string
+		matcher  itemMatcher
+		itemID   int
+		itemName string
+		want     bool
+	}{
+		{"id match", newItemMatcher(19675, ""), 19675, "Mystic Clover", true},
+		{"id mismatch", newItemMatcher(19675, ""), 19976, "Mystic Coin", false},
+		{"id takes precedence over name", newItemMatcher(19675, "Mystic Coin"), 19976, "Mystic Coin", false},
+		{"exact name", newItemMatcher(0, "Mystic Clover"), 19675, "Mystic Clover", true},
+		{"case-insensitive partial name", newItemMatcher(0, "  mystic clo "), 19675, "Mystic Clover", true},
+		{"name mismatch", newItemMatcher(0, "Mystic Clover"), 19976, "Mystic Coin", false},
+		{"unresolved item name", newItemMatcher(0, "Mystic Clover"), 19675, "", false},
+		{"empty query", newItemMatcher(0, ""), 19675, "Mystic Clover", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.matcher.matches(tt.itemID, tt.itemName); got != tt.want {
+				t.Errorf("matches(%d, %q) = %v, want %v", tt.itemID, tt.itemName, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocateInBags(t *testing.T) {
+	bags := []*gw2api.CharacterBag{
+		{ID: 8932, Size: 20, Inventory: []*gw2api.BagSlot{
+			{ID: 19675, Count: 12, ItemName: "Mystic Clover"},
+			nil,
+			{ID: 19976, Count: 250, ItemName: "Mystic Coin"},
+		}},
+		nil,
+		{ID: 8932, Size: 20, Inventory: []*gw2api.BagSlot{
+			{ID: 19675, Count: 3, ItemName: "Mystic Clover"},
+		}},
+	}
+
+	locations := locateInBags(newItemMatcher(19675, ""), "Alyx", bags)
+	if len(locations) != 2 {
+		t.Fatalf("got %d locations, want 2", len(locations))
+	}
+	if locations[0].Slot != "bag 1" || locations[0].Count != 12 {
+		t.Errorf("first location = %+v, want bag 1 with count 12", locations[0])
+	}
+	if locations[1].Slot != "bag 3" || locations[1].Count != 3 {
+		t.Errorf("second location = %+v, want bag 3 with count 3", locations[1])
+	}
+	for _, loc := range locations {
+		if loc.Source != "character_bags" || loc.Character != "Alyx" {
+			t.Errorf("unexpected source/character in %+v", loc)
+		}
+	}
+}
+
+func TestLocateInEquipment(t *testing.T) {
+	equipment := []gw2api.EquipmentItem{
+		{ID: 30684, Slot: "WeaponA1", ItemName: "Frostfang"},
+		{ID: 30684, Slot: "WeaponB1", ItemName: "Frostfang", Count: 2},
+		{ID: 48879, Slot: "Helm", ItemName: "Perfected Envoy Helmet"},
+	}
+
+	locations := locateInEquipment(newItemMatcher(0, "frostfang"), "Alyx", equipment)
+	if len(locations) != 2 {
+		t.Fatalf("got %d locations, want 2", len(locations))
+	}
+	if locations[0].Count != 1 {
+		t.Errorf("count without explicit value = %d, want 1", locations[0].Count)
+	}
+	if locations[1].Count != 2 || locations[1].Slot != "WeaponB1" {
+		t.Errorf("second location = %+v, want WeaponB1 with count 2", locations[1])
+	}
+}
+
+func TestLegendarySlot(t *testing.T) {
+	tests := []struct {
+		name string
+		item gw2api.Item
+		want string
+	}{
+		{"armor", gw2api.Item{Type: "Armor", Details: []byte(`{"type":"Helm","weight_class":"Heavy"}`)}, "Armor: Helm"},
+		{"weapon", gw2api.Item{Type: "Weapon", Details: []byte(`{"type":"Greatsword"}`)}, "Weapon: Greatsword"},
+		{"trinket", gw2api.Item{Type: "Trinket", Details: []byte(`{"type":"Ring"}`)}, "Trinket: Ring"},
+		{"sigil", gw2api.Item{Type: "UpgradeComponent", Details: []byte(`{"type":"Sigil"}`)}, "Sigil"},
+		{"back", gw2api.Item{Type: "Back"}, "Back"},
+		{"weapon without details", gw2api.Item{Type: "Weapon"}, "Weapon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := legendarySlot(tt.item); got != tt.want {
+				t.Errorf("legendarySlot() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarizeLegendarySlots(t *testing.T) {
+	entries := []gw2api.LegendaryArmoryEntry{{ID: 1, MaxCount: 1}, {ID: 2, MaxCount: 1}, {ID: 3, MaxCount: 2}}
+	items := map[int]gw2api.Item{
+		1: {ID: 1, Name: "Twilight", Type: "Weapon", Details: []byte(`{"type":"Greatsword"}`)},
+		2: {ID: 2, Name: "Sunrise", Type: "Weapon", Details: []byte(`{"type":"Greatsword"}`)},
+		3: {ID: 3, Name: "Aurora", Type: "Trinket", Details: []byte(`{"type":"Accessory"}`)},
+	}
+
+	slots := summarizeLegendarySlots(entries, items, map[int]int{1: 1})
+	if len(slots) != 2 {
+		t.Fatalf("got %d slots, want 2", len(slots))
+	}
+	gs := slots[1]
+	if gs.Slot != "Weapon: Greatsword" || gs.Total != 2 || gs.Unlocked != 1 {
+		t.Errorf("greatsword slot = %+v", gs)
+	}
+	if len(gs.Owned) != 1 || gs.Owned[0] != "Twilight" || len(gs.Missing) != 1 || gs.Missing[0] != "Sunrise" {
+		t.Errorf("greatsword owned/missing = %v/%v", gs.Owned, gs.Missing)
+	}
+}
+
+func TestFindItemIDByName(t *testing.T) {
+	items := map[int]gw2api.Item{
+		30704: {ID: 30704, Name: "Twilight"},
+		30703: {ID: 30703, Name: "Sunrise"},
+		81908: {ID: 81908, Name: "Aurora"},
+		91234: {ID: 91234, Name: "Twilight Arbor Token"},
+	}
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"Twilight", 30704},
+		{"  aurora ", 81908},
+		{"sun", 30703},
+		{"Bolt", 0},
+		{"", 0},
+	}
+	for _, tt := range tests {
+		if got := findItemIDByName(items, tt.query); got != tt.want {
+			t.Errorf("findItemIDByName(%q) = %d, want %d", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestMysticForgeToEnriched(t *testing.T) {
+	mf := wiki.MysticForgeRecipe{
+		Output:       "Twilight",
+		OutputItemID: 30704,
+		OutputCount:  1,
+		URL:          "https://wiki.guildwars2.com/wiki/Twilight",
+		Ingredients: []wiki.MysticForgeIngredient{
+			{Name: "Gift of Twilight", Count: 1, ItemID: 19648},
+			{Name: "Gift of Mastery", Count: 1, ItemID: 19674},
+			{Name: "Gifts of Fortune", Count: 1},
+			{Name: "Dusk", Count: 1, ItemID: 29185},
+		},
+	}
+
+	recipe := mysticForgeToEnriched(mf)
+	if recipe.Source != recipeSourceMysticForge || recipe.ID != 0 || recipe.Type != "MysticForge" {
+		t.Errorf("unexpected recipe header %+v", recipe.Recipe)
+	}
+	if recipe.OutputItemID != 30704 || recipe.OutputItemCount != 1 || recipe.OutputItemName != "Twilight" {
+		t.Errorf("unexpected output %d x%d %q", recipe.OutputItemID, recipe.OutputItemCount, recipe.OutputItemName)
+	}
+	if len(recipe.Ingredients) != 3 {
+		t.Fatalf("got %d resolved ingredients, want 3", len(recipe.Ingredients))
+	}
+	if recipe.IngredientNames[29185] != "Dusk" {
+		t.Errorf("ingredient name for 29185 = %q, want Dusk", recipe.IngredientNames[29185])
+	}
+	if len(recipe.UnresolvedIngredients) != 1 || recipe.UnresolvedIngredients[0] != "1 Gifts of Fortune" {
+		t.Errorf("unresolved ingredients = %v", recipe.UnresolvedIngredients)
+	}
+}
+
+func TestFilterMysticForgeRecipes(t *testing.T) {
+	recipes := []wiki.MysticForgeRecipe{
+		{Output: "Twilight", OutputItemID: 30704},
+		{Output: "Dusk", OutputItemID: 29185},
+		{Output: "Unknown"},
+	}
+
+	if got := filterMysticForgeRecipes(recipes, 0); len(got) != 3 {
+		t.Errorf("item ID 0 kept %d recipes, want 3", len(got))
+	}
+	got := filterMysticForgeRecipes(recipes, 30704)
+	if len(got) != 2 || got[0].Output != "Twilight" || got[1].Output != "Unknown" {
+		t.Errorf("filter(30704) = %+v", got)
+	}
+}
+
+func TestForgeOutputCandidate(t *testing.T) {
+	tests := []struct {
+		item gw2api.Item
+		want bool
+	}{
+		{gw2api.Item{Name: "Mithril Ore", Type: "CraftingMaterial"}, false},
+		{gw2api.Item{Name: "Mystic Coin", Type: "Trophy"}, false},
+		{gw2api.Item{Name: "Mystic Clover", Type: "CraftingMaterial", Flags: []string{"AccountBound"}}, true},
+		{gw2api.Item{Name: "Gift of Fortune", Type: "Trophy", Flags: []string{"AccountBound"}}, true},
+		{gw2api.Item{Name: "Dusk", Type: "Weapon"}, true},
+	}
+	for _, tt := range tests {
+		if got := forgeOutputCandidate(tt.item); got != tt.want {
+			t.Errorf("forgeOutputCandidate(%s) = %v, want %v", tt.item.Name, got, tt.want)
+		}
+	}
+}
+
+func TestMysticForgeLookupSkipsBasics(t *testing.T) {
+	s, api := newFakeAPIServer(t)
+	ctx := context.Background()
+	wikiRequests := func() int {
+		n := 0
+		for _, path := range api.requests {
+			if strings.HasPrefix(path, "wiki:") {
+				n++
+			}
+		}
+		return n
+	}
+
+	// A tradeable trophy is bought, so the wiki is not asked
+	if recipe, err := s.mysticForgeLookup(ctx, 19976); recipe != nil || err != nil {
+		t.Fatalf("mysticForgeLookup(Mystic Coin) = %+v, %v; want nil, nil", recipe, err)
+	}
+	if n := wikiRequests(); n != 0 {
+		t.Errorf("Mystic Coin lookup made %d wiki requests, want 0", n)
+	}
+
+	// An account bound material is looked up once, and finding no recipe is cached
+	if recipe, err := s.mysticForgeLookup(ctx, 19675); recipe != nil || err != nil {
+		t.Fatalf("mysticForgeLookup(Mystic Clover) = %+v, %v; want nil, nil", recipe, err)
+	}
+	if wikiRequests() == 0 {
+		t.Error("Mystic Clover lookup made no wiki request")
+	}
+	var cached []wiki.MysticForgeRecipe
+	if !s.cache.GetJSON(s.cache.GetMysticForgeRecipesKey(19675), &cached) || len(cached) != 0 {
+		t.Errorf("Expected no Mystic Forge recipes to be cached for Mystic Clover, got %v", cached)
+	}
+}
+
+func TestAchievementPoints(t *testing.T) {
+	tiered := gw2api.Achievement{Tiers: []gw2api.AchievementTier{{Count: 1, Points: 5}, {Count: 5, Points: 5}, {Count: 10, Points: 10}}}
+	repeatable := gw2api.Achievement{
+		Flags:    []string{"Repeatable"},
+		PointCap: 50,
+		Tiers:    []gw2api.AchievementTier{{Count: 10, Points: 10}},
+	}
+
+	tests := []struct {
+		name         string
+		ach          gw2api.Achievement
+		progress     *gw2api.AccountAchievement
+		wantEarned   int
+		wantPossible int
+	}{
+		{"not started", tiered, nil, 0, 20},
+		{"partial tiers", tiered, &gw2api.AccountAchievement{Current: 6, Max: 10}, 10, 20},
+		{"done", tiered, &gw2api.AccountAchievement{Current: 10, Max: 10, Done: true}, 20, 20},
+		{"repeatable under cap", repeatable, &gw2api.AccountAchievement{Current: 3, Max: 10, Repeated: 2}, 20, 50},
+		{"repeatable capped", repeatable, &gw2api.AccountAchievement{Done: true, Repeated: 9}, 50, 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			earned, possible := achievementPoints(tt.ach, tt.progress)
+			if earned != tt.wantEarned || possible != tt.wantPossible {
+				t.Errorf("achievementPoints() = (%d, %d), want (%d, %d)", earned, possible, tt.wantEarned, tt.wantPossible)
+			}
+		})
+	}
+}
+
+func TestMatchAchievementCategories(t *testing.T) {
+	categories := []gw2api.AchievementCategory{
+		{ID: 1, Name: "Slayer"},
+		{ID: 75, Name: "Collections"},
+		{ID: 97, Name: "Legendary Collections"},
+	}
+
+	tests := []struct {
+		filter string
+		want   []int
+	}{
+		{"", []int{1, 75, 97}},
+		{"97", []int{97}},
+		{"collections", []int{75}},
+		{"collect", []int{75, 97}},
+		{"42", nil},
+		{"dungeon", nil},
+	}
+	for _, tt := range tests {
+		got := matchAchievementCategories(categories, tt.filter)
+		if len(got) != len(tt.want) {
+			t.Errorf("matchAchievementCategories(%q) returned %d categories, want %d", tt.filter, len(got), len(tt.want))
+			continue
+		}
+		for i, c := range got {
+			if c.ID != tt.want[i] {
+				t.Errorf("matchAchievementCategories(%q)[%d] = %d, want %d", tt.filter, i, c.ID, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestBuildAchievementProgress(t *testing.T) {
+	groups := []gw2api.AchievementGroup{{ID: "A", Name: "General", Categories: []int{1, 2}}}
+	categories := []gw2api.AchievementCategory{
+		{ID: 1, Name: "Slayer", Achievements: []int{10, 11, 12}},
+		{ID: 2, Name: "Daily", Achievements: []int{20, 10}},
+	}
+	tier := []gw2api.AchievementTier{{Count: 10, Points: 10}}
+	achievements := map[int]gw2api.Achievement{
+		10: {ID: 10, Name: "Done", Tiers: tier},
+		11: {ID: 11, Name: "Almost", Tiers: tier},
+		12: {ID: 12, Name: "Started", Tiers: tier},
+		20: {ID: 20, Name: "Daily Thing", Flags: []string{"Daily"}, Tiers: tier},
+	}
+	progress := map[int]*gw2api.AccountAchievement{
+		10: {ID: 10, Current: 10, Max: 10, Done: true},
+		11: {ID: 11, Current: 9, Max: 10},
+		12: {ID: 12, Current: 2, Max: 10},
+		20: {ID: 20, Current: 9, Max: 10},
+	}
+
+	result := buildAchievementProgress(groups, categories, achievements, progress, false, 1)
+	if result.PointsEarned != 10 || result.PointsRemaining != 20 {
+		t.Errorf("points = %d earned / %d remaining, want 10 / 20", result.PointsEarned, result.PointsRemaining)
+	}
+	if len(result.Categories) != 2 {
+		t.Fatalf("got %d categories, want 2", len(result.Categories))
+	}
+	slayer := result.Categories[0]
+	if slayer.Total != 3 || slayer.Done != 1 || slayer.Group != "General" || slayer.PointsRemaining != 20 {
+		t.Errorf("slayer summary = %+v", slayer)
+	}
+	if len(result.Nearest) != 1 || result.Nearest[0].ID != 11 || result.Nearest[0].Percent != 90 {
+		t.Errorf("nearest = %+v, want only achievement 11 at 90%%", result.Nearest)
+	}
+	if result.Achievements != nil {
+		t.Errorf("expected no detailed achievements without a category filter")
+	}
+
+	detailed := buildAchievementProgress(groups, categories[:1], achievements, progress, true, 10)
+	if len(detailed.Achievements) != 3 || len(detailed.Nearest) != 2 {
+		t.Errorf("detailed = %d achievements / %d nearest, want 3 / 2", len(detailed.Achievements), len(detailed.Nearest))
+	}
+}
+
+func TestAchievementBits(t *testing.T) {
+	ach := gw2api.Achievement{Bits: []gw2api.AchievementBit{
+		{Type: "Text", Text: "Visit the Grove"},
+		{Type: "Item", ID: 19675},
+		{Type: "Skin", ID: 7},
+		{Type: "Minipet", ID: 99},
+	}}
+	names := achievementBitNames{
+		items: map[int]string{19675: "Mystic Clover"},
+		skins: map[int]string{7: "Chaos Gloves"},
+		minis: map[int]string{},
+	}
+
+	done, remaining := achievementBits(ach, &gw2api.AccountAchievement{Bits: []int{0, 2}}, names)
+	if len(done) != 2 || done[0] != "Visit the Grove" || done[1] != "Chaos Gloves" {
+		t.Errorf("done = %v", done)
+	}
+	if len(remaining) != 2 || remaining[0] != "Mystic Clover" || remaining[1] != "Minipet 99" {
+		t.Errorf("remaining = %v", remaining)
+	}
+
+	done, remaining = achievementBits(ach, nil, names)
+	if len(done) != 0 || len(remaining) != 4 {
+		t.Errorf("not started: done = %v, remaining = %v", done, remaining)
+	}
+}
+
+func TestFindAchievementByName(t *testing.T) {
+	achievements := []gw2api.Achievement{
+		{ID: 1, Name: "Aurora: Awakening"},
+		{ID: 2, Name: "Aurora: Empowering"},
+		{ID: 3, Name: "Vision: Awakening"},
+		{ID: 4, Name: "Aurora"},
+	}
+
+	if ach, _ := findAchievementByName(achievements, "aurora"); ach == nil || ach.ID != 4 {
+		t.Errorf("exact match = %v, want ID 4", ach)
+	}
+	if ach, _ := findAchievementByName(achievements, "empower"); ach == nil || ach.ID != 2 {
+		t.Errorf("single partial match = %v, want ID 2", ach)
+	}
+	ach, candidates := findAchievementByName(achievements, "awakening")
+	if ach != nil || len(candidates) != 2 || candidates[0] != "Aurora: Awakening" {
+		t.Errorf("ambiguous match = %v / %v", ach, candidates)
+	}
+	if ach, candidates := findAchievementByName(achievements, "dusk"); ach != nil || len(candidates) != 0 {
+		t.Errorf("no match = %v / %v", ach, candidates)
+	}
+}
+
+func TestCollectionPieces(t *testing.T) {
+	ach := gw2api.Achievement{Bits: []gw2api.AchievementBit{
+		{Type: "Item", ID: 100},
+		{Type: "Item", ID: 101},
+		{Type: "Item", ID: 102},
+		{Type: "Skin", ID: 7},
+		{Type: "Minipet", ID: 9},
+		{Type: "Text", Text: "Talk to Tarir"},
+		{Type: "Skin", ID: 8},
+		{Type: "Skin", ID: 11},
+	}}
+	progress := &gw2api.AccountAchievement{Bits: []int{0}}
+	inv := crafting.Inventory{}
+	inv.Add(crafting.KindItem, 101, 1)
+	inv.Add(crafting.KindItem, 601, 1)
+	minis := map[int]gw2api.Mini{9: {ID: 9, ItemID: 500}}
+	sources := gw2api.SkinSources{8: {600, 601}, 11: {700, 701, 702}}
+	names := achievementBitNames{items: map[int]string{100: "A", 101: "B", 102: "C"}, skins: map[int]string{7: "S", 8: "T", 11: "U"}, minis: map[int]string{9: "M"}}
+
+	pieces := collectionPieces(ach, progress, map[int]bool{7: true}, nil, inv, minis, sources, names)
+	want := []string{pieceDone, pieceOwned, pieceMissing, pieceDone, pieceMissing, pieceMissing, pieceOwned, pieceMissing}
+	if len(pieces) != len(want) {
+		t.Fatalf("got %d pieces, want %d", len(pieces), len(want))
+	}
+	for i, p := range pieces {
+		if p.Status != want[i] {
+			t.Errorf("piece %d (%s) status = %q, want %q", i, p.Name, p.Status, want[i])
+		}
+	}
+	if pieces[4].ItemID != 500 {
+		t.Errorf("miniature piece item ID = %d, want 500", pieces[4].ItemID)
+	}
+
+	if pieces[6].ItemID != 601 {
+		t.Errorf("owned skin piece item ID = %d, want the owned source 601", pieces[6].ItemID)
+	}
+
+	// The missing skin is priced at its cheapest listed source
+	total, unpriced := priceCollection(pieces, map[int]int{102: 1500, 500: 250, 700: 900, 701: 400}, sources)
+	if total != 2150 {
+		t.Errorf("cost to finish = %d, want 2150", total)
+	}
+	if pieces[7].ItemID != 701 || pieces[7].UnitPrice != 400 {
+		t.Errorf("skin piece = %+v, want priced at source 701 for 400", pieces[7])
+	}
+	if len(unpriced) != 1 || unpriced[0] != "Talk to Tarir" {
+		t.Errorf("unpriced = %v, want [Talk to Tarir]", unpriced)
+	}
+	if pieces[2].PriceFormatted == "" || pieces[1].UnitPrice != 0 {
+		t.Errorf("prices applied to wrong pieces: %+v / %+v", pieces[1], pieces[2])
+	}
+}
+
+func testContinentMap() gw2api.ContinentMap {
+	return gw2api.ContinentMap{
+		ID:   15,
+		Name: "Queensdale",
+		PointsOfInterest: map[string]gw2api.PointOfInterest{
+			"1": {ID: 1, Name: "Shaemoor Waypoint", Type: "waypoint", ChatLink: "[&BO4AAAA=]"},
+			"2": {ID: 2, Name: "Shaemoor Garrison", Type: "landmark"},
+			"3": {ID: 3, Name: "Vista", Type: "vista"},
+			"4": {ID: 4, Name: "Instance Entrance", Type: "unlock"},
+		},
+		Tasks: map[string]gw2api.MapTask{
+			"10": {ID: 10, Objective: "Help Farmer Eda", Level: 1},
+		},
+		Sectors: map[string]gw2api.MapSector{
+			"20": {ID: 20, Name: "Shaemoor Fields", Level: 2},
+		},
+		SkillChallenges: []gw2api.SkillChallenge{{ID: "0-4"}, {ID: "0-1"}, {}},
+	}
+}
+
+func TestSearchLocations(t *testing.T) {
+	floor := &gw2api.ContinentFloor{Regions: map[string]gw2api.ContinentRegion{
+		"4": {ID: 4, Name: "Kryta", Maps: map[string]gw2api.ContinentMap{"15": testContinentMap()}},
+	}}
+
+	matches := searchLocations(floor, "Tyria", "shaemoor", "")
+	if len(matches) != 3 {
+		t.Fatalf("got %d matches, want 3", len(matches))
+	}
+	for _, m := range matches {
+		if m.Map != "Queensdale" || m.Region != "Kryta" || m.Continent != "Tyria" {
+			t.Errorf("unexpected map context in %+v", m)
+		}
+	}
+
+	matches = searchLocations(floor, "Tyria", "shaemoor", "waypoint")
+	if len(matches) != 1 || matches[0].ChatLink != "[&BO4AAAA=]" {
+		t.Errorf("waypoint filter = %+v", matches)
+	}
+
+	matches = searchLocations(floor, "Tyria", "farmer", "heart")
+	if len(matches) != 1 || matches[0].Level != 1 || matches[0].Name != "Help Farmer Eda" {
+		t.Errorf("heart search = %+v", matches)
+	}
+}
+
+func TestSortLocationMatches(t *testing.T) {
+	matches := []LocationMatch{
+		{Name: "Lion's Arch Aerodrome Waypoint", MapID: 50},
+		{Name: "Lion's Arch Aerodrome", MapID: 1155},
+		{Name: "Lion's Arch Aerodrome", MapID: 50},
+	}
+	sortLocationMatches(matches, "lion's arch aerodrome")
+	if matches[0].MapID != 50 || matches[1].MapID != 1155 || matches[2].Name != "Lion's Arch Aerodrome Waypoint" {
+		t.Errorf("sorted matches = %+v", matches)
+	}
+}
+
+func TestMapObjectives(t *testing.T) {
+	m := testContinentMap()
+	objectives := mapObjectives(&m, "Kryta", "Tyria")
+	if len(objectives.Waypoints) != 1 || len(objectives.PointsOfInterest) != 1 || len(objectives.Vistas) != 1 || len(objectives.Hearts) != 1 {
+		t.Errorf("objectives = %+v", objectives)
+	}
+	if len(objectives.HeroChallenges) != 3 || objectives.HeroChallenges[1].ID != "0-1" {
+		t.Errorf("hero challenges = %+v", objectives.HeroChallenges)
+	}
+}
+
+func TestMissingHeroChallenges(t *testing.T) {
+	m := testContinentMap()
+	done, missing := missingHeroChallenges(m.SkillChallenges, []string{"0-4", "9-9"})
+	if done != 1 {
+		t.Errorf("done = %d, want 1", done)
+	}
+	if len(missing) != 1 || missing[0].ID != "0-1" {
+		t.Errorf("missing = %+v, want [0-1]", missing)
+	}
+}
+
+func TestMapChestOpened(t *testing.T) {
+	chests := []string{"auric_basin_heros_choice_chest", "dragons_stand_heros_choice_chest"}
+	tests := []struct {
+		mapName string
+		want    bool
+	}{
+		{"Auric Basin", true},
+		{"Dragon's Stand", true},
+		{"Verdant Brink", false},
+		{"Auric", false},
+	}
+	for _, tt := range tests {
+		if got := mapChestOpened(tt.mapName, chests); got != tt.want {
+			t.Errorf("mapChestOpened(%q) = %v, want %v", tt.mapName, got, tt.want)
+		}
+	}
+}
+
+func TestFormatCountdown(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0m"},
+		{-time.Minute, "0m"},
+		{12*time.Minute + 20*time.Second, "12m"},
+		{65 * time.Minute, "1h 05m"},
+		{3 * time.Hour, "3h 00m"},
+	}
+	for _, tt := range tests {
+		if got := formatCountdown(tt.d); got != tt.want {
+			t.Errorf("formatCountdown(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestToUpcomingEvent(t *testing.T) {
+	now := time.Date(2026, 10, 18, 23, 0, 0, 0, time.UTC)
+	boss := schedule.Event{ID: "tequatl_the_sunless", Name: "Tequatl the Sunless", WorldBossID: "tequatl_the_sunless"}
+	meta := schedule.Event{ID: "octovine", Name: "Octovine", MapChestID: "auric_basin_heros_choice_chest"}
+	bosses := map[string]bool{"tequatl_the_sunless": true}
+	chests := map[string]bool{"auric_basin_heros_choice_chest": true}
+
+	active := toUpcomingEvent(schedule.Occurrence{Event: meta, Start: now.Add(-5 * time.Minute), End: now.Add(15 * time.Minute), Active: true}, now, bosses, chests)
+	if active.Countdown != "active, ends in 15m" || active.StartsInMinutes != 0 || !active.CompletedToday {
+		t.Errorf("active event = %+v", active)
+	}
+
+	tomorrow := toUpcomingEvent(schedule.Occurrence{Event: boss, Start: now.Add(75 * time.Minute), End: now.Add(90 * time.Minute)}, now, bosses, chests)
+	if tomorrow.Countdown != "in 1h 15m" || tomorrow.StartsInMinutes != 75 {
+		t.Errorf("upcoming event = %+v", tomorrow)
+	}
+	if tomorrow.CompletedToday {
+		t.Error("spawn after daily reset should not be marked completed")
+	}
+
+	today := toUpcomingEvent(schedule.Occurrence{Event: boss, Start: now.Add(30 * time.Minute), End: now.Add(45 * time.Minute)}, now, bosses, nil)
+	if !today.CompletedToday {
+		t.Error("spawn before daily reset should be marked completed")
+	}
+}
+
+func TestHumanizeID(t *testing.T) {
+	tests := map[string]string{
+		"charged_quartz_crystal": "Charged Quartz Crystal",
+		"vale_guardian":          "Vale Guardian",
+		"ac_story":               "Ac Story",
+		"":                       "",
+	}
+	for id, want := range tests {
+		if got := humanizeID(id); got != want {
+			t.Errorf("humanizeID(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
+
+func TestWizardsVaultChecklist(t *testing.T) {
+	progress := &gw2api.WizardsVaultProgress{
+		MetaProgressCurrent: 1, MetaProgressComplete: 4,
+		Objectives: []gw2api.WizardsVaultObjective{
+			{ID: 1, Title: "Complete 3 events", Track: "PvE", Acclaim: 10, ProgressCurrent: 3, ProgressComplete: 3},
+			{ID: 2, Title: "Gather 10 plants", Track: "PvE", Acclaim: 10, ProgressCurrent: 4, ProgressComplete: 10},
+		},
+	}
+	items := wizardsVaultChecklist(progress)
+	if len(items) != 3 {
+		t.Fatalf("got %d items, want 3", len(items))
+	}
+	if !items[0].Done || items[0].Detail != "PvE, 3/3, 10 acclaim, not claimed" {
+		t.Errorf("items[0] = %+v", items[0])
+	}
+	if items[1].Done {
+		t.Errorf("items[1] should not be done")
+	}
+	if items[2].ID != "meta" || items[2].Done || items[2].Detail != "1/4 objectives" {
+		t.Errorf("meta item = %+v", items[2])
+	}
+}
+
+func TestDungeonAndRaidChecklist(t *testing.T) {
+	dungeons := []gw2api.Dungeon{{ID: "ascalonian_catacombs", Paths: []gw2api.DungeonPath{
+		{ID: "ac_story", Type: "Story"}, {ID: "hodgins", Type: "Explorable"},
+	}}}
+	items := dungeonChecklist(dungeons, map[string]bool{"hodgins": true})
+	section := newChecklistSection("dungeons", "daily", items)
+	if section.Total != 2 || section.Done != 1 {
+		t.Errorf("dungeons done/total = %d/%d, want 1/2", section.Done, section.Total)
+	}
+	if items[1].Name != "Ascalonian Catacombs: Hodgins" || !items[1].Done {
+		t.Errorf("items[1] = %+v", items[1])
+	}
+
+	items = raidChecklist(testRaids(), map[string]bool{"vale_guardian": true})
+	if len(items) != 3 || !items[0].Done || items[1].Done {
+		t.Errorf("raid items = %+v", items)
+	}
+	if items[0].Detail != "Forsaken Thicket, Spirit Vale" {
+		t.Errorf("raid detail = %q", items[0].Detail)
+	}
+}
+
+// testRaids returns a small raid with one wing of a boss, a checkpoint and a boss
+func testRaids() []gw2api.Raid {
+	return []gw2api.Raid{{ID: "forsaken_thicket", Wings: []gw2api.RaidWing{{ID: "spirit_vale", Events: []gw2api.RaidEvent{
+		{ID: "vale_guardian", Type: "Boss"},
+		{ID: "spirit_woods", Type: "Checkpoint"},
+		{ID: "gorseval", Type: "Boss"},
+	}}}}}
+}
+
+func TestChecklistFromIDs(t *testing.T) {
+	items := checklistFromIDs([]string{"lump_of_mithrillium", "spool_of_silk_weaving_thread"}, map[string]bool{"lump_of_mithrillium": true})
+	section := newChecklistSection("dailycrafting", "daily", items)
+	if section.Done != 1 || section.Total != 2 {
+		t.Errorf("done/total = %d/%d, want 1/2", section.Done, section.Total)
+	}
+	if empty := newChecklistSection("mapchests", "daily", nil); empty.Items == nil {
+		t.Error("empty section items should be non-nil")
+	}
+}
+
+func TestBuildRaidStatus(t *testing.T) {
+	status, remaining, total := buildRaidStatus(testRaids(), map[string]bool{"vale_guardian": true, "spirit_woods": true})
+	if total != 2 || remaining != 1 {
+		t.Errorf("remaining/total = %d/%d, want 1/2", remaining, total)
+	}
+	if len(status) != 1 || len(status[0].Wings) != 1 {
+		t.Fatalf("status = %+v", status)
+	}
+	wing := status[0].Wings[0]
+	if wing.Name != "Spirit Vale" || wing.Cleared != 2 || wing.Total != 3 {
+		t.Errorf("wing = %+v", wing)
+	}
+	if wing.Encounters[2].Cleared || wing.Encounters[2].Name != "Gorseval" {
+		t.Errorf("gorseval = %+v", wing.Encounters[2])
+	}
+}
+
+func TestBuildDungeonStatus(t *testing.T) {
+	dungeons := []gw2api.Dungeon{{ID: "caudecus_manor", Paths: []gw2api.DungeonPath{
+		{ID: "cm_story", Type: "Story"}, {ID: "asura", Type: "Explorable"}, {ID: "seraph", Type: "Explorable"},
+	}}}
+	status, remaining, total := buildDungeonStatus(dungeons, map[string]bool{"asura": true})
+	if total != 3 || remaining != 2 {
+		t.Errorf("remaining/total = %d/%d, want 2/3", remaining, total)
+	}
+	if status[0].Name != "Caudecus Manor" || !status[0].Paths[1].Done {
+		t.Errorf("status = %+v", status[0])
+	}
+}
+
+func TestDailyFractalsFromAchievements(t *testing.T) {
+	tier4, recommended := dailyFractalsFromAchievements([]string{
+		"Daily Tier 1 Nightmare",
+		"Daily Tier 4 Nightmare",
+		"Daily Recommended Fractal—Scale 40",
+		"Daily Tier 4 Snowblind",
+		"Daily Recommended Fractal—Scale 12",
+		"Fractal Adept",
+	})
+	if len(tier4) != 2 || tier4[0] != "Nightmare" || tier4[1] != "Snowblind" {
+		t.Errorf("tier4 = %v", tier4)
+	}
+	if len(recommended) != 2 || recommended[0] != 12 || recommended[1] != 40 {
+		t.Errorf("recommended = %v, want [12 40]", recommended)
+	}
+
+	tier4, recommended = dailyFractalsFromAchievements(nil)
+	if tier4 == nil || recommended == nil {
+		t.Error("empty results should be non-nil")
+	}
+}
+
+func TestDayInstabilities(t *testing.T) {
+	day := fractals.Day{Instabilities: map[int][]string{
+		98: {"Vengeance"},
+		76: {"Flux Bomb", "Afflicted"},
+	}}
+	all := dayInstabilities(day, 0)
+	if len(all) != 2 || all[0].Level != 76 || all[1].Level != 98 {
+		t.Errorf("dayInstabilities(0) = %+v", all)
+	}
+	one := dayInstabilities(day, 98)
+	if len(one) != 1 || one[0].Instabilities[0] != "Vengeance" {
+		t.Errorf("dayInstabilities(98) = %+v", one)
+	}
+	if none := dayInstabilities(day, 80); len(none) != 0 {
+		t.Errorf("dayInstabilities(80) = %+v, want empty", none)
+	}
+}
+
+func TestLearnDailyFractals(t *testing.T) {
+	s := newTestServer(t)
+	s.store = cache.NewStore(t.TempDir())
+
+	today := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+	learned := fractals.CycleDay{Tier4: []string{"Nightmare", "Snowblind", "Volcanic"}, Recommended: []int{12, 40, 96}}
+	s.learnDailyFractals(today, learned)
+
+	// The day is known at once, and a restarted server restores it from the data directory
+	if day, ok := s.fractals.Day(today.AddDate(0, 0, fractals.CycleLength)); !ok || !day.Learned {
+		t.Errorf("Day() a cycle later = %+v, %v; want the learned day", day, ok)
+	}
+	var saved []fractals.LearnedDay
+	if found, err := s.store.Load(learnedFractalsFile, &saved); err != nil || !found {
+		t.Fatalf("Load() = %v, %v; want the saved days", found, err)
+	}
+	rotation, err := fractals.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rotation.LoadLearned(saved); err != nil {
+		t.Fatal(err)
+	}
+	day, ok := rotation.Day(today)
+	if !ok || !slices.Equal(day.Tier4, learned.Tier4) {
+		t.Errorf("restored Day() = %+v, %v; want %v", day, ok, learned.Tier4)
+	}
+}
+
+func TestNewMasteryTrackProgress(t *testing.T) {
+	mastery := gw2api.Mastery{ID: 8, Name: "Fractal Attunement", Region: "Tyria", Levels: []gw2api.MasteryLevel{
+		{Name: "Fractal Attunement", PointCost: 1},
+		{Name: "Fractal Empowerment", PointCost: 2},
+		{Name: "Fractal Resistance", PointCost: 3},
+	}}
+
+	started := newMasteryTrackProgress(mastery, &gw2api.AccountMastery{ID: 8, Level: 1})
+	if started.Level != 2 || started.MaxLevel != 3 || len(started.Unlocked) != 2 {
+		t.Errorf("started = %+v", started)
+	}
+	if started.NextLevel != "Fractal Resistance" || started.NextPointCost != 3 {
+		t.Errorf("next = %q (%d)", started.NextLevel, started.NextPointCost)
+	}
+
+	unstarted := newMasteryTrackProgress(mastery, nil)
+	if unstarted.Level != 0 || unstarted.NextLevel != "Fractal Attunement" || len(unstarted.Unlocked) != 0 {
+		t.Errorf("unstarted = %+v", unstarted)
+	}
+
+	maxed := newMasteryTrackProgress(mastery, &gw2api.AccountMastery{ID: 8, Level: 2})
+	if maxed.Level != 3 || maxed.NextLevel != "" {
+		t.Errorf("maxed = %+v", maxed)
+	}
+}
+
+func TestFractalCategories(t *testing.T) {
+	groups := []gw2api.AchievementGroup{{ID: "g1", Name: "Fractals", Categories: []int{1, 2}}}
+	categories := []gw2api.AchievementCategory{
+		{ID: 1, Name: "Fractal Initiate"},
+		{ID: 2, Name: "Mistlock Observatory"},
+		{ID: 3, Name: "Daily Fractals"},
+		{ID: 4, Name: "Slayer"},
+	}
+	got := fractalCategories(groups, categories)
+	if len(got) != 3 || got[0].ID != 1 || got[1].ID != 2 || got[2].ID != 3 {
+		t.Errorf("fractalCategories() = %+v", got)
+	}
+}
+
+func TestMasteryRegionName(t *testing.T) {
+	tests := map[string]string{
+		"Maguuma":         "Heart of Thorns",
+		"Heart of Thorns": "Heart of Thorns",
+		"jade":            "End of Dragons",
+		"Mists":           "Mists",
+	}
+	for region, want := range tests {
+		if got := masteryRegionName(region); got != want {
+			t.Errorf("masteryRegionName(%q) = %q, want %q", region, got, want)
+		}
+	}
+}
+
+func TestBuildMasteryStatus(t *testing.T) {
+	masteries := []gw2api.Mastery{
+		{ID: 2, Name: "Itzel Lore", Region: "Maguuma", Order: 2, Levels: []gw2api.MasteryLevel{{Name: "Bouncing Mushrooms", PointCost: 1}, {Name: "Nuhoch Wallows", PointCost: 4}}},
+		{ID: 1, Name: "Gliding", Region: "Maguuma", Order: 1, Levels: []gw2api.MasteryLevel{{Name: "Gliding", PointCost: 1}, {Name: "Updraft Use", PointCost: 2}}},
+		{ID: 8, Name: "Fractal Attunement", Region: "Tyria", Order: 1, Levels: []gw2api.MasteryLevel{{Name: "Fractal Attunement", PointCost: 1}}},
+	}
+	progress := map[int]*gw2api.AccountMastery{1: {ID: 1, Level: 1}, 2: {ID: 2, Level: 0}}
+	totals := []gw2api.MasteryPointTotal{
+		{Region: "Heart of Thorns", Earned: 10, Spent: 4},
+		{Region: "Central Tyria", Earned: 3, Spent: 0},
+	}
+
+	result := buildMasteryStatus(masteries, progress, totals, "")
+	if len(result.Regions) != 2 || result.Regions[0].Region != "Central Tyria" || result.Regions[1].Region != "Heart of Thorns" {
+		t.Fatalf("regions = %+v", result.Regions)
+	}
+	if result.Earned != 13 || result.Spent != 4 || result.Unspent != 9 {
+		t.Errorf("totals = %d/%d/%d", result.Earned, result.Spent, result.Unspent)
+	}
+
+	hot := result.Regions[1]
+	if hot.Unspent != 6 || hot.PointsToComplete != 4 {
+		t.Errorf("hot unspent = %d, to complete = %d", hot.Unspent, hot.PointsToComplete)
+	}
+	if len(hot.Tracks) != 2 || hot.Tracks[0].Name != "Gliding" || hot.Tracks[1].NextLevel != "Nuhoch Wallows" {
+		t.Errorf("hot tracks = %+v", hot.Tracks)
+	}
+	if result.Regions[0].PointsToComplete != 1 {
+		t.Errorf("tyria to complete = %d, want 1", result.Regions[0].PointsToComplete)
+	}
+
+	filtered := buildMasteryStatus(masteries, progress, totals, "thorns")
+	if len(filtered.Regions) != 1 || filtered.Unspent != 6 {
+		t.Errorf("filtered = %+v", filtered)
+	}
+}
+
+func TestFilterAndBuildWardrobeStatus(t *testing.T) {
+	skins := []gw2api.Skin{
+		{ID: 1, Name: "Zojja's Mask", Type: "Armor", Details: []byte(`{"type":"Helm","weight_class":"Light"}`)},
+		{ID: 2, Name: "Acolyte Mask", Type: "Armor", Details: []byte(`{"type":"Helm","weight_class":"Light"}`)},
+		{ID: 3, Name: "Krytan Helm", Type: "Armor", Details: []byte(`{"type":"Helm","weight_class":"Heavy"}`)},
+		{ID: 4, Name: "Dawn", Type: "Weapon", Details: []byte(`{"type":"Greatsword"}`)},
+		{ID: 5, Name: "Bolt", Type: "Weapon", Details: []byte(`{"type":"Sword"}`)},
+	}
+
+	if got := filterSkins(skins, "armor", "", "light"); len(got) != 2 {
+		t.Errorf("filterSkins(armor, light) = %d skins, want 2", len(got))
+	}
+	if got := filterSkins(skins, "", "greatsword", ""); len(got) != 1 || got[0].ID != 4 {
+		t.Errorf("filterSkins(greatsword) = %+v", got)
+	}
+
+	result := buildWardrobeStatus(skins, map[int]bool{1: true, 4: true})
+	if result.Unlocked != 2 || result.Total != 5 || result.Percent != 40 {
+		t.Errorf("unlocked/total/percent = %d/%d/%v", result.Unlocked, result.Total, result.Percent)
+	}
+	if len(result.Groups) != 4 {
+		t.Fatalf("groups = %+v", result.Groups)
+	}
+	if g := result.Groups[0]; g.Type != "Armor" || g.Weight != "Heavy" || g.Total != 1 {
+		t.Errorf("groups[0] = %+v", g)
+	}
+	if g := result.Groups[1]; g.Weight != "Light" || g.Subtype != "Helm" || g.Unlocked != 1 || g.Total != 2 {
+		t.Errorf("groups[1] = %+v", g)
+	}
+	if result.MissingTotal != 3 || result.Missing[0].Name != "Krytan Helm" || result.Missing[1].Name != "Acolyte Mask" {
+		t.Errorf("missing = %+v", result.Missing)
+	}
+}
+
+func TestCheapestSkinSource(t *testing.T) {
+	sources := []SkinSource{
+		{ItemID: 1, SellPrice: 0},
+		{ItemID: 2, SellPrice: 5000},
+		{ItemID: 3, SellPrice: 1200},
+	}
+	if got := cheapestSkinSource(sources); got == nil || got.ItemID != 3 {
+		t.Errorf("cheapestSkinSource() = %+v, want item 3", got)
+	}
+	if got := cheapestSkinSource([]SkinSource{{ItemID: 1}}); got != nil {
+		t.Errorf("cheapestSkinSource() = %+v, want nil when nothing is listed", got)
+	}
+}
+
+func TestMatchDyes(t *testing.T) {
+	all := []gw2api.Color{
+		{ID: 1, Name: "Flame", BaseRGB: [3]int{128, 26, 26}, Categories: []string{"Red", "Vibrant", "Rare"},
+			Cloth: &gw2api.ColorComponent{RGB: [3]int{200, 20, 20}}, Metal: &gw2api.ColorComponent{RGB: [3]int{150, 60, 50}}, Item: 20370},
+		{ID: 2, Name: "Sky", BaseRGB: [3]int{40, 80, 200}, Categories: []string{"Blue", "Vibrant", "Common"},
+			Cloth: &gw2api.ColorComponent{RGB: [3]int{30, 90, 220}}},
+		{ID: 3, Name: "Blood", BaseRGB: [3]int{120, 10, 10}, Categories: []string{"Red", "Leather", "Uncommon"},
+			Cloth: &gw2api.ColorComponent{RGB: [3]int{150, 10, 10}}},
+	}
+
+	target := colors.RGB{210, 15, 15}
+	got := matchDyes(all, FindDyesArgs{}, "cloth", &target, map[int]bool{3: true})
+	if len(got) != 3 || got[0].Name != "Flame" || got[1].Name != "Blood" || got[2].Name != "Sky" {
+		t.Fatalf("matchDyes() order = %+v", got)
+	}
+	if got[0].Hex != "#c81414" || got[0].Distance == nil || *got[0].Distance >= *got[1].Distance {
+		t.Errorf("got[0] = %+v", got[0])
+	}
+	if got[0].Unlocked == nil || *got[0].Unlocked || !*got[1].Unlocked {
+		t.Error("unlocked flags not set from the unlock set")
+	}
+	if got[0].Hue != "Red" || got[0].Material != "Vibrant" || got[0].Rarity != "Rare" || got[0].ItemID != 20370 {
+		t.Errorf("categories = %+v", got[0])
+	}
+
+	// Fur has no component here, so the base colour is used
+	if fur := matchDyes(all[:1], FindDyesArgs{}, "fur", nil, nil); fur[0].Hex != "#801a1a" || fur[0].Distance != nil || fur[0].Unlocked != nil {
+		t.Errorf("fur = %+v", fur[0])
+	}
+
+	filtered := matchDyes(all, FindDyesArgs{Hue: "red", LockedOnly: true}, "cloth", nil, map[int]bool{3: true})
+	if len(filtered) != 1 || filtered[0].ID != 1 {
+		t.Errorf("filtered = %+v", filtered)
+	}
+	if byName := matchDyes(all, FindDyesArgs{}, "cloth", nil, nil); byName[0].Name != "Blood" {
+		t.Errorf("without a target dyes should sort by name, got %q first", byName[0].Name)
+	}
+}
+
+func TestEstimateObjectiveMinutes(t *testing.T) {
+	tests := []struct {
+		objective gw2api.WizardsVaultObjective
+		want      float64
+	}{
+		{gw2api.WizardsVaultObjective{Title: "Log In", ProgressComplete: 1}, 0.5},
+		{gw2api.WizardsVaultObjective{Title: "Salvage 20 Items", ProgressCurrent: 10, ProgressComplete: 20}, 1},
+		{gw2api.WizardsVaultObjective{Title: "Complete 3 Events", ProgressCurrent: 1, ProgressComplete: 3}, 8},
+		{gw2api.WizardsVaultObjective{Title: "Defeat a World Boss", ProgressComplete: 1}, 15},
+		{gw2api.WizardsVaultObjective{Title: "Something New", ProgressComplete: 4}, defaultObjectiveMinutes},
+	}
+	for _, tt := range tests {
+		if got := estimateObjectiveMinutes(tt.objective); got != tt.want {
+			t.Errorf("estimateObjectiveMinutes(%q) = %v, want %v", tt.objective.Title, got, tt.want)
+		}
+	}
+}
+
+func TestPlanObjectives(t *testing.T) {
+	periods := map[string]*gw2api.WizardsVaultProgress{
+		"daily": {Objectives: []gw2api.WizardsVaultObjective{
+			{ID: 1, Title: "Log In", Track: "PvE", Acclaim: 10, ProgressCurrent: 1, ProgressComplete: 1},
+			{ID: 2, Title: "Salvage 10 Items", Track: "PvE", Acclaim: 10, ProgressComplete: 10},
+			{ID: 3, Title: "Win a PvP Match", Track: "PvP", Acclaim: 10, ProgressComplete: 1},
+		}},
+		"weekly": {Objectives: []gw2api.WizardsVaultObjective{
+			{ID: 4, Title: "Complete a Fractal", Track: "PvE", Acclaim: 50, ProgressComplete: 1},
+		}},
+	}
+	got := planObjectives(periods, "")
+	if len(got) != 3 {
+		t.Fatalf("got %d objectives, want 3 (done ones skipped)", len(got))
+	}
+	if got[0].ID != 2 || got[0].AcclaimPerMinute != 10 || got[0].Period != "daily" {
+		t.Errorf("best = %+v", got[0])
+	}
+	if got[1].ID != 4 || got[2].ID != 3 {
+		t.Errorf("order = %d, %d, want 4, 3", got[1].ID, got[2].ID)
+	}
+	if pvp := planObjectives(periods, "pvp"); len(pvp) != 1 || pvp[0].ID != 3 {
+		t.Errorf("pvp = %+v", pvp)
+	}
+}
+
+func TestPlanListings(t *testing.T) {
+	limit, bought := 5, 5
+	oneLeft := 1
+	listings := []gw2api.WizardsVaultListing{
+		{ID: 1, ItemID: 100, ItemCount: 10, Cost: 20},                                            // unlimited, tradeable
+		{ID: 2, ItemID: 200, ItemCount: 1, Cost: 500, PurchaseLimit: &limit, Purchased: &bought}, // sold out
+		{ID: 3, ItemID: 300, ItemCount: 1, Cost: 30, PurchaseLimit: &limit, Purchased: &oneLeft}, // account bound
+		{ID: 4, ItemID: 400, ItemCount: 1, Cost: 100},                                            // tradeable, better value
+	}
+	prices := map[int]int{100: 50, 400: 3000}
+
+	got := planListings(listings, prices, 90)
+	if len(got) != 3 {
+		t.Fatalf("got %d listings, want 3 (sold out skipped)", len(got))
+	}
+	if got[0].ID != 4 || got[0].CoinsPerAcclaim != 30 || got[0].Affordable != 0 {
+		t.Errorf("got[0] = %+v", got[0])
+	}
+	if got[1].ID != 1 || got[1].CoinsPerAcclaim != 25 || got[1].Affordable != 4 || got[1].Remaining != nil {
+		t.Errorf("got[1] = %+v", got[1])
+	}
+	if got[2].ID != 3 || got[2].Remaining == nil || *got[2].Remaining != 4 || got[2].Affordable != 3 || got[2].CoinsPerAcclaim != 0 {
+		t.Errorf("got[2] = %+v", got[2])
+	}
+}
+
+func TestAstralAcclaimBalance(t *testing.T) {
+	wallet := &gw2api.WalletInfo{
+		Currencies: map[int]gw2api.Currency{1: {ID: 1, Name: "Coin"}, 63: {ID: 63, Name: "Astral Acclaim"}},
+		Entries:    []gw2api.WalletEntry{{ID: 1, Value: 100000}, {ID: 63, Value: 742}},
+	}
+	if got := astralAcclaimBalance(wallet); got != 742 {
+		t.Errorf("astralAcclaimBalance() = %d, want 742", got)
+	}
+	if got := astralAcclaimBalance(&gw2api.WalletInfo{}); got != 0 {
+		t.Errorf("astralAcclaimBalance(empty) = %d, want 0", got)
+	}
+}
+
+func testGuildUpgrades() []gw2api.GuildUpgrade {
+	return []gw2api.GuildUpgrade{
+		{ID: 1, Name: "Guild Hall", Type: "GuildHall"},
+		{ID: 2, Name: "Tavern Level 1", Type: "Unlock", RequiredLevel: 5, Prerequisites: []int{1}, Costs: []gw2api.GuildUpgradeCost{
+			{Type: "Item", Name: "Pile of Compost", Count: 50, ItemID: 100},
+			{Type: "Item", Name: "Bag of Coffee Beans", Count: 20, ItemID: 200},
+			{Type: "Currency", Name: "Aetherium", Count: 500},
+		}},
+		{ID: 3, Name: "Tavern Level 2", Type: "Unlock", RequiredLevel: 10, Prerequisites: []int{2}},
+		{ID: 4, Name: "Mine Level 1", Type: "Unlock", Prerequisites: []int{1}},
+		{ID: 5, Name: "Guild Banner", Type: "Consumable", Prerequisites: []int{1}},
+	}
+}
+
+func TestCompletedGuildUpgrades(t *testing.T) {
+	got := completedGuildUpgrades(testGuildUpgrades(), []int{1, 99})
+	if len(got) != 2 || got[0].Name != "Guild Hall" || got[0].Type != "GuildHall" {
+		t.Fatalf("got %+v", got)
+	}
+	if got[1].ID != 99 || got[1].Name != "" {
+		t.Errorf("unknown upgrade = %+v, want ID only", got[1])
+	}
+}
+
+func TestNextGuildUpgrades(t *testing.T) {
+	completed := map[int]bool{1: true}
+	next := nextGuildUpgrades(testGuildUpgrades(), completed, "")
+	if len(next) != 2 || next[0].ID != 2 || next[1].ID != 4 {
+		t.Fatalf("next = %+v, want tavern 1 and mine 1", next)
+	}
+	if named := nextGuildUpgrades(testGuildUpgrades(), completed, "TAVERN"); len(named) != 1 || named[0].ID != 2 {
+		t.Errorf("named = %+v", named)
+	}
+}
+
+func TestPlanGuildUpgrade(t *testing.T) {
+	tavern := testGuildUpgrades()[1]
+	got := planGuildUpgrade(tavern, 3, map[int]int{100: 80, 200: 5}, map[int]int{200: 150})
+
+	if got.LevelMet {
+		t.Error("LevelMet = true at guild level 3, want false")
+	}
+	if len(got.Costs) != 3 {
+		t.Fatalf("got %d costs, want 3", len(got.Costs))
+	}
+	if c := got.Costs[0]; c.Deposited != 50 || c.Remaining != 0 {
+		t.Errorf("compost = %+v, want fully deposited", c)
+	}
+	if c := got.Costs[1]; c.Deposited != 5 || c.Remaining != 15 || c.CoinsToBuy != 2250 {
+		t.Errorf("coffee = %+v", c)
+	}
+	if c := got.Costs[2]; c.Remaining != 500 || c.CoinsToBuy != 0 {
+		t.Errorf("aetherium = %+v", c)
+	}
+	if got.CoinsToBuy != 2250 || got.CoinsFormatted != gw2api.FormatCoins(2250) {
+		t.Errorf("CoinsToBuy = %d (%s)", got.CoinsToBuy, got.CoinsFormatted)
+	}
+	if len(got.Untradeable) != 0 {
+		t.Errorf("Untradeable = %v, want none", got.Untradeable)
+	}
+
+	unpriced := planGuildUpgrade(tavern, 5, nil, nil)
+	if !unpriced.LevelMet || len(unpriced.Untradeable) != 2 {
+		t.Errorf("unpriced = %+v", unpriced)
+	}
+}
+
+func TestEntriesSince(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	entries := []gw2api.GuildLogEntry{
+		{ID: 3, Time: now},
+		{ID: 2, Time: now.Add(-24 * time.Hour)},
+		{ID: 1, Time: now.Add(-72 * time.Hour)},
+	}
+	got := entriesSince(entries, now.Add(-24*time.Hour))
+	if len(got) != 2 || got[0].ID != 3 || got[1].ID != 2 {
+		t.Errorf("entriesSince() = %+v, want IDs 3 and 2", got)
+	}
+}
+
+func TestBuildGuildActivity(t *testing.T) {
+	at := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	entries := []gw2api.GuildLogEntry{
+		{ID: 12, Time: at, Type: "stash", User: "Thief.1234", Operation: "withdraw", ItemID: 300, Count: 1},
+		{ID: 11, Time: at, Type: "stash", User: "Alice.1234", Operation: "withdraw", Coins: 5000},
+		{ID: 10, Time: at, Type: "stash", User: "Alice.1234", Operation: "withdraw", ItemID: 100, Count: 2},
+		{ID: 9, Time: at, Type: "stash", User: "Bob.1234", Operation: "withdraw", ItemID: 100, Count: 1},
+		{ID: 8, Time: at, Type: "stash", User: "Alice.1234", Operation: "deposit", ItemID: 100, Count: 10, Coins: 100},
+		{ID: 7, Time: at, Type: "stash", User: "Bob.1234", Operation: "move", ItemID: 100, Count: 1},
+		{ID: 6, Time: at, Type: "treasury", User: "Bob.1234", ItemID: 200, Count: 25},
+		{ID: 5, Time: at, Type: "upgrade", User: "Alice.1234", Action: "completed", UpgradeID: 38},
+		{ID: 4, Time: at, Type: "upgrade", Action: "queued", UpgradeID: 39},
+		{ID: 3, Time: at, Type: "rank_change", User: "Bob.1234", ChangedBy: "Alice.1234", OldRank: "Member", NewRank: "Officer"},
+		{ID: 2, Time: at, Type: "kick", User: "Eve.1234", KickedBy: "Alice.1234"},
+		{ID: 1, Time: at, Type: "joined", User: "Thief.1234"},
+	}
+	prices := map[int]int{100: 2000, 200: 10, 300: 50000}
+	report := buildGuildActivity(entries, prices, map[int]string{300: "Gift of Mastery"}, map[int]string{38: "Tavern Level 1"})
+
+	if report.Entries != 12 {
+		t.Errorf("Entries = %d, want 12", report.Entries)
+	}
+	if len(report.Membership.Joined) != 1 || len(report.Membership.Kicked) != 1 || report.Membership.Kicked[0].By != "Alice.1234" {
+		t.Errorf("Membership = %+v", report.Membership)
+	}
+	if len(report.Membership.Ranks) != 1 || report.Membership.Ranks[0].NewRank != "Officer" {
+		t.Errorf("Ranks = %+v", report.Membership.Ranks)
+	}
+	if len(report.UpgradesCompleted) != 1 || report.UpgradesCompleted[0].Name != "Tavern Level 1" || report.UpgradesQueued != 1 {
+		t.Errorf("upgrades = %+v, queued %d", report.UpgradesCompleted, report.UpgradesQueued)
+	}
+
+	if len(report.Members) != 3 || report.Members[0].User != "Thief.1234" {
+		t.Fatalf("Members = %+v, want Thief.1234 first by value withdrawn", report.Members)
+	}
+	var alice, bob MemberActivity
+	for _, m := range report.Members {
+		switch m.User {
+		case "Alice.1234":
+			alice = m
+		case "Bob.1234":
+			bob = m
+		}
+	}
+	if alice.StashDeposits != 1 || alice.StashWithdrawals != 2 || alice.ValueDeposited != 20100 || alice.ValueWithdrawn != 9000 || alice.CoinsWithdrawn != 5000 {
+		t.Errorf("alice = %+v", alice)
+	}
+	if bob.StashWithdrawals != 1 || bob.TreasuryItems != 25 || bob.TreasuryValue != 250 {
+		t.Errorf("bob = %+v", bob)
+	}
+
+	if len(report.Flagged) != 1 {
+		t.Fatalf("Flagged = %+v, want only the Gift of Mastery withdrawal", report.Flagged)
+	}
+	if f := report.Flagged[0]; f.User != "Thief.1234" || f.ItemName != "Gift of Mastery" || f.Value != 50000 || len(f.Reasons) != 2 {
+		t.Errorf("flagged = %+v", f)
+	}
+}
+
+func TestLastSeen(t *testing.T) {
+	at := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	entries := []gw2api.GuildLogEntry{
+		{ID: 3, Time: at, Type: "stash", User: "Alice.1234"},
+		{ID: 2, Time: at.Add(-time.Hour), Type: "kick", User: "Eve.1234", KickedBy: "Bob.1234"},
+		{ID: 1, Time: at.Add(-2 * time.Hour), Type: "treasury", User: "Alice.1234"},
+	}
+	seen := lastSeen(entries)
+	if seen["Alice.1234"].ID != 3 {
+		t.Errorf("Alice last seen at entry %d, want 3", seen["Alice.1234"].ID)
+	}
+	if seen["Bob.1234"].Type != "kick" {
+		t.Errorf("Bob last activity = %q, want kick", seen["Bob.1234"].Type)
+	}
+	if _, ok := seen["Carol.1234"]; ok {
+		t.Error("Carol should not be seen")
+	}
+}
+
+func TestBuildGuildRoster(t *testing.T) {
+	at := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	joinedEarly, joinedLate := at.AddDate(-1, 0, 0), at.AddDate(0, 0, -2)
+	members := []gw2api.GuildMember{
+		{Name: "Alice.1234", Rank: "Leader", Joined: &joinedEarly},
+		{Name: "Bob.1234", Rank: "Member", Joined: &joinedLate},
+		{Name: "Carol.1234", Rank: "Member"},
+		{Name: "Dan.1234", Rank: "Member", Joined: &joinedEarly},
+		{Name: "Old.1234", Rank: "Retired"},
+	}
+	ranks := []gw2api.GuildRank{
+		{ID: "Member", Order: 2, Permissions: []string{"StashDeposit"}},
+		{ID: "Leader", Order: 1, Permissions: []string{"Admin", "StashDeposit"}},
+	}
+	names := map[string]string{"StashDeposit": "Deposit into Guild Bank"}
+	seen := map[string]gw2api.GuildLogEntry{
+		"Dan.1234": {Time: at, Type: "stash"},
+		"Bob.1234": {Time: at.Add(-time.Hour), Type: "joined"},
+	}
+
+	roster := buildGuildRoster(members, ranks, names, seen)
+	if len(roster) != 3 || roster[0].Rank != "Leader" || roster[1].Rank != "Member" || roster[2].Rank != "Retired" {
+		t.Fatalf("roster ranks = %+v", roster)
+	}
+	if p := roster[0].Permissions; len(p) != 2 || p[0] != "Admin" || p[1] != "Deposit into Guild Bank" {
+		t.Errorf("leader permissions = %v", p)
+	}
+	got := roster[1].Members
+	if len(got) != 3 || got[0].Name != "Dan.1234" || got[1].Name != "Bob.1234" || got[2].Name != "Carol.1234" {
+		t.Errorf("member order = %+v, want Dan, Bob, Carol", got)
+	}
+	if got[0].LastActivity != "stash" || got[2].LastSeen != nil {
+		t.Errorf("last seen = %+v / %+v", got[0], got[2])
+	}
+
+	newest := newestMembers(members, seen, 2)
+	if len(newest) != 2 || newest[0].Name != "Bob.1234" {
+		t.Errorf("newest = %+v, want Bob first", newest)
+	}
+}
+
+func TestAPIErrResult(t *testing.T) {
+	missing := fmt.Errorf("failed to fetch bank: %w", &gw2api.APIError{
+		Kind: gw2api.ErrMissingScope, Status: 403, Text: "requires scope inventories", Scope: "inventories",
+	})
+	result, _, _ := apiErrResult("Failed to get bank", missing)
+	text := result.Content[0].(*mcp.TextContent).Text
+	if !result.IsError || !strings.Contains(text, "Failed to get bank: failed to fetch bank") || !strings.Contains(text, "missing the `inventories` scope") {
+		t.Errorf("text = %q", text)
+	}
+	if result.Meta["gw2/error"] != "missing_scope" || result.Meta["gw2/status"] != 403 || result.Meta["gw2/scope"] != "inventories" || result.Meta["gw2/retryable"] != false {
+		t.Errorf("meta = %v", result.Meta)
+	}
+
+	limited := &gw2api.APIError{Kind: gw2api.ErrRateLimited, Status: 429, Text: "too many requests"}
+	result, _, _ = apiErrResult("Failed to get prices", limited)
+	if result.Meta["gw2/retryable"] != true || !strings.Contains(result.Content[0].(*mcp.TextContent).Text, "wait a minute") {
+		t.Errorf("rate limited result = %+v", result)
+	}
+
+	result, _, _ = apiErrResult("Failed to search", errors.New("timeout"))
+	if text := result.Content[0].(*mcp.TextContent).Text; text != "Failed to search: timeout" || result.Meta != nil {
+		t.Errorf("plain error result = %q, meta %v", text, result.Meta)
+	}
+}
+
+func TestDetailTypeValidation(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() (*mcp.CallToolResult, any, error)
+		want string
+	}{
+		{"guild detail type", func() (*mcp.CallToolResult, any, error) {
+			return s.handleGetGuildDetails(ctx, nil, GetGuildDetailsArgs{ID: "guild", Type: "bank"})
+		}, `invalid guild detail type "bank"`},
+		{"mount type", func() (*mcp.CallToolResult, any, error) {
+			return s.handleGetMountsInfo(ctx, nil, GetMountsInfoArgs{Type: "gliders"})
+		}, `invalid mount type "gliders"`},
+		{"mount skins without ids", func() (*mcp.CallToolResult, any, error) {
+			return s.handleGetMountsInfo(ctx, nil, GetMountsInfoArgs{Type: "skins"})
+		}, "ids parameter is required"},
+		{"dungeon type", func() (*mcp.CallToolResult, any, error) {
+			return s.handleGetDungeonsAndRaids(ctx, nil, GetDungeonsAndRaidsArgs{Type: "strikes", IDs: []string{"x"}})
+		}, `invalid type "strikes"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, out, _ := tt.call()
+			text := result.Content[0].(*mcp.TextContent).Text
+			if !result.IsError || out != nil || !strings.Contains(text, tt.want) {
+				t.Errorf("result = %q, want an error containing %q", text, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceID(t *testing.T) {
+	tests := []struct {
+		uri    string
+		want   int
+		wantOK bool
+	}{
+		{"gw2://items/19721", 19721, true},
+		{"gw2://items/", 0, false},
+		{"gw2://items/abc", 0, false},
+		{"gw2://items/-1", 0, false},
+		{"gw2://skins/19721", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := resourceID(tt.uri, "gw2://items/")
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("resourceID(%q) = %d, %v, want %d, %v", tt.uri, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestResourceTemplates(t *testing.T) {
+	session := connectTestClient(t, newTestServer(t))
+	ctx := context.Background()
+
+	templates, err := session.ListResourceTemplates(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]bool{}
+	for _, tmpl := range templates.ResourceTemplates {
+		got[tmpl.URITemplate] = true
+	}
+	for _, want := range []string{
+		"gw2://items/{id}", "gw2://recipes/{id}", "gw2://skins/{id}", "gw2://achievements/{id}", "gw2://wiki/{title}",
+		"gw2://characters/{name}", "gw2://account/unlocks/{+type}", "gw2://account/progress/{+type}",
+		"gw2://account/dailies/{type}", "gw2://guilds/{name}",
+	} {
+		if !got[want] {
+			t.Errorf("resource template %s is not registered", want)
+		}
+	}
+
+	if _, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "gw2://items/abc"}); err == nil {
+		t.Error("reading a non-numeric item ID should fail")
+	}
+	if _, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "gw2://account/bank"}); err == nil {
+		t.Error("reading the bank without an API key should fail")
+	}
+}
+
+func TestResolveItemNameFromIndex(t *testing.T) {
+	s := newTestServer(t)
+	if err := s.cache.SetJSON(s.cache.GetGameBuildKey(), gw2api.BuildInfo{ID: 1}, cache.GameBuildTTL); err != nil {
+		t.Fatal(err)
+	}
+	s.cache.Set(s.cache.GetItemIndexKey(1), &gw2api.ItemIndex{Build: 1, Items: []gw2api.IndexedItem{
+		{ID: 19976, Name: "Mystic Coin", Rarity: "Rare", Type: "Trophy"},
+		{ID: 30704, Name: "Twilight", Rarity: "Legendary", Type: "Weapon"},
+		{ID: 19648, Name: "Gift of Twilight", Rarity: "Legendary", Type: "Trophy"},
+		{ID: 19675, Name: "Mystic Clover", Rarity: "Rare", Type: "Trophy"},
+		{ID: 79501, Name: "Mystic Clover", Rarity: "Rare", Type: "Consumable"},
+	}}, cache.ItemIndexTTL)
+
+	tests := []struct {
+		name       string
+		filter     gw2api.ItemNameFilter
+		want       int
+		candidates []string
+	}{
+		{name: "Mystic Coin", want: 19976},
+		{name: "twilight", filter: gw2api.ItemNameFilter{Rarity: "Legendary"}, want: 30704},
+		{name: "mystic clover", filter: gw2api.ItemNameFilter{Type: "Trophy"}, want: 19675},
+		// Partial and typo-tolerant matches are offered rather than taken
+		{name: "mystik coin", candidates: []string{"Mystic Coin (ID 19976, Rare Trophy)"}},
+		{name: "gift of twi", candidates: []string{"Gift of Twilight (ID 19648, Legendary Trophy)"}},
+		// So are items sharing the name
+		{name: "Mystic Clover", candidates: []string{"ID 19675", "ID 79501"}},
+	}
+	for _, tt := range tests {
+		resolved, err := s.resolveItemName(context.Background(), tt.name, tt.filter)
+		if len(tt.candidates) > 0 {
+			if err == nil {
+				t.Errorf("resolveItemName(%q) = %+v, want an error listing candidates", tt.name, resolved)
+				continue
+			}
+			for _, c := range tt.candidates {
+				if !strings.Contains(err.Error(), c) {
+					t.Errorf("resolveItemName(%q) error = %v, want it to list %q", tt.name, err, c)
+				}
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("resolveItemName(%q) error = %v", tt.name, err)
+		}
+		if resolved.ID != tt.want || resolved.Wiki != nil {
+			t.Errorf("resolveItemName(%q) = %+v, want ID %d from the index", tt.name, resolved, tt.want)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// listOutput wraps list results, since structured content must be a JSON object
+type listOutput struct {
+	Items any `json:"items"`
+}
+
+// toolOutput is a tool's output schema and its resolved form for validating results
+type toolOutput struct {
+	typ      reflect.Type
+	schema   *jsonschema.Schema
+	resolved *jsonschema.Resolved
+}
+
+// newToolOutput derives the output schema of a tool whose results are of type t
+func newToolOutput(t reflect.Type) (*toolOutput, error) {
+	schema, err := outputSchemaFor(t)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := schema.Resolve(nil)
+	if err != nil {
+		return nil, fmt.Errorf("resolving output schema for %v: %w", t, err)
+	}
+	return &toolOutput{typ: t, schema: schema, resolved: resolved}, nil
+}
+
+// structured turns a handler's result into the structured content sent with it, and
+// checks it against the output schema
+func (o *toolOutput) structured(v any) (json.RawMessage, error) {
+	data, err := json.Marshal(structuredOutput(v))
+	if err != nil {
+		return nil, err
+	}
+	var instance map[string]any
+	if err := json.Unmarshal(data, &instance); err != nil {
+		return nil, fmt.Errorf("structured content is not an object: %w", err)
+	}
+	if err := o.resolved.Validate(instance); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// structuredOutput wraps list results in an {"items": [...]} object; raw API responses
+// are wrapped the same way when they are arrays
+func structuredOutput(v any) any {
+	if raw, ok := v.(json.RawMessage); ok {
+		if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+			return listOutput{Items: raw}
+		}
+		return raw
+	}
+	t := reflect.TypeOf(v)
+	if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		return listOutput{Items: v}
+	}
+	return v
+}
+
+// outputSchemaFor derives the JSON schema of a tool's results from their Go type. Lists
+// are wrapped as structuredOutput wraps them, nested lists and maps may be null as Go
+// encodes nil ones, maps with integer keys become objects, recursive types such as
+// crafting trees are referenced from $defs and raw JSON accepts anything.
+func outputSchemaFor(t reflect.Type) (*jsonschema.Schema, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	opts := &jsonschema.ForOptions{TypeSchemas: map[reflect.Type]*jsonschema.Schema{
+		reflect.TypeFor[json.RawMessage](): {},
+	}}
+	types := &schemaTypes{seen: map[reflect.Type]bool{}, visiting: map[reflect.Type]bool{}, recursive: map[reflect.Type]bool{}}
+	types.collect(t)
+
+	for r := range types.recursive {
+		opts.TypeSchemas[r] = &jsonschema.Schema{Ref: "#/$defs/" + r.Name()}
+	}
+	for _, m := range types.keyedMaps {
+		elem, err := jsonschema.ForType(m.Elem(), opts)
+		if err != nil {
+			return nil, fmt.Errorf("deriving output schema for %v: %w", m, err)
+		}
+		opts.TypeSchemas[m] = &jsonschema.Schema{Type: "object", AdditionalProperties: elem}
+	}
+	defs := map[string]*jsonschema.Schema{}
+	for r := range types.recursive {
+		def, err := jsonschema.ForType(exportedFields(r), opts)
+		if err != nil {
+			return nil, fmt.Errorf("deriving output schema for %v: %w", r, err)
+		}
+		allowNullCollections(def)
+		// Recursive structs are reached through pointers, which may be nil
+		def.Types, def.Type = []string{"null", def.Type}, ""
+		defs[r.Name()] = def
+	}
+
+	schema, err := jsonschema.ForType(t, opts)
+	if err != nil {
+		return nil, fmt.Errorf("deriving output schema for %v: %w", t, err)
+	}
+	for _, child := range childSchemas(schema) {
+		allowNullCollections(child)
+	}
+	if schema.Type == "array" {
+		allowNullCollections(schema)
+		schema = &jsonschema.Schema{
+			Type:                 "object",
+			Properties:           map[string]*jsonschema.Schema{"items": schema},
+			Required:             []string{"items"},
+			AdditionalProperties: &jsonschema.Schema{Not: &jsonschema.Schema{}},
+		}
+	}
+	if schema.Type != "object" {
+		return nil, fmt.Errorf("output schema for %v has type %q, not object", t, schema.Type)
+	}
+	if len(defs) > 0 {
+		schema.Defs = defs
+	}
+	return schema, nil
+}
+
+// schemaTypes collects the types reachable from a result type that jsonschema cannot
+// derive on its own: maps with non-string keys, which encoding/json writes as objects,
+// and structs that contain themselves
+type schemaTypes struct {
+	seen      map[reflect.Type]bool
+	visiting  map[reflect.Type]bool
+	recursive map[reflect.Type]bool
+	// keyedMaps are in dependency order, inner maps first
+	keyedMaps []reflect.Type
+}
+
+func (c *schemaTypes) collect(t reflect.Type) {
+	if c.visiting[t] {
+		c.recursive[t] = true
+		return
+	}
+	if c.seen[t] {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		c.collect(t.Elem())
+	case reflect.Map:
+		c.collect(t.Elem())
+		if t.Key().Kind() != reflect.String && !slices.Contains(c.keyedMaps, t) {
+			c.keyedMaps = append(c.keyedMaps, t)
+		}
+	case reflect.Struct:
+		c.visiting[t] = true
+		for _, field := range reflect.VisibleFields(t) {
+			if field.IsExported() && !field.Anonymous {
+				c.collect(field.Type)
+			}
+		}
+		delete(c.visiting, t)
+		c.seen[t] = true
+	}
+}
+
+// exportedFields returns an unnamed struct type with t's encoded fields, so the schema of
+// a recursive struct can be derived without its own $ref standing in for it
+func exportedFields(t reflect.Type) reflect.Type {
+	var fields []reflect.StructField
+	for _, field := range reflect.VisibleFields(t) {
+		if field.IsExported() && !field.Anonymous {
+			fields = append(fields, reflect.StructField{Name: field.Name, Type: field.Type, Tag: field.Tag})
+		}
+	}
+	return reflect.StructOf(fields)
+}
+
+// allowNullCollections lets the lists and maps in a schema be null
+func allowNullCollections(s *jsonschema.Schema) {
+	if s == nil {
+		return
+	}
+	isMap := s.Type == "object" && s.Properties == nil && s.AdditionalProperties != nil && s.AdditionalProperties.Not == nil
+	if s.Type == "array" || isMap {
+		s.Types = []string{"null", s.Type}
+		s.Type = ""
+	}
+	for _, child := range childSchemas(s) {
+		allowNullCollections(child)
+	}
+}
+
+// childSchemas returns the schemas of an object's properties and values or a list's items
+func childSchemas(s *jsonschema.Schema) []*jsonschema.Schema {
+	var children []*jsonschema.Schema
+	for _, p := range s.Properties {
+		children = append(children, p)
+	}
+	if s.Items != nil {
+		children = append(children, s.Items)
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Not == nil {
+		children = append(children, s.AdditionalProperties)
+	}
+	return children
+}
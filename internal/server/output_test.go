@@ -0,0 +1,179 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// sampleValue fills every field, list and map reachable from t so that validating it
+// exercises the whole schema; recursive types stop after a few levels
+func sampleValue(t reflect.Type, depth int) reflect.Value {
+	v := reflect.New(t).Elem()
+	if depth > 4 {
+		return v
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		v.Set(sampleValue(t.Elem(), depth+1).Addr())
+	case reflect.Slice:
+		v.Set(reflect.Append(reflect.MakeSlice(t, 0, 1), sampleValue(t.Elem(), depth+1)))
+	case reflect.Map:
+		v.Set(reflect.MakeMap(t))
+		v.SetMapIndex(sampleValue(t.Key(), depth+1), sampleValue(t.Elem(), depth+1))
+	case reflect.Struct:
+		if t == reflect.TypeFor[time.Time]() {
+			v.Set(reflect.ValueOf(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)))
+			break
+		}
+		for i := range t.NumField() {
+			if t.Field(i).IsExported() {
+				v.Field(i).Set(sampleValue(t.Field(i).Type, depth+1))
+			}
+		}
+	case reflect.Interface:
+		v.Set(reflect.ValueOf("sample"))
+	case reflect.String:
+		v.SetString("sample")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(7)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(7)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1.5)
+	}
+	if t == reflect.TypeFor[json.RawMessage]() {
+		v.SetBytes([]byte(`{"id":1}`))
+	}
+	return v
+}
+
+func TestToolOutputSchemas(t *testing.T) {
+	s := newTestServer(t)
+	if len(s.tools) == 0 {
+		t.Fatal("no tools registered")
+	}
+	for name, reg := range s.tools {
+		if reg.output == nil || reg.output.schema.Type != "object" {
+			t.Errorf("%s: output schema must be an object", name)
+			continue
+		}
+		zero := reflect.New(reg.output.typ).Elem()
+		if zero.Kind() == reflect.Pointer {
+			zero = reflect.New(reg.output.typ.Elem())
+		}
+		if _, err := reg.output.structured(zero.Interface()); err != nil {
+			t.Errorf("%s: zero %v does not match its schema: %v", name, reg.output.typ, err)
+		}
+		if _, err := reg.output.structured(sampleValue(reg.output.typ, 0).Interface()); err != nil {
+			t.Errorf("%s: sample %v does not match its schema: %v", name, reg.output.typ, err)
+		}
+	}
+}
+
+func TestToolOutputRejectsMismatch(t *testing.T) {
+	s := newTestServer(t)
+	if _, err := s.tools["get_tp_prices"].output.structured(map[string]int{"id": 1}); err == nil {
+		t.Error("get_tp_prices accepted an object that is not a price list")
+	}
+	if _, err := s.tools["get_wallet"].output.structured([]int{1, 2}); err == nil {
+		t.Error("get_wallet accepted a list")
+	}
+}
+
+func TestStructuredOutput(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+		want string
+	}{
+		{"object", map[string]int{"a": 1}, `{"a":1}`},
+		{"list", []int{1, 2}, `{"items":[1,2]}`},
+		{"nil list", []string(nil), `{"items":null}`},
+		{"raw list", json.RawMessage(` [1,2]`), `{"items":[1,2]}`},
+		{"raw object", json.RawMessage(`{"a":1}`), `{"a":1}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(structuredOutput(tt.v))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("structuredOutput() = %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputSchemaForRecursiveTypes(t *testing.T) {
+	schema, err := outputSchemaFor(reflect.TypeFor[LegendaryPlannerResult]())
+	if err != nil {
+		t.Fatalf("outputSchemaFor() error = %v", err)
+	}
+	if _, ok := schema.Defs["Node"]; !ok {
+		t.Errorf("crafting tree nodes should be defined once in $defs, got %v", schema.Defs)
+	}
+}
+
+// connectTestClient connects an in-memory client session to s
+func connectTestClient(t *testing.T, s *MCPServer) *mcp.ClientSession {
+	t.Helper()
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := s.mcp.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+	client := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "v0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+func TestCallToolReturnsStructuredContent(t *testing.T) {
+	session := connectTestClient(t, newTestServer(t))
+	ctx := context.Background()
+
+	tools, err := session.ListTools(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tool := range tools.Tools {
+		if tool.OutputSchema == nil {
+			t.Errorf("%s has no output schema", tool.Name)
+		}
+	}
+
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "get_upcoming_events",
+		Arguments: map[string]any{"limit": 2},
+	})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if res.IsError || len(res.Content) == 0 {
+		t.Fatalf("get_upcoming_events failed: %+v", res.Content)
+	}
+	data, err := json.Marshal(res.StructuredContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var events UpcomingEventsResult
+	if err := json.Unmarshal(data, &events); err != nil {
+		t.Fatalf("structured content is not an UpcomingEventsResult: %v", err)
+	}
+	if len(events.Events) != 2 {
+		t.Errorf("structured content has %d events, want 2", len(events.Events))
+	}
+}
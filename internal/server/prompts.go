@@ -0,0 +1,144 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// serverPrompt is a prompt in the catalogue and the instructions it expands into. Tools
+// are named in backticks, and only tools may be, so tests can check every name is
+// registered.
+type serverPrompt struct {
+	prompt *mcp.Prompt
+	expand func(args map[string]string) string
+}
+
+// prompts is the prompt catalogue, one entry per workflow described in the docs
+var prompts = []serverPrompt{
+	{
+		prompt: &mcp.Prompt{
+			Name:        "craft_or_buy",
+			Title:       "Craft or Buy",
+			Description: "Compare the cost of crafting an item from Trading Post materials with buying it outright",
+			Arguments: []*mcp.PromptArgument{
+				{Name: "item", Description: "Name of the item to craft or buy (e.g. 'Deldrimor Steel Ingot')", Required: true},
+				{Name: "quantity", Description: "Number of items wanted (default: 1)"},
+			},
+		},
+		expand: func(args map[string]string) string {
+			quantity := args["quantity"]
+			if quantity == "" {
+				quantity = "1"
+			}
+			return fmt.Sprintf(`I want %s of "%s". Is it cheaper to craft or to buy?
+
+1. Call `+"`get_item_recipe_by_name`"+` for "%[2]s" to get its recipes and ingredients. If it has no recipe, say so and only price the item itself.
+2. Price every ingredient with `+"`get_tp_prices`"+` using the ingredient item IDs, and the item itself with `+"`get_tp_price_by_name`"+`. Use the lowest sell listing as the cost to buy now and the highest buy order as the cost to wait for an order to fill.
+3. Multiply each ingredient price by its quantity and the number of items wanted, and total the crafting cost. Ingredients that cannot be traded (account bound, vendor or Mystic Forge only) have no price; list them separately instead of counting them as free.
+4. If I have an API key, check `+"`find_item_on_account`"+` for ingredients I already own and subtract them from what I need to buy.
+5. Answer with the cheaper option, the gold difference, and a table of ingredient costs. If crafting to sell, account for the 15%% Trading Post fees.`, quantity, args["item"])
+		},
+	},
+	{
+		prompt: &mcp.Prompt{
+			Name:        "daily_checklist",
+			Title:       "Daily Checklist",
+			Description: "Go through everything that resets today and what is still left to do",
+		},
+		expand: func(map[string]string) string {
+			return "Walk me through today's Guild Wars 2 dailies.\n\n" +
+				"1. Call `reset_checklist` for the done/not-done list of everything that resets, with the time left until each reset.\n" +
+				"2. Call `get_wizards_vault_objectives` for my daily Wizard's Vault objectives and their progress.\n" +
+				"3. Call `get_daily_fractals` for today's Tier 4 and recommended fractals, and the instabilities if its notes do not say they are unknown.\n" +
+				"4. Call `get_upcoming_events` with exclude_completed set for the next world bosses and meta events I have not done today.\n\n" +
+				"Summarise what is still open, ordered by how quickly it can be done, and group it by game mode. Leave out anything already completed."
+		},
+	},
+	{
+		prompt: &mcp.Prompt{
+			Name:        "weekly_reset_review",
+			Title:       "Weekly Reset Review",
+			Description: "Review what is left before the weekly reset: raids, strikes and weekly Wizard's Vault objectives",
+		},
+		expand: func(map[string]string) string {
+			return "Help me make the most of the week before the Monday 07:30 UTC reset.\n\n" +
+				"1. Call `raid_clears` for the raid wings and encounters I have not cleared this week, and the strike missions.\n" +
+				"2. Call `reset_checklist` for the weekly Wizard's Vault objectives still open and the time left until the reset.\n" +
+				"3. Call `wizards_vault_plan` for my Astral Acclaim, the rewards still purchasable, and the unfinished weekly objectives ranked by acclaim per minute.\n\n" +
+				"Summarise what is left as a short plan: the rewards at stake, how long each activity takes, and what to do first if I only have a few hours."
+		},
+	},
+	{
+		prompt: &mcp.Prompt{
+			Name:        "bank_cleanup",
+			Title:       "Bank Cleanup",
+			Description: "Find the valuable items in the bank and what to sell, deposit or delete",
+			Arguments: []*mcp.PromptArgument{
+				{Name: "min_value", Description: "Only suggest selling stacks worth at least this much (e.g. '50s', '1g'); default: any value"},
+			},
+		},
+		expand: func(args map[string]string) string {
+			threshold := "Suggest selling anything with a Trading Post value."
+			if v := args["min_value"]; v != "" {
+				threshold = fmt.Sprintf("Only suggest selling stacks worth at least %s.", v)
+			}
+			return "Help me clean up my Guild Wars 2 bank.\n\n" +
+				"1. Call `get_bank` for my bank contents.\n" +
+				"2. Call `get_items` with the item IDs to see which items are account bound or soulbound and cannot be sold.\n" +
+				"3. Call `get_tp_prices` with the remaining item IDs and value each stack at the highest buy order times its count.\n" +
+				"4. Call `get_materials` to check which bank items are crafting materials that could go into material storage instead.\n\n" +
+				threshold + " Report the most valuable stacks first, then the materials to deposit, then bound items that look safe to delete or salvage. Give totals in gold, silver and copper."
+		},
+	},
+	{
+		prompt: &mcp.Prompt{
+			Name:        "legendary_progress",
+			Title:       "Legendary Progress",
+			Description: "Check how far the account is from crafting a legendary and what the rest costs",
+			Arguments: []*mcp.PromptArgument{
+				{Name: "legendary", Description: "Name of the legendary item (e.g. 'Twilight', 'Aurora')", Required: true},
+			},
+		},
+		expand: func(args map[string]string) string {
+			return fmt.Sprintf(`How close am I to crafting "%s"?
+
+1. Call `+"`legendary_planner`"+` with target "%[1]s" for its recipe tree checked against what my account holds, and the Trading Post cost of what is missing.
+2. For the missing pieces that cannot be bought, such as gifts and collection items, use `+"`wiki_search`"+` to find how they are obtained.
+3. For missing materials I may hold on characters, call `+"`find_item_on_account`"+` to see where they are.
+
+Summarise the percentage done, the gold still needed to buy the tradeable part, and the account-bound steps left, in the order I should do them.`, args["legendary"])
+		},
+	},
+}
+
+// registerPrompts registers the prompt catalogue
+func (s *MCPServer) registerPrompts() {
+	for _, p := range prompts {
+		s.mcp.AddPrompt(p.prompt, promptHandler(p))
+	}
+}
+
+// promptHandler expands p with the request's arguments, which must include every
+// required one
+func promptHandler(p serverPrompt) mcp.PromptHandler {
+	return func(_ context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		args := map[string]string{}
+		for name, value := range req.Params.Arguments {
+			args[name] = strings.TrimSpace(value)
+		}
+		for _, arg := range p.prompt.Arguments {
+			if arg.Required && args[arg.Name] == "" {
+				return nil, fmt.Errorf("prompt %s requires the %s argument", p.prompt.Name, arg.Name)
+			}
+		}
+		return &mcp.GetPromptResult{
+			Description: p.prompt.Description,
+			Messages: []*mcp.PromptMessage{
+				{Role: "user", Content: &mcp.TextContent{Text: p.expand(args)}},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// promptToolRef matches a tool named in a prompt's instructions
+var promptToolRef = regexp.MustCompile("`([^`]+)`")
+
+func TestPromptsOnlyMentionRegisteredTools(t *testing.T) {
+	s := newTestServer(t)
+	for _, p := range prompts {
+		args := map[string]string{}
+		for _, arg := range p.prompt.Arguments {
+			args[arg.Name] = "Sample"
+		}
+		for _, withArgs := range []map[string]string{args, {}} {
+			text := p.expand(withArgs)
+			refs := promptToolRef.FindAllStringSubmatch(text, -1)
+			if len(refs) == 0 {
+				t.Errorf("%s mentions no tools", p.prompt.Name)
+			}
+			for _, ref := range refs {
+				if _, ok := s.tools[ref[1]]; !ok {
+					t.Errorf("%s mentions %q, which is not a registered tool", p.prompt.Name, ref[1])
+				}
+			}
+		}
+	}
+}
+
+func TestGetPrompt(t *testing.T) {
+	session := connectTestClient(t, newTestServer(t))
+	ctx := context.Background()
+
+	list, err := session.ListPrompts(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Prompts) != len(prompts) {
+		t.Errorf("ListPrompts() returned %d prompts, want %d", len(list.Prompts), len(prompts))
+	}
+
+	res, err := session.GetPrompt(ctx, &mcp.GetPromptParams{
+		Name:      "craft_or_buy",
+		Arguments: map[string]string{"item": "Deldrimor Steel Ingot", "quantity": "5"},
+	})
+	if err != nil {
+		t.Fatalf("GetPrompt() error = %v", err)
+	}
+	if len(res.Messages) != 1 {
+		t.Fatalf("GetPrompt() returned %d messages, want 1", len(res.Messages))
+	}
+	text, ok := res.Messages[0].Content.(*mcp.TextContent)
+	if !ok || !strings.Contains(text.Text, `I want 5 of "Deldrimor Steel Ingot"`) {
+		t.Errorf("craft_or_buy did not expand its arguments: %+v", res.Messages[0].Content)
+	}
+
+	if _, err := session.GetPrompt(ctx, &mcp.GetPromptParams{Name: "craft_or_buy"}); err == nil {
+		t.Error("craft_or_buy without an item should fail")
+	}
+}
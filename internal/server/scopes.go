@@ -0,0 +1,246 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/AlyxPink/gw2-mcp/internal/gw2api"
+)
+
+// apiKeyManagementURL is where players create API keys with more scopes
+const apiKeyManagementURL = "https://account.arena.net/applications"
+
+// toolAccess describes the API key a tool needs. Every key has the account scope, so it
+// is not listed.
+type toolAccess struct {
+	// keyOptional tools work without a key; the key only adds account data
+	keyOptional bool
+	// required scopes are those the tool cannot work without
+	required []string
+	// optional scopes only add part of the result; without them that part is skipped
+	optional []string
+}
+
+// toolScopes lists the API key needs of every tool that uses one; tools not listed only
+// read public data
+var toolScopes = map[string]toolAccess{
+	"achievement_progress":         {required: []string{"progression"}},
+	"collection_status":            {optional: []string{"progression", "unlocks", "inventories"}},
+	"find_dyes":                    {keyOptional: true, optional: []string{"unlocks"}},
+	"find_item_on_account":         {optional: []string{"inventories", "characters", "tradingpost"}},
+	"fractal_progress":             {required: []string{"progression"}},
+	"get_account":                  {},
+	"get_account_dailies":          {required: []string{"progression"}},
+	"get_account_progress":         {required: []string{"progression"}},
+	"get_account_unlocks":          {required: []string{"unlocks"}},
+	"get_bank":                     {required: []string{"inventories"}},
+	"get_characters":               {required: []string{"characters"}},
+	"get_guild_details":            {required: []string{"guilds"}},
+	"get_inventory":                {required: []string{"inventories"}},
+	"get_materials":                {required: []string{"inventories"}},
+	"get_token_info":               {},
+	"get_tp_delivery":              {required: []string{"tradingpost"}},
+	"get_tp_transactions":          {required: []string{"tradingpost"}},
+	"get_upcoming_events":          {keyOptional: true, optional: []string{"progression"}},
+	"get_wallet":                   {required: []string{"wallet"}},
+	"get_wizards_vault_listings":   {keyOptional: true, optional: []string{"progression"}},
+	"get_wizards_vault_objectives": {keyOptional: true, optional: []string{"progression"}},
+	"guild_activity_report":        {required: []string{"guilds"}},
+	"guild_roster_report":          {required: []string{"guilds"}},
+	"guild_upgrade_plan":           {required: []string{"guilds"}},
+	"legendary_planner":            {keyOptional: true, optional: []string{"inventories", "unlocks", "wallet"}},
+	"map_completion":               {keyOptional: true, optional: []string{"characters", "progression"}},
+	"mastery_status":               {required: []string{"progression"}},
+	"raid_clears":                  {required: []string{"progression"}},
+	"reset_checklist":              {required: []string{"progression"}},
+	"wardrobe_status":              {required: []string{"unlocks"}},
+	"wizards_vault_plan":           {required: []string{"wallet", "progression"}},
+}
+
+// toolRegistration keeps what is needed to register a tool again with a new description
+type toolRegistration struct {
+	description string
+	output      *toolOutput
+	register    func(description string, meta mcp.Meta)
+}
+
+// addTool registers a tool whose calls are checked against the API key's scopes first,
+// and remembers it so its description can be annotated when the key's scopes are known.
+// Out is the Go type of the tool's results; the tool's output schema is derived from it,
+// and results are sent as structured content alongside their text when they match it.
+func addTool[Out, In any](s *MCPServer, tool *mcp.Tool, handler mcp.ToolHandlerFor[In, any]) {
+	name := tool.Name
+	output, err := newToolOutput(reflect.TypeFor[Out]())
+	if err != nil {
+		panic(fmt.Sprintf("tool %s: %v", name, err))
+	}
+	guarded := func(ctx context.Context, req *mcp.CallToolRequest, args In) (*mcp.CallToolResult, any, error) {
+		if msg := s.toolAccessError(name); msg != "" {
+			kind := gw2api.ErrMissingScope
+			if s.gw2API.APIKey() == "" {
+				kind = gw2api.ErrInvalidKey
+			}
+			result, _, _ := errResult(msg)
+			result.Meta = mcp.Meta{"gw2/error": string(kind), "gw2/retryable": false}
+			return result, nil, nil
+		}
+		result, out, err := handler(ctx, req, args)
+		if err != nil || out == nil || (result != nil && result.IsError) {
+			return result, nil, err
+		}
+		structured, err := output.structured(out)
+		if err != nil {
+			s.logger.Warn("Tool result does not match its output schema; sending text only", "tool", name, "error", err)
+			return result, nil, nil
+		}
+		return result, structured, nil
+	}
+	reg := toolRegistration{
+		description: tool.Description,
+		output:      output,
+		register: func(description string, meta mcp.Meta) {
+			t := *tool
+			t.Description = description
+			t.Meta = meta
+			t.OutputSchema = output.schema
+			mcp.AddTool(s.mcp, &t, guarded)
+		},
+	}
+	s.tools[name] = reg
+	reg.register(s.toolDescription(name, reg.description), s.toolMeta(name))
+}
+
+// describeScopes renders scope names for messages, e.g. "the wallet and progression scopes"
+func describeScopes(scopes []string) string {
+	quoted := make([]string, len(scopes))
+	for i, scope := range scopes {
+		quoted[i] = "`" + scope + "`"
+	}
+	switch len(quoted) {
+	case 0:
+		return "the `account` scope"
+	case 1:
+		return "the " + quoted[0] + " scope"
+	}
+	return "the " + strings.Join(quoted[:len(quoted)-1], ", ") + " and " + quoted[len(quoted)-1] + " scopes"
+}
+
+// missingScopes returns the scopes the key's permissions lack
+func missingScopes(scopes []string, permissions map[string]bool) []string {
+	var missing []string
+	for _, scope := range scopes {
+		if !permissions[scope] {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// keyPermissions returns the configured key's scopes; ok is false when the key is set but
+// its scopes have not been checked, in which case calls are left to the GW2 API to refuse
+func (s *MCPServer) keyPermissions() (hasKey bool, permissions map[string]bool, ok bool) {
+	s.permissionsMu.RLock()
+	defer s.permissionsMu.RUnlock()
+	if s.gw2API.APIKey() == "" {
+		return false, nil, true
+	}
+	return true, s.permissions, s.permissions != nil
+}
+
+// toolAccessError explains why the configured key cannot run a tool, naming the exact
+// scopes missing, or returns "" when the tool can run
+func (s *MCPServer) toolAccessError(name string) string {
+	access, ok := toolScopes[name]
+	if !ok || access.keyOptional {
+		return ""
+	}
+	hasKey, permissions, known := s.keyPermissions()
+	if !hasKey {
+		return fmt.Sprintf("%s requires GW2_API_KEY with %s, but GW2_API_KEY is not configured. Create a key at %s",
+			name, describeScopes(access.required), apiKeyManagementURL)
+	}
+	if !known {
+		return ""
+	}
+	if missing := missingScopes(access.required, permissions); len(missing) > 0 {
+		return fmt.Sprintf("%s requires %s, which the configured API key is missing. Create a key with it at %s",
+			name, describeScopes(missing), apiKeyManagementURL)
+	}
+	return ""
+}
+
+// toolDescription annotates a tool's description with what the configured key cannot do
+func (s *MCPServer) toolDescription(name, description string) string {
+	access, ok := toolScopes[name]
+	if !ok {
+		return description
+	}
+	hasKey, permissions, known := s.keyPermissions()
+	switch {
+	case !hasKey && access.keyOptional:
+		return description
+	case !hasKey:
+		return description + " [Unavailable: GW2_API_KEY is not configured.]"
+	case !known:
+		return description
+	}
+	if missing := missingScopes(access.required, permissions); len(missing) > 0 {
+		return description + fmt.Sprintf(" [Unavailable: the API key is missing %s.]", describeScopes(missing))
+	}
+	if missing := missingScopes(access.optional, permissions); len(missing) > 0 {
+		return description + fmt.Sprintf(" [Limited: the API key is missing %s, so that part is skipped.]", describeScopes(missing))
+	}
+	return description
+}
+
+// toolMeta records a tool's scopes in its _meta so clients can filter on them
+func (s *MCPServer) toolMeta(name string) mcp.Meta {
+	access, ok := toolScopes[name]
+	if !ok {
+		return nil
+	}
+	meta := mcp.Meta{
+		"gw2/requiresKey":    !access.keyOptional,
+		"gw2/requiredScopes": append([]string{"account"}, access.required...),
+	}
+	if len(access.optional) > 0 {
+		meta["gw2/optionalScopes"] = access.optional
+	}
+	hasKey, permissions, known := s.keyPermissions()
+	if hasKey && known {
+		missing := missingScopes(append(slices.Clone(access.required), access.optional...), permissions)
+		meta["gw2/missingScopes"] = append([]string{}, missing...)
+	}
+	return meta
+}
+
+// refreshToolAccess looks up the configured key's scopes and registers every tool again
+// with descriptions annotated for what the key can do
+func (s *MCPServer) refreshToolAccess(ctx context.Context) {
+	var permissions map[string]bool
+	if s.gw2API.APIKey() != "" {
+		info, err := s.gw2API.GetTokenInfo(ctx)
+		if err != nil {
+			s.logger.Warn("Failed to check API key scopes; tools will not be filtered", "error", err)
+		} else {
+			permissions = make(map[string]bool, len(info.Permissions))
+			for _, p := range info.Permissions {
+				permissions[p] = true
+			}
+			s.logger.Info("API key scopes checked", "name", info.Name, "permissions", info.Permissions)
+		}
+	}
+
+	s.permissionsMu.Lock()
+	s.permissions = permissions
+	s.permissionsMu.Unlock()
+
+	for name, reg := range s.tools {
+		reg.register(s.toolDescription(name, reg.description), s.toolMeta(name))
+	}
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/log"
+)
+
+func newTestServer(t *testing.T) *MCPServer {
+	t.Helper()
+	s, err := NewMCPServer(log.New(io.Discard), "", "")
+	if err != nil {
+		t.Fatalf("NewMCPServer() error = %v", err)
+	}
+	return s
+}
+
+func TestToolScopesAreRegistered(t *testing.T) {
+	s := newTestServer(t)
+	for name := range toolScopes {
+		if _, ok := s.tools[name]; !ok {
+			t.Errorf("toolScopes lists %q, which is not a registered tool", name)
+		}
+	}
+}
+
+func TestDescribeScopes(t *testing.T) {
+	tests := []struct {
+		scopes []string
+		want   string
+	}{
+		{nil, "the `account` scope"},
+		{[]string{"wallet"}, "the `wallet` scope"},
+		{[]string{"wallet", "progression"}, "the `wallet` and `progression` scopes"},
+		{[]string{"a", "b", "c"}, "the `a`, `b` and `c` scopes"},
+	}
+	for _, tt := range tests {
+		if got := describeScopes(tt.scopes); got != tt.want {
+			t.Errorf("describeScopes(%v) = %q, want %q", tt.scopes, got, tt.want)
+		}
+	}
+}
+
+func TestToolAccessWithoutKey(t *testing.T) {
+	s := newTestServer(t)
+
+	msg := s.toolAccessError("get_wallet")
+	if !strings.Contains(msg, "GW2_API_KEY is not configured") || !strings.Contains(msg, "`wallet` scope") {
+		t.Errorf("get_wallet error = %q", msg)
+	}
+	for _, name := range []string{"find_dyes", "get_items", "map_completion"} {
+		if msg := s.toolAccessError(name); msg != "" {
+			t.Errorf("%s should run without a key, got %q", name, msg)
+		}
+	}
+
+	if got := s.toolDescription("get_bank", "Bank."); got != "Bank. [Unavailable: GW2_API_KEY is not configured.]" {
+		t.Errorf("get_bank description = %q", got)
+	}
+	if got := s.toolDescription("find_dyes", "Dyes."); got != "Dyes." {
+		t.Errorf("find_dyes description = %q, want unchanged", got)
+	}
+}
+
+func TestToolAccessWithKey(t *testing.T) {
+	s, err := NewMCPServer(log.New(io.Discard), "test-key", "")
+	if err != nil {
+		t.Fatalf("NewMCPServer() error = %v", err)
+	}
+
+	// Scopes not yet checked: calls go through and the GW2 API decides
+	if msg := s.toolAccessError("wizards_vault_plan"); msg != "" {
+		t.Errorf("unchecked key should not block, got %q", msg)
+	}
+
+	s.permissions = map[string]bool{"account": true, "wallet": true}
+
+	msg := s.toolAccessError("wizards_vault_plan")
+	if !strings.Contains(msg, "the `progression` scope") || strings.Contains(msg, "`wallet`") {
+		t.Errorf("wizards_vault_plan error = %q, want only progression named", msg)
+	}
+	if msg := s.toolAccessError("get_wallet"); msg != "" {
+		t.Errorf("get_wallet error = %q, want none", msg)
+	}
+	if msg := s.toolAccessError("get_account"); msg != "" {
+		t.Errorf("get_account error = %q, want none", msg)
+	}
+
+	if got := s.toolDescription("get_bank", "Bank."); got != "Bank. [Unavailable: the API key is missing the `inventories` scope.]" {
+		t.Errorf("get_bank description = %q", got)
+	}
+	if got := s.toolDescription("find_dyes", "Dyes."); !strings.Contains(got, "[Limited: the API key is missing the `unlocks` scope") {
+		t.Errorf("find_dyes description = %q", got)
+	}
+
+	meta := s.toolMeta("wizards_vault_plan")
+	if missing, _ := meta["gw2/missingScopes"].([]string); len(missing) != 1 || missing[0] != "progression" {
+		t.Errorf("missingScopes meta = %v", meta["gw2/missingScopes"])
+	}
+	if s.toolMeta("get_items") != nil {
+		t.Error("public tools should have no scope metadata")
+	}
+}
@@ -3,11 +3,15 @@ package server
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/AlyxPink/gw2-mcp/internal/cache"
+	"github.com/AlyxPink/gw2-mcp/internal/fractals"
 	"github.com/AlyxPink/gw2-mcp/internal/gw2api"
+	"github.com/AlyxPink/gw2-mcp/internal/schedule"
 	"github.com/AlyxPink/gw2-mcp/internal/wiki"
 
 	"github.com/charmbracelet/log"
@@ -15,11 +19,20 @@ import (
 
 // MCPServer wraps the MCP server with GW2-specific functionality
 type MCPServer struct {
-	mcp    *mcp.Server
-	logger *log.Logger
-	cache  *cache.Manager
-	gw2API *gw2api.Client
-	wiki   *wiki.Client
+	mcp      *mcp.Server
+	logger   *log.Logger
+	cache    *cache.Manager
+	store    *cache.Store
+	gw2API   *gw2api.Client
+	wiki     *wiki.Client
+	events   *schedule.Timetable
+	fractals *fractals.Rotation
+
+	// tools holds every registered tool so it can be re-annotated once the key's scopes are known
+	tools map[string]toolRegistration
+	// permissions are the configured key's scopes; nil when unknown or no key is set
+	permissionsMu sync.RWMutex
+	permissions   map[string]bool
 }
 
 // --- Argument structs for tools with parameters ---
@@ -114,7 +127,7 @@ type GetMinisArgs struct {
 
 type GetMountsInfoArgs struct {
 	Type string `json:"type" jsonschema:"Mount info type: 'skins' or 'types'"`
-	IDs  []int  `json:"ids" jsonschema:"Array of mount skin or type IDs to look up"`
+	IDs  []int  `json:"ids,omitempty" jsonschema:"Array of mount skin IDs to look up; required for skins, ignored for types, which returns every mount"`
 }
 
 type GetDungeonsAndRaidsArgs struct {
@@ -123,7 +136,9 @@ type GetDungeonsAndRaidsArgs struct {
 }
 
 type GetItemByNameArgs struct {
-	Name string `json:"name" jsonschema:"Item name to search for (e.g. 'Mystic Coin', 'Dusk')"`
+	Name   string `json:"name" jsonschema:"Item name to search for (e.g. 'Mystic Coin', 'Dusk'); partial names and small typos return the closest matches as an error"`
+	Rarity string `json:"rarity,omitempty" jsonschema:"Only match items of this rarity (e.g. 'Exotic', 'Legendary')"`
+	Type   string `json:"type,omitempty" jsonschema:"Only match items of this type (e.g. 'Weapon', 'CraftingMaterial')"`
 }
 
 type GetItemRecipeByNameArgs struct {
@@ -134,45 +149,171 @@ type GetTPPriceByNameArgs struct {
 	Name string `json:"name" jsonschema:"Item name to get trading post prices for (e.g. 'Glob of Ectoplasm', 'Mystic Coin')"`
 }
 
-// NewMCPServer creates a new GW2 MCP server instance
-func NewMCPServer(logger *log.Logger, apiKey string) (*MCPServer, error) {
-	// Create cache manager
+type LegendaryPlannerArgs struct {
+	Target   string `json:"target,omitempty" jsonschema:"Legendary item name to plan (e.g. 'Twilight', 'Aurora'); omit to only show Legendary Armory progress"`
+	TargetID int    `json:"target_id,omitempty" jsonschema:"Item ID of the legendary to plan; takes precedence over target"`
+	Count    int    `json:"count,omitempty" jsonschema:"Number of copies to plan for (default: 1)"`
+}
+
+type AchievementProgressArgs struct {
+	Category string `json:"category,omitempty" jsonschema:"Achievement category ID or name to drill into (e.g. 'Collections', '97'); case-insensitive, partial names match. Omit for an account-wide summary"`
+	Limit    int    `json:"limit,omitempty" jsonschema:"Number of nearest-to-completion achievements to return (default: 10)"`
+}
+
+type CollectionStatusArgs struct {
+	AchievementID int    `json:"achievement_id,omitempty" jsonschema:"Collection achievement ID (e.g. 2258)"`
+	Name          string `json:"name,omitempty" jsonschema:"Collection achievement name when the ID is unknown; case-insensitive, partial names match (e.g. 'Aurora: Awakening')"`
+}
+
+type FindLocationArgs struct {
+	Name  string `json:"name" jsonschema:"Name of a waypoint, point of interest, vista, renown heart or area to find (e.g. 'Lion's Arch Aerodrome', 'Help the Seraph'); case-insensitive, partial names match"`
+	Type  string `json:"type,omitempty" jsonschema:"Only return locations of this type: waypoint, landmark, vista, unlock, heart or sector"`
+	Limit int    `json:"limit,omitempty" jsonschema:"Maximum number of matches to return (default: 10)"`
+}
+
+type MapCompletionArgs struct {
+	Map       string `json:"map" jsonschema:"Map name or ID (e.g. 'Queensdale', '15')"`
+	Character string `json:"character,omitempty" jsonschema:"Character name; omit to report every character on the account"`
+}
+
+type GetUpcomingEventsArgs struct {
+	Limit            int    `json:"limit,omitempty" jsonschema:"Number of upcoming spawns to return (default: 10)"`
+	Category         string `json:"category,omitempty" jsonschema:"Only return this category: world_boss or meta"`
+	Map              string `json:"map,omitempty" jsonschema:"Only return events on maps whose name contains this text (e.g. 'Auric Basin')"`
+	ExcludeCompleted bool   `json:"exclude_completed,omitempty" jsonschema:"Hide events whose world boss or map chest the account already completed since daily reset (requires GW2_API_KEY)"`
+}
+
+type GetDailyFractalsArgs struct {
+	Date  string `json:"date,omitempty" jsonschema:"UTC date as YYYY-MM-DD (default: today)"`
+	Level int    `json:"level,omitempty" jsonschema:"Only return instabilities for this fractal level (e.g. 98)"`
+}
+
+type FractalProgressArgs struct {
+	Limit int `json:"limit,omitempty" jsonschema:"Number of nearly complete fractal achievements to return (default: 10)"`
+}
+
+type MasteryStatusArgs struct {
+	Region string `json:"region,omitempty" jsonschema:"Only show regions whose name contains this text (e.g. 'Heart of Thorns', 'End of Dragons')"`
+}
+
+type WardrobeStatusArgs struct {
+	Type        string `json:"type,omitempty" jsonschema:"Skin type: Armor, Weapon, Back or Gathering"`
+	Subtype     string `json:"subtype,omitempty" jsonschema:"Armor slot, weapon type or tool type (e.g. 'Helm', 'Greatsword', 'Foraging')"`
+	Weight      string `json:"weight,omitempty" jsonschema:"Armor weight class: Light, Medium, Heavy or Clothing"`
+	Limit       int    `json:"limit,omitempty" jsonschema:"Number of missing skins to list (default: 25)"`
+	SkipSources bool   `json:"skip_sources,omitempty" jsonschema:"Do not look up tradeable items and Trading Post prices for missing skins"`
+}
+
+type FindDyesArgs struct {
+	Hex              string `json:"hex,omitempty" jsonschema:"Target colour as #rrggbb; dyes are sorted by perceptual distance to it"`
+	Material         string `json:"material,omitempty" jsonschema:"Armor material to compare on: cloth, leather, metal or fur (default: cloth)"`
+	Hue              string `json:"hue,omitempty" jsonschema:"Only dyes in this hue category (e.g. 'Red', 'Blue', 'Gray')"`
+	MaterialCategory string `json:"material_category,omitempty" jsonschema:"Only dyes in this finish category: Vibrant, Leather or Metal"`
+	Rarity           string `json:"rarity,omitempty" jsonschema:"Only dyes of this rarity category: Starter, Common, Uncommon, Rare or Exclusive"`
+	LockedOnly       bool   `json:"locked_only,omitempty" jsonschema:"Only dyes the account has not unlocked (requires GW2_API_KEY)"`
+	Limit            int    `json:"limit,omitempty" jsonschema:"Number of dyes to return (default: 10)"`
+}
+
+type WizardsVaultPlanArgs struct {
+	Track string `json:"track,omitempty" jsonschema:"Only plan objectives on this track: PvE, PvP or WvW"`
+	Limit int    `json:"limit,omitempty" jsonschema:"Number of objectives to return (default: 10)"`
+}
+
+type GuildUpgradePlanArgs struct {
+	ID      string `json:"id" jsonschema:"Guild ID (UUID)"`
+	Upgrade string `json:"upgrade,omitempty" jsonschema:"Only plan upgrades whose name contains this text"`
+	Limit   int    `json:"limit,omitempty" jsonschema:"Number of next upgrades to return (default: 20)"`
+}
+
+type GuildActivityReportArgs struct {
+	ID   string `json:"id" jsonschema:"Guild ID (UUID)"`
+	Days int    `json:"days,omitempty" jsonschema:"Number of days to report on (default: 7)"`
+}
+
+type GuildRosterReportArgs struct {
+	ID    string `json:"id" jsonschema:"Guild ID (UUID)"`
+	Limit int    `json:"limit,omitempty" jsonschema:"Number of newest members to list (default: 10)"`
+}
+
+type FindItemOnAccountArgs struct {
+	ItemID int    `json:"item_id,omitempty" jsonschema:"Item ID to search for (e.g. 19675 for Mystic Clover)"`
+	Name   string `json:"name,omitempty" jsonschema:"Item name to search for when the ID is unknown; case-insensitive, partial names match (e.g. 'Mystic Clover')"`
+}
+
+// NewMCPServer creates a new GW2 MCP server instance. Data that is expensive to rebuild
+// is saved to dataDir; an empty dataDir keeps everything in memory.
+func NewMCPServer(logger *log.Logger, apiKey, dataDir string) (*MCPServer, error) {
+	// Create cache manager and data store
 	cacheManager := cache.NewManager()
+	store := cache.NewStore(dataDir)
 
 	// Create GW2 API client
-	gw2Client := gw2api.NewClient(cacheManager, logger, apiKey)
+	gw2Client := gw2api.NewClient(cacheManager, store, logger, apiKey)
+
+	// Load the item index saved by an earlier run
+	if err := gw2Client.LoadItemIndex(); err != nil {
+		logger.Warn("Failed to load saved item index", "error", err)
+	}
 
 	// Create wiki client
 	wikiClient := wiki.NewClient(cacheManager, logger)
 
+	// Load bundled event timetable
+	events, err := schedule.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load event timetable: %w", err)
+	}
+
+	// Load bundled fractal rotation
+	rotation, err := fractals.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fractal rotation: %w", err)
+	}
+
+	// Restore the daily fractals learned from the API by an earlier run
+	var learned []fractals.LearnedDay
+	if _, err := store.Load(learnedFractalsFile, &learned); err != nil {
+		logger.Warn("Failed to load learned daily fractals", "error", err)
+	} else if err := rotation.LoadLearned(learned); err != nil {
+		logger.Warn("Failed to restore learned daily fractals", "error", err)
+	}
+
+	gw2MCP := &MCPServer{
+		logger:   logger,
+		cache:    cacheManager,
+		store:    store,
+		gw2API:   gw2Client,
+		wiki:     wikiClient,
+		events:   events,
+		fractals: rotation,
+		tools:    make(map[string]toolRegistration),
+	}
+
 	// Create MCP server
-	mcpServer := mcp.NewServer(
+	gw2MCP.mcp = mcp.NewServer(
 		&mcp.Implementation{
 			Name:    "GW2 MCP Server",
 			Version: "1.0.0",
 		},
-		nil,
+		&mcp.ServerOptions{CompletionHandler: gw2MCP.handleComplete},
 	)
 
-	gw2MCP := &MCPServer{
-		mcp:    mcpServer,
-		logger: logger,
-		cache:  cacheManager,
-		gw2API: gw2Client,
-		wiki:   wikiClient,
-	}
-
 	// Register tools
 	gw2MCP.registerTools()
 
 	// Register resources
 	gw2MCP.registerResources()
 
+	// Register prompts
+	gw2MCP.registerPrompts()
+
 	return gw2MCP, nil
 }
 
 // Start starts the MCP server
 func (s *MCPServer) Start(ctx context.Context) error {
+	s.refreshToolAccess(ctx)
+
 	s.logger.Info("Starting MCP server on stdio")
 	return s.mcp.Run(ctx, &mcp.StdioTransport{})
 }
@@ -180,215 +321,305 @@ func (s *MCPServer) Start(ctx context.Context) error {
 // registerTools registers all available tools
 func (s *MCPServer) registerTools() {
 	// Wiki search tool
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[*wiki.SearchResponse](s, &mcp.Tool{
 		Name:        "wiki_search",
 		Description: "Search Guild Wars 2 wiki for information about game content",
 	}, s.handleWikiSearch)
 
 	// Wallet info tool (no params)
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[*gw2api.WalletInfo](s, &mcp.Tool{
 		Name:        "get_wallet",
 		Description: "Get user's wallet information including all currencies. Requires GW2_API_KEY environment variable.",
 	}, s.handleGetWallet)
 
 	// Currency info tool
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[map[int]gw2api.Currency](s, &mcp.Tool{
 		Name:        "get_currencies",
 		Description: "Get information about Guild Wars 2 currencies",
 	}, s.handleGetCurrencies)
 
 	// Trading Post prices tool
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[[]gw2api.PriceInfo](s, &mcp.Tool{
 		Name:        "get_tp_prices",
 		Description: "Get Trading Post prices for items. Returns aggregated best buy/sell prices with item names and formatted coin values.",
 	}, s.handleGetTPPrices)
 
 	// Trading Post listings tool
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[[]gw2api.ListingInfo](s, &mcp.Tool{
 		Name:        "get_tp_listings",
 		Description: "Get Trading Post order book listings for items. Returns all buy/sell price tiers with quantities.",
 	}, s.handleGetTPListings)
 
 	// Gem exchange tool
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[*gw2api.ExchangeRate](s, &mcp.Tool{
 		Name:        "get_gem_exchange",
 		Description: "Get gem exchange rates. Convert coins to gems or gems to coins.",
 	}, s.handleGetGemExchange)
 
 	// Trading Post delivery tool (no params)
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[*gw2api.DeliveryInfo](s, &mcp.Tool{
 		Name:        "get_tp_delivery",
 		Description: "Get items and coins awaiting pickup from the Trading Post. Requires GW2_API_KEY with account and tradingpost scopes.",
 	}, s.handleGetTPDelivery)
 
 	// Trading Post transactions tool
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[*gw2api.TransactionList](s, &mcp.Tool{
 		Name:        "get_tp_transactions",
 		Description: "Get Trading Post transaction history. View current orders or completed transactions from the past 90 days. Requires GW2_API_KEY with account and tradingpost scopes.",
 	}, s.handleGetTPTransactions)
 
 	// --- Account Tools ---
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[*gw2api.AccountInfo](s, &mcp.Tool{
 		Name:        "get_account",
 		Description: "Get account information including name, world, guilds, and access. Requires GW2_API_KEY.",
 	}, s.handleGetAccount)
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[*gw2api.BankInfo](s, &mcp.Tool{
 		Name:        "get_bank",
 		Description: "Get bank vault contents with item names. Requires GW2_API_KEY.",
 	}, s.handleGetBank)
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[*gw2api.MaterialStorage](s, &mcp.Tool{
 		Name:        "get_materials",
 		Description: "Get material storage contents with item names. Requires GW2_API_KEY.",
 	}, s.handleGetMaterials)
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[*gw2api.InventoryInfo](s, &mcp.Tool{
 		Name:        "get_inventory",
 		Description: "Get shared inventory slot contents with item names. Requires GW2_API_KEY.",
 	}, s.handleGetInventory)
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[CharactersResult](s, &mcp.Tool{
 		Name:        "get_characters",
-		Description: "Get list of character names, or detailed info for a specific character including crafting disciplines, equipment, skills, specializations, and build tabs. Requires GW2_API_KEY.",
+		Description: "Get list of character names, or detailed info for a specific character including crafting disciplines, equipment, bags with item names, skills, specializations, and build tabs. Requires GW2_API_KEY.",
 	}, s.handleGetCharacters)
 
 	// --- Account Unlocks ---
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[*gw2api.AccountUnlocks](s, &mcp.Tool{
 		Name:        "get_account_unlocks",
-		Description: "Get account unlocked IDs. Requires GW2_API_KEY.",
+		Description: "Get everything the account has unlocked of a type, with names. Requires GW2_API_KEY.",
 	}, s.handleGetAccountUnlocks)
 
 	// --- Account Progress ---
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[*gw2api.AccountProgress](s, &mcp.Tool{
 		Name:        "get_account_progress",
-		Description: "Get account progress data. Requires GW2_API_KEY.",
+		Description: "Get account progress of a type, with achievement, mastery and item names. Requires GW2_API_KEY.",
 	}, s.handleGetAccountProgress)
 
 	// --- Account Dailies ---
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[*gw2api.AccountDailies](s, &mcp.Tool{
 		Name:        "get_account_dailies",
-		Description: "Get completed daily content IDs. Requires GW2_API_KEY.",
+		Description: "Get completed daily content IDs, and the entries still to do where all are known. Requires GW2_API_KEY.",
 	}, s.handleGetAccountDailies)
 
 	// --- Wizard's Vault ---
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[*gw2api.WizardsVaultSeason](s, &mcp.Tool{
 		Name:        "get_wizards_vault",
 		Description: "Get current Wizard's Vault season information.",
 	}, s.handleGetWizardsVault)
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[*gw2api.WizardsVaultProgress](s, &mcp.Tool{
 		Name:        "get_wizards_vault_objectives",
 		Description: "Get Wizard's Vault objectives. Uses authenticated endpoint if GW2_API_KEY is set, otherwise returns public objective list.",
 	}, s.handleGetWizardsVaultObjectives)
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[[]gw2api.WizardsVaultListing](s, &mcp.Tool{
 		Name:        "get_wizards_vault_listings",
 		Description: "Get Wizard's Vault reward listings. Uses authenticated endpoint if GW2_API_KEY is set.",
 	}, s.handleGetWizardsVaultListings)
 
 	// --- Game Data Lookups ---
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[map[int]gw2api.Item](s, &mcp.Tool{
 		Name:        "get_items",
 		Description: "Get item metadata (name, type, rarity, level, icon, description, vendor value, flags, game types, restrictions, and type-specific details) for given item IDs.",
 	}, s.handleGetItems)
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[[]gw2api.Skin](s, &mcp.Tool{
 		Name:        "get_skins",
 		Description: "Get skin metadata (name, type, icon, rarity, description, flags, restrictions, and type-specific details) for given skin IDs.",
 	}, s.handleGetSkins)
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[[]gw2api.Recipe](s, &mcp.Tool{
 		Name:        "get_recipes",
 		Description: "Get recipe details (type, output, ingredients, disciplines, crafting time, flags, guild ingredients, chat link) for given recipe IDs.",
 	}, s.handleGetRecipes)
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[RecipeSearchResult](s, &mcp.Tool{
 		Name:        "search_recipes",
-		Description: "Search for recipe IDs by input or output item ID.",
+		Description: "Search for recipes by input or output item ID. Each match is tagged with its source: \"api\" recipes return their recipe ID, \"mystic_forge\" recipes (output search only, parsed from the wiki) are returned in full.",
 	}, s.handleSearchRecipes)
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[[]gw2api.Achievement](s, &mcp.Tool{
 		Name:        "get_achievements",
 		Description: "Get achievement details (name, description, requirements, tiers, prerequisites, rewards, bits, icon) for given achievement IDs.",
 	}, s.handleGetAchievements)
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[*gw2api.DailyAchievements](s, &mcp.Tool{
 		Name:        "get_daily_achievements",
 		Description: "Get today's and tomorrow's daily achievements.",
 	}, s.handleGetDailyAchievements)
 
 	// --- Guild Tools ---
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[*gw2api.GuildInfo](s, &mcp.Tool{
 		Name:        "get_guild",
 		Description: "Get public guild information (name, tag, level).",
 	}, s.handleGetGuild)
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[[]string](s, &mcp.Tool{
 		Name:        "search_guild",
 		Description: "Search for a guild by name. Returns matching guild IDs.",
 	}, s.handleSearchGuild)
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[GuildDetailsResult](s, &mcp.Tool{
 		Name:        "get_guild_details",
 		Description: "Get detailed guild data (log, members, ranks, stash, etc.). Requires GW2_API_KEY with guild leader permissions.",
 	}, s.handleGetGuildDetails)
 
 	// --- Game Metadata ---
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[[]gw2api.Color](s, &mcp.Tool{
 		Name:        "get_colors",
 		Description: "Get dye color metadata (name, base RGB, cloth/leather/metal/fur material adjustments) for given color IDs.",
 	}, s.handleGetColors)
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[[]gw2api.Mini](s, &mcp.Tool{
 		Name:        "get_minis",
 		Description: "Get miniature metadata (name, icon, item_id) for given mini IDs.",
 	}, s.handleGetMinis)
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[MountsInfoResult](s, &mcp.Tool{
 		Name:        "get_mounts_info",
-		Description: "Get mount skin or mount type metadata for given IDs.",
+		Description: "Get mount skin metadata for given IDs, or every mount type.",
 	}, s.handleGetMountsInfo)
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[*gw2api.BuildInfo](s, &mcp.Tool{
 		Name:        "get_game_build",
 		Description: "Get the current Guild Wars 2 game build number.",
 	}, s.handleGetGameBuild)
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[*gw2api.TokenInfo](s, &mcp.Tool{
 		Name:        "get_token_info",
 		Description: "Get API key information including name and permission scopes. Requires GW2_API_KEY.",
 	}, s.handleGetTokenInfo)
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[DungeonsAndRaidsResult](s, &mcp.Tool{
 		Name:        "get_dungeons_and_raids",
 		Description: "Get dungeon or raid metadata (paths, wings, events) for given IDs.",
 	}, s.handleGetDungeonsAndRaids)
 
 	// --- Composite Tools ---
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[gw2api.Item](s, &mcp.Tool{
 		Name:        "get_item_by_name",
-		Description: "Look up a GW2 item by name. Searches the wiki to find the item ID, then returns full item details from the API.",
+		Description: "Look up a GW2 item by name, optionally filtered by rarity and type. Resolves the name from the local item index, which must match exactly one item (otherwise the error lists the closest matches), falling back to wiki search while the index is first built, then returns full item details from the API.",
 	}, s.handleGetItemByName)
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[ItemRecipeResult](s, &mcp.Tool{
 		Name:        "get_item_recipe_by_name",
-		Description: "Find crafting recipes for a GW2 item by name. Searches the wiki for recipe data, then returns full recipe details with resolved ingredient names.",
+		Description: "Find crafting and Mystic Forge recipes for a GW2 item by name. Resolves the name from the local item index, falling back to wiki search, then returns full recipe details with resolved ingredient names, each tagged with its source (\"api\" or \"mystic_forge\").",
 	}, s.handleGetItemRecipeByName)
 
-	mcp.AddTool(s.mcp, &mcp.Tool{
+	addTool[gw2api.PriceInfo](s, &mcp.Tool{
 		Name:        "get_tp_price_by_name",
-		Description: "Get Trading Post prices for an item by name. Searches the wiki to find the item ID, then returns current buy/sell prices.",
+		Description: "Get Trading Post prices for an item by name. Resolves the name from the local item index, which must match exactly one item (otherwise the error lists the closest matches), falling back to wiki search while the index is first built, then returns current buy/sell prices.",
 	}, s.handleGetTPPriceByName)
+
+	addTool[LegendaryPlannerResult](s, &mcp.Tool{
+		Name:        "legendary_planner",
+		Description: "Show Legendary Armory progress by slot (owned vs possible legendaries) and, for a target legendary, expand its full recipe tree against material storage, bank, shared inventory and wallet to report what is still missing and its Trading Post cost. Requires GW2_API_KEY with inventories, wallet and unlocks scopes.",
+	}, s.handleLegendaryPlanner)
+
+	addTool[FindItemResult](s, &mcp.Tool{
+		Name:        "find_item_on_account",
+		Description: "Find where an item is stored across the account: bank, material storage, shared inventory slots, every character's bags and equipped gear, and the Trading Post delivery box. Search by item ID or name. Requires GW2_API_KEY with inventories and characters scopes.",
+	}, s.handleFindItemOnAccount)
+
+	addTool[AchievementProgressResult](s, &mcp.Tool{
+		Name:        "achievement_progress",
+		Description: "Join achievement definitions with account progress: per-category completion and achievement points (AP) earned vs still available, the achievements nearest to completion, and for a chosen category every achievement with its bits done and remaining by name. Requires GW2_API_KEY with progression scope.",
+	}, s.handleAchievementProgress)
+
+	addTool[CollectionStatusResult](s, &mcp.Tool{
+		Name:        "collection_status",
+		Description: "Show what a collection achievement still needs: each item, skin and miniature piece is checked against achievement progress, account unlocks, bank, material storage and shared inventory, and missing tradeable pieces are priced on the Trading Post for a cost-to-finish estimate. Requires GW2_API_KEY with progression, unlocks and inventories scopes.",
+	}, s.handleCollectionStatus)
+
+	addTool[FindLocationResult](s, &mcp.Tool{
+		Name:        "find_location",
+		Description: "Find a waypoint, point of interest, vista, renown heart or map area by name in Tyria and the Mists. Returns the map, region, level and chat code for each match, so waypoints can be pasted in game.",
+	}, s.handleFindLocation)
+
+	addTool[MapCompletionResult](s, &mcp.Tool{
+		Name:        "map_completion",
+		Description: "List a map's map-completion objectives (waypoints, points of interest, vistas, renown hearts, hero challenges) with chat codes, and report the hero challenges each character is still missing. The API does not expose per-character exploration of the other objectives. Requires GW2_API_KEY with characters and progression scopes for the per-character part.",
+	}, s.handleMapCompletion)
+
+	addTool[UpcomingEventsResult](s, &mcp.Tool{
+		Name:        "get_upcoming_events",
+		Description: "List the next world boss and map meta event spawns (UTC) with countdowns, from a bundled event timetable. With GW2_API_KEY, marks or hides events whose world boss or Hero's Choice map chest the account already completed today.",
+	}, s.handleGetUpcomingEvents)
+
+	addTool[ResetChecklistResult](s, &mcp.Tool{
+		Name:        "reset_checklist",
+		Description: "One done/not-done checklist of everything that resets: Wizard's Vault daily and weekly objectives, daily crafting, Hero's Choice map chests, world bosses and dungeon paths (daily reset, 00:00 UTC), and raid encounters (weekly reset, Monday 07:30 UTC), with the time until each reset. Requires GW2_API_KEY with progression scope.",
+	}, s.handleResetChecklist)
+
+	addTool[RaidClearsResult](s, &mcp.Tool{
+		Name:        "raid_clears",
+		Description: "Weekly raid progress: every raid wing and encounter marked cleared or not since the Monday 07:30 UTC reset, the strike mission list, and every dungeon path marked done or not since daily reset, with counts of remaining raid boss and dungeon path rewards. Requires GW2_API_KEY with progression scope.",
+	}, s.handleRaidClears)
+
+	addTool[DailyFractalsResult](s, &mcp.Tool{
+		Name:        "get_daily_fractals",
+		Description: "Get the daily Tier 4 and recommended fractals and the Mistlock Instabilities for a UTC date (default today). Today's fractals are read from the Daily Fractals achievements; other dates are computed from the 15-day fractal cycle, bundled or learned from earlier days, and instabilities from the bundled schedule. Notes say which parts are unknown.",
+	}, s.handleGetDailyFractals)
+
+	addTool[FractalProgressResult](s, &mcp.Tool{
+		Name:        "fractal_progress",
+		Description: "Fractal progress for the account: personal fractal level, Fractal Attunement and other fractal mastery levels unlocked, and fractal achievement progress with the achievements nearest to completion. Requires GW2_API_KEY with account and progression scopes.",
+	}, s.handleFractalProgress)
+
+	addTool[MasteryStatusResult](s, &mcp.Tool{
+		Name:        "mastery_status",
+		Description: "Mastery progress by region: each mastery track with its current level, the levels unlocked, the next level's name and point cost, and earned, spent and unspent mastery points per region. Requires GW2_API_KEY with progression scope.",
+	}, s.handleMasteryStatus)
+
+	addTool[WardrobeStatusResult](s, &mcp.Tool{
+		Name:        "wardrobe_status",
+		Description: "Wardrobe completion: unlocked skins compared with every skin, grouped by type, armor weight and weapon or armor slot, plus the missing skins with the tradeable items that unlock them and the cheapest Trading Post price. The first source lookup after a game update indexes every item and can take a while. Requires GW2_API_KEY with unlocks scope.",
+	}, s.handleWardrobeStatus)
+
+	addTool[FindDyesResult](s, &mcp.Tool{
+		Name:        "find_dyes",
+		Description: "Search dye colours by hue, finish and rarity category, or find the dyes nearest to a hex colour on cloth, leather, metal or fur using a perceptual (CIEDE2000) colour distance. Includes the dye item's Trading Post price and, with GW2_API_KEY, whether the account has each dye unlocked.",
+	}, s.handleFindDyes)
+
+	addTool[WizardsVaultPlanResult](s, &mcp.Tool{
+		Name:        "wizards_vault_plan",
+		Description: "Plan Wizard's Vault spending and earning: current Astral Acclaim balance, rewards still purchasable with their limits and how many the balance covers, Trading Post value per acclaim of tradeable rewards, and unfinished daily, weekly and special objectives ranked by acclaim per estimated minute. Requires GW2_API_KEY with wallet and progression scopes.",
+	}, s.handleWizardsVaultPlan)
+
+	addTool[GuildUpgradePlanResult](s, &mcp.Tool{
+		Name:        "guild_upgrade_plan",
+		Description: "Plan guild hall upgrades: completed upgrades, the upgrades whose prerequisites are met with each cost, what the treasury already holds towards it, and the Trading Post cost of the rest. Requires GW2_API_KEY with guilds scope from a guild leader.",
+	}, s.handleGuildUpgradePlan)
+
+	addTool[GuildActivityReport](s, &mcp.Tool{
+		Name:        "guild_activity_report",
+		Description: "Summarise a guild's log over recent days: joins, invites, kicks and rank changes, stash deposits and withdrawals and treasury contributions per member with Trading Post values, upgrade completions, and unusually large withdrawals. Log history is collected across calls. Requires GW2_API_KEY with guilds scope from a guild leader.",
+	}, s.handleGuildActivityReport)
+
+	addTool[GuildRosterReport](s, &mcp.Tool{
+		Name:        "guild_roster_report",
+		Description: "Guild roster by rank with each rank's permissions, the newest members, and each member's last activity estimated from the guild log. Requires GW2_API_KEY with guilds scope from a guild leader.",
+	}, s.handleGuildRosterReport)
 }
 
 // registerResources registers all available resources
@@ -399,4 +630,81 @@ func (s *MCPServer) registerResources() {
 		Description: "Complete list of all Guild Wars 2 currencies with metadata",
 		MIMEType:    "application/json",
 	}, s.handleCurrencyListResource)
+
+	s.mcp.AddResource(&mcp.Resource{
+		URI:         "gw2://account/bank",
+		Name:        "Account Bank",
+		Description: "Bank vault contents with item names. Requires GW2_API_KEY with the inventories scope.",
+		MIMEType:    "application/json",
+	}, s.handleBankResource)
+
+	s.mcp.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: "gw2://items/{id}",
+		Name:        "Item",
+		Description: "Item details by item ID",
+		MIMEType:    "application/json",
+	}, s.handleItemResource)
+
+	s.mcp.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: "gw2://recipes/{id}",
+		Name:        "Recipe",
+		Description: "Recipe details by recipe ID",
+		MIMEType:    "application/json",
+	}, s.handleRecipeResource)
+
+	s.mcp.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: "gw2://skins/{id}",
+		Name:        "Skin",
+		Description: "Skin details by skin ID",
+		MIMEType:    "application/json",
+	}, s.handleSkinResource)
+
+	s.mcp.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: "gw2://achievements/{id}",
+		Name:        "Achievement",
+		Description: "Achievement details by achievement ID",
+		MIMEType:    "application/json",
+	}, s.handleAchievementResource)
+
+	s.mcp.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: "gw2://wiki/{title}",
+		Name:        "Wiki Page",
+		Description: "Introduction, infobox and recipes of a wiki page by exact title; URL-encode spaces and special characters",
+		MIMEType:    "application/json",
+	}, s.handleWikiPageResource)
+
+	s.mcp.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: "gw2://characters/{name}",
+		Name:        "Character",
+		Description: "Character details by name; URL-encode spaces. Requires GW2_API_KEY with the characters scope.",
+		MIMEType:    "application/json",
+	}, s.handleCharacterResource)
+
+	s.mcp.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: "gw2://account/unlocks/{+type}",
+		Name:        "Account Unlocks",
+		Description: "Unlocked skins, dyes, minis and other collections with names, by unlock type (e.g. skins, mounts/skins). Requires GW2_API_KEY with the unlocks scope.",
+		MIMEType:    "application/json",
+	}, s.handleAccountUnlocksResource)
+
+	s.mcp.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: "gw2://account/progress/{+type}",
+		Name:        "Account Progress",
+		Description: "Achievement, mastery, luck, Legendary Armory or progression data by type (e.g. achievements, mastery/points). Requires GW2_API_KEY with the progression scope.",
+		MIMEType:    "application/json",
+	}, s.handleAccountProgressResource)
+
+	s.mcp.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: "gw2://account/dailies/{type}",
+		Name:        "Account Dailies",
+		Description: "Daily content completed since reset and still to do, by type (e.g. worldbosses, mapchests). Requires GW2_API_KEY with the progression scope.",
+		MIMEType:    "application/json",
+	}, s.handleAccountDailiesResource)
+
+	s.mcp.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: "gw2://guilds/{name}",
+		Name:        "Guild",
+		Description: "Public guild details by exact guild name; URL-encode spaces",
+		MIMEType:    "application/json",
+	}, s.handleGuildResource)
 }
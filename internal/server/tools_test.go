@@ -0,0 +1,315 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/AlyxPink/gw2-mcp/internal/cache"
+	"github.com/AlyxPink/gw2-mcp/internal/gw2api"
+)
+
+const testGuildID = "4BBB52AA-D768-4FC6-8EDE-C299F2822F0F"
+
+// fakeAPI serves canned GW2 API and wiki responses by request path, with "?ids" appended
+// for ID lookups, recording the paths requested and the requests it has no response for
+type fakeAPI struct {
+	routes map[string]string
+
+	mu        sync.Mutex
+	requests  []string
+	unhandled []string
+}
+
+func (f *fakeAPI) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := strings.TrimPrefix(req.URL.Path, "/v2")
+	if req.URL.Host == "wiki.guildwars2.com" {
+		path = "wiki:" + req.URL.Query().Get("list") + req.URL.Query().Get("prop")
+	}
+	if req.URL.Query().Has("ids") {
+		path += "?ids"
+	}
+	status, body := http.StatusOK, f.routes[path]
+	f.mu.Lock()
+	f.requests = append(f.requests, path)
+	if body == "" {
+		f.unhandled = append(f.unhandled, req.URL.String())
+		status, body = http.StatusNotFound, `{"text":"no such endpoint"}`
+	}
+	f.mu.Unlock()
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+// newFakeAPIServer creates a server with an API key whose GW2 API and wiki requests are
+// answered by a fakeAPI, and whose item index is already built
+func newFakeAPIServer(t *testing.T) (*MCPServer, *fakeAPI) {
+	t.Helper()
+	api := &fakeAPI{routes: fakeRoutes}
+	transport := http.DefaultTransport
+	http.DefaultTransport = api
+	t.Cleanup(func() { http.DefaultTransport = transport })
+
+	dir := filepath.Join(t.TempDir(), "data")
+	if err := cache.NewStore(dir).Save("item-index", fakeItemIndex); err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewMCPServer(log.New(io.Discard), "test-key", dir)
+	if err != nil {
+		t.Fatalf("NewMCPServer() error = %v", err)
+	}
+	return s, api
+}
+
+var fakeItemIndex = gw2api.ItemIndex{
+	Build: 170000,
+	Items: []gw2api.IndexedItem{
+		{ID: 19976, Name: "Mystic Coin", Type: "Trophy", Rarity: "Rare", Tradeable: true},
+		{ID: 19721, Name: "Glob of Ectoplasm", Type: "CraftingMaterial", Rarity: "Exotic", Tradeable: true},
+		{ID: 19675, Name: "Mystic Clover", Type: "CraftingMaterial", Rarity: "Rare"},
+		{ID: 30704, Name: "Twilight", Type: "Weapon", Rarity: "Legendary", DefaultSkin: 4678},
+	},
+}
+
+var fakeRoutes = map[string]string{
+	"/build":     `{"id":170000}`,
+	"/tokeninfo": `{"id":"key","name":"test","permissions":["account","builds","characters","guilds","inventories","progression","tradingpost","unlocks","wallet"]}`,
+	"/account": `{"id":"account","name":"Test.1234","age":3600,"world":1001,"guilds":["` + testGuildID + `"],
+		"guild_leader":["` + testGuildID + `"],"created":"2012-08-28T00:00:00Z","access":["GuildWars2"],"fractal_level":100}`,
+	"/account/wallet":               `[{"id":1,"value":1234567},{"id":23,"value":40}]`,
+	"/account/bank":                 `[{"id":19976,"count":250},null]`,
+	"/account/materials":            `[{"id":19721,"category":6,"count":200}]`,
+	"/account/inventory":            `[{"id":19675,"count":3},null]`,
+	"/account/skins":                `[4678]`,
+	"/account/masteries":            `[{"id":1,"level":2}]`,
+	"/account/worldbosses":          `["admiral_taidha_covington"]`,
+	"/account/mapchests":            `["auric_basin_heros_choice_chest"]`,
+	"/account/wizardsvault/daily":   wizardsVaultProgress,
+	"/account/wizardsvault/weekly":  wizardsVaultProgress,
+	"/account/wizardsvault/special": wizardsVaultProgress,
+	"/account/wizardsvault/listings": `[{"id":1,"item_id":19976,"item_count":1,"type":"Normal","cost":300,
+		"purchase_limit":5,"purchased":1}]`,
+	"/characters":                `["Test Character"]`,
+	"/characters/Test Character": `{"name":"Test Character","race":"Human","gender":"Female","profession":"Guardian","level":80}`,
+	"/currencies":                `[1,23]`,
+	"/currencies?ids":            `[{"id":1,"name":"Coin","description":"Coins","order":101,"icon":""}]`,
+	"/items":                     `[19976,19721,19675,30704]`,
+	"/items?ids": `[{"id":19976,"name":"Mystic Coin","type":"Trophy","rarity":"Rare","level":0,"icon":"","chat_link":"[&AgFQTgAA]","vendor_value":0},
+		{"id":19721,"name":"Glob of Ectoplasm","type":"CraftingMaterial","rarity":"Exotic","level":0,"icon":"","chat_link":"[&AgHRTAAA]","vendor_value":0},
+		{"id":19675,"name":"Mystic Clover","type":"CraftingMaterial","rarity":"Rare","level":0,"icon":"","chat_link":"[&AgGbTAAA]","vendor_value":0,"flags":["AccountBound"]},
+		{"id":30704,"name":"Twilight","type":"Weapon","rarity":"Legendary","level":80,"icon":"","chat_link":"[&AgHwdwAA]","vendor_value":100000,
+		"details":{"type":"Greatsword","default_skin":4678}}]`,
+	"/commerce/prices?ids":                `[{"id":19976,"whitelisted":false,"buys":{"unit_price":22000,"quantity":100},"sells":{"unit_price":24000,"quantity":50}}]`,
+	"/commerce/listings?ids":              `[{"id":19976,"buys":[{"listings":1,"unit_price":22000,"quantity":100}],"sells":[{"listings":1,"unit_price":24000,"quantity":50}]}]`,
+	"/commerce/exchange/coins":            `{"coins_per_gem":2500,"quantity":40}`,
+	"/commerce/delivery":                  `{"coins":1000,"items":[{"id":19976,"count":1}]}`,
+	"/commerce/transactions/current/buys": `[{"id":1,"item_id":19976,"price":22000,"quantity":1,"created":"2026-10-17T12:00:00+00:00"}]`,
+	"/recipes?ids": `[{"id":7319,"type":"Refinement","output_item_id":19721,"output_item_count":1,"disciplines":["Artificer"],"min_rating":400,
+		"ingredients":[{"item_id":19976,"count":1}],"chat_link":"[&CTcdAAA=]"}]`,
+	"/recipes/search": `[7319]`,
+	"/skins":          `[4678,4679]`,
+	"/skins?ids":      `[{"id":4678,"name":"Twilight","type":"Weapon","icon":"","rarity":"Legendary","details":{"type":"Greatsword"}}]`,
+	"/achievements?ids": `[{"id":2258,"name":"Twilight Collection","description":"","requirement":"Collect the items.","type":"ItemSet",
+		"flags":["Pvp"],"tiers":[{"count":2,"points":5}],"bits":[{"type":"Item","id":19976},{"type":"Skin","id":4678}]},
+		{"id":2950,"name":"Daily Tier 4 Nightmare","description":"","requirement":"","type":"Default","flags":["Daily"]}]`,
+	"/achievements/categories?ids": `[{"id":75,"name":"Collections","description":"","order":1,"achievements":[2258]},
+		{"id":88,"name":"Daily Fractals","description":"","order":2,"achievements":[2950]}]`,
+	"/achievements/categories/88":  `{"id":88,"name":"Daily Fractals","description":"","order":2,"achievements":[2950]}`,
+	"/achievements/groups?ids":     `[{"id":"A4ED8379-5B6B-4ECC-B6E1-70C350C902D2","name":"Collections","description":"","order":1,"categories":[75]}]`,
+	"/account/achievements":        `[{"id":2258,"bits":[0],"current":1,"max":2,"done":false}]`,
+	"/achievements/daily":          `{"pve":[],"pvp":[],"wvw":[],"fractals":[],"special":[]}`,
+	"/achievements/daily/tomorrow": `{"pve":[],"pvp":[],"wvw":[],"fractals":[],"special":[]}`,
+	"/guild/search":                `["` + testGuildID + `"]`,
+	"/guild/" + testGuildID: `{"id":"` + testGuildID + `","name":"Test Guild","tag":"TEST","level":69,"aetherium":15000,"favor":1000,
+		"member_count":2,"member_capacity":500}`,
+	"/guild/" + testGuildID + "/members":  `[{"name":"Test.1234","rank":"Leader","joined":"2020-01-01T00:00:00.000Z"},{"name":"New.5678","rank":"Member","joined":"2026-10-01T00:00:00.000Z"}]`,
+	"/guild/" + testGuildID + "/ranks":    `[{"id":"Leader","order":1,"permissions":["Admin"],"icon":""},{"id":"Member","order":2,"permissions":[],"icon":""}]`,
+	"/guild/" + testGuildID + "/upgrades": `[38]`,
+	"/guild/" + testGuildID + "/treasury": `[{"item_id":19721,"count":10,"needed_by":[{"upgrade_id":39,"count":20}]}]`,
+	"/guild/" + testGuildID + "/log":      `[{"id":2,"time":"2026-10-17T12:00:00.000Z","type":"joined","user":"New.5678"},{"id":1,"time":"2026-10-16T12:00:00.000Z","type":"motd","user":"Test.1234","motd":"Hello"}]`,
+	"/guild/upgrades?ids": `[{"id":38,"name":"Guild Hall","type":"Unlock","build_time":0,"required_level":0,"experience":0,"prerequisites":[],"costs":[]},
+		{"id":39,"name":"Tavern 1","type":"Unlock","build_time":60,"required_level":1,"experience":100,"prerequisites":[38],
+		"costs":[{"type":"Item","name":"Glob of Ectoplasm","count":30,"item_id":19721},{"type":"Currency","name":"Aetherium","count":100}]}]`,
+	"/colors": `[1]`,
+	"/colors?ids": `[{"id":1,"name":"Dye Remover","base_rgb":[128,26,26],"cloth":{"brightness":15,"contrast":1.25,"hue":38,"saturation":0.28,"lightness":1.44,"rgb":[124,108,83]},
+		"item":20358,"categories":["Red","Vibrant","Common"]}]`,
+	"/minis?ids":               `[{"id":1,"name":"Miniature Rytlock","icon":"","item_id":21047}]`,
+	"/mounts/types?ids":        `[{"id":"raptor","name":"Raptor","default_skin":1,"skins":[1],"skills":[{"id":40576,"slot":"Weapon_1"}]}]`,
+	"/dungeons?ids":            `[{"id":"ascalonian_catacombs","paths":[{"id":"ac_story","type":"Story"}]}]`,
+	"/raids?ids":               `[{"id":"forsaken_thicket","wings":[{"id":"spirit_vale","events":[{"id":"vale_guardian","type":"Boss"}]}]}]`,
+	"/account/raids":           `["vale_guardian"]`,
+	"/dailycrafting":           `["mithrillium"]`,
+	"/mapchests":               `["auric_basin_heros_choice_chest"]`,
+	"/worldbosses":             `["admiral_taidha_covington","shadow_behemoth"]`,
+	"/legendaryarmory?ids":     `[{"id":30704,"max_count":1}]`,
+	"/account/legendaryarmory": `[{"id":30704,"count":1}]`,
+	"/masteries?ids": `[{"id":1,"name":"Exalted Lore","requirement":"","order":1,"region":"Maguuma","levels":[{"name":"a","description":"","instruction":"","point_cost":1,"exp_cost":100},
+		{"name":"b","description":"","instruction":"","point_cost":2,"exp_cost":200},{"name":"c","description":"","instruction":"","point_cost":3,"exp_cost":300},
+		{"name":"d","description":"","instruction":"","point_cost":4,"exp_cost":400}]}]`,
+	"/account/mastery/points":               `{"totals":[{"region":"Maguuma","spent":6,"earned":10}],"unlocked":[]}`,
+	"/wizardsvault":                         `{"title":"Season 1","start":"2026-10-01T00:00:00Z","end":"2026-12-01T00:00:00Z","listings":[1],"objectives":[1]}`,
+	"/continents?ids":                       `[{"id":1,"name":"Tyria","continent_dims":[81920,114688],"min_zoom":0,"max_zoom":7,"floors":[1]}]`,
+	"/continents/2/floors/1":                `{"id":1,"texture_dims":[16384,16384],"regions":{}}`,
+	"/account/dyes":                         `[1]`,
+	"/continents/1/floors/1":                continentFloor,
+	"/characters/Test Character/heropoints": `["0-1"]`,
+	"/characters/Test Character/inventory":  `{"bags":[{"id":8932,"size":20,"inventory":[{"id":19675,"count":2},null]}]}`,
+	"/characters/Test Character/equipment":  `{"equipment":[{"id":30704,"slot":"WeaponA1","skin":4678}]}`,
+	"/account/minis":                        `[1]`,
+	"/account/dailycrafting":                `["mithrillium"]`,
+	"/account/dungeons":                     `["ac_story"]`,
+	"/guild/permissions?ids":                `[{"id":"Admin","name":"Admin","description":"Everything"}]`,
+	"wiki:search":                           `{"query":{"search":[{"title":"Mystic Coin","snippet":"A coin","timestamp":"2026-01-01T00:00:00Z"}],"searchinfo":{"totalhits":1}}}`,
+	"wiki:extracts|revisions":               `{"query":{"pages":{"1":{"title":"Mystic Coin","extract":"A rare coin.","pageid":1,"ns":0}}}}`,
+}
+
+const wizardsVaultProgress = `{"meta_progress_current":1,"meta_progress_complete":4,"meta_reward_item_id":19976,"meta_reward_astral":20,
+	"objectives":[{"id":1,"title":"Kill 5 enemies","track":"PvE","acclaim":10,"progress_current":2,"progress_complete":5,"claimed":false}]}`
+
+const continentFloor = `{"id":1,"texture_dims":[32768,32768],"regions":{"4":{"id":4,"name":"Kryta","label_coord":[0,0],"continent_rect":[[0,0],[1,1]],
+	"maps":{"15":{"id":15,"name":"Queensdale","min_level":1,"max_level":15,"default_floor":1,"label_coord":[0,0],"map_rect":[[0,0],[1,1]],"continent_rect":[[0,0],[1,1]],
+	"points_of_interest":{"1":{"id":1,"name":"Lion's Arch Waypoint","type":"waypoint","floor":1,"coord":[1,1],"chat_link":"[&BAEAAAA=]"}},
+	"tasks":{"2":{"id":2,"objective":"Help the farmers","level":1,"coord":[1,1],"chat_link":"[&BAIAAAA=]"}},
+	"skill_challenges":[{"id":"0-1","coord":[1,1]}],
+	"sectors":{"3":{"id":3,"name":"Shaemoor","level":1,"coord":[1,1],"chat_link":"[&BAMAAAA=]"}}}}}}}`
+
+// toolCalls has a valid call of every tool
+var toolCalls = map[string]map[string]any{
+	"wiki_search":                  {"query": "Mystic Coin"},
+	"get_wallet":                   {},
+	"get_currencies":               {"ids": []int{1}},
+	"get_tp_prices":                {"item_ids": []int{19976}},
+	"get_tp_listings":              {"item_ids": []int{19976}},
+	"get_gem_exchange":             {"direction": "coins", "quantity": 100000},
+	"get_tp_delivery":              {},
+	"get_tp_transactions":          {"type": "current/buys"},
+	"get_account":                  {},
+	"get_bank":                     {},
+	"get_materials":                {},
+	"get_inventory":                {},
+	"get_characters":               {"name": "Test Character"},
+	"get_account_unlocks":          {"type": "skins"},
+	"get_account_progress":         {"type": "masteries"},
+	"get_account_dailies":          {"type": "worldbosses"},
+	"get_wizards_vault":            {},
+	"get_wizards_vault_objectives": {"type": "daily"},
+	"get_wizards_vault_listings":   {},
+	"get_items":                    {"ids": []int{19976}},
+	"get_skins":                    {"ids": []int{4678}},
+	"get_recipes":                  {"ids": []int{7319}},
+	"search_recipes":               {"output": 19721},
+	"get_achievements":             {"ids": []int{2258}},
+	"get_daily_achievements":       {},
+	"get_guild":                    {"id": testGuildID},
+	"search_guild":                 {"name": "Test Guild"},
+	"get_guild_details":            {"id": testGuildID, "type": "members"},
+	"get_colors":                   {"ids": []int{1}},
+	"get_minis":                    {"ids": []int{1}},
+	"get_mounts_info":              {"type": "types"},
+	"get_game_build":               {},
+	"get_token_info":               {},
+	"get_dungeons_and_raids":       {"type": "raids", "ids": []string{"forsaken_thicket"}},
+	"get_item_by_name":             {"name": "Mystic Coin"},
+	"get_item_recipe_by_name":      {"name": "Glob of Ectoplasm"},
+	"get_tp_price_by_name":         {"name": "Mystic Coin"},
+	"legendary_planner":            {"target": "Twilight"},
+	"find_item_on_account":         {"name": "Mystic Clover"},
+	"achievement_progress":         {"category": "Collections"},
+	"collection_status":            {"achievement_id": 2258},
+	"find_location":                {"name": "Lion's Arch"},
+	"map_completion":               {"map": "Queensdale"},
+	"get_upcoming_events":          {"limit": 3},
+	"reset_checklist":              {},
+	"raid_clears":                  {},
+	"get_daily_fractals":           {},
+	"fractal_progress":             {},
+	"mastery_status":               {},
+	"wardrobe_status":              {"type": "Weapon"},
+	"find_dyes":                    {"hex": "#aa0000"},
+	"wizards_vault_plan":           {},
+	"guild_upgrade_plan":           {"id": testGuildID},
+	"guild_activity_report":        {"id": testGuildID},
+	"guild_roster_report":          {"id": testGuildID},
+}
+
+func TestToolResultsMatchOutputSchemas(t *testing.T) {
+	s, api := newFakeAPIServer(t)
+	session := connectTestClient(t, s)
+	ctx := context.Background()
+
+	for name := range s.tools {
+		if _, ok := toolCalls[name]; !ok {
+			t.Errorf("%s has no test call", name)
+		}
+	}
+	for name, args := range toolCalls {
+		res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: name, Arguments: args})
+		if err != nil {
+			t.Errorf("%s: CallTool() error = %v", name, err)
+			continue
+		}
+		if res.IsError {
+			t.Errorf("%s failed: %s", name, res.Content[0].(*mcp.TextContent).Text)
+			continue
+		}
+		if res.StructuredContent == nil {
+			t.Errorf("%s: result does not match its output schema", name)
+			continue
+		}
+		if _, err := s.tools[name].output.structured(res.StructuredContent); err != nil {
+			t.Errorf("%s: structured content does not match its output schema: %v", name, err)
+		}
+	}
+	for _, u := range api.unhandled {
+		t.Errorf("no fake response for %s", u)
+	}
+}
+
+func TestGetCharactersResult(t *testing.T) {
+	s, _ := newFakeAPIServer(t)
+	session := connectTestClient(t, s)
+	ctx := context.Background()
+
+	if props := s.tools["get_characters"].output.schema.Properties; props["characters"] == nil || props["character"] == nil {
+		t.Fatalf("get_characters output schema properties = %v, want characters and character", props)
+	}
+
+	for _, tt := range []struct {
+		args map[string]any
+		want CharactersResult
+	}{
+		{map[string]any{}, CharactersResult{Characters: []string{"Test Character"}}},
+		{map[string]any{"name": "Test Character"}, CharactersResult{Character: &gw2api.CharacterInfo{
+			Name: "Test Character", Race: "Human", Gender: "Female", Profession: "Guardian", Level: 80,
+		}}},
+	} {
+		res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "get_characters", Arguments: tt.args})
+		if err != nil || res.IsError || res.StructuredContent == nil {
+			t.Fatalf("get_characters %v = %+v, %v", tt.args, res, err)
+		}
+		data, err := json.Marshal(res.StructuredContent)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got CharactersResult
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("get_characters %v = %+v, want %+v", tt.args, got, tt.want)
+		}
+	}
+}
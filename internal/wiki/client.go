@@ -4,11 +4,13 @@ package wiki
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -95,6 +97,7 @@ type PageContentResponse struct {
 
 // pageDetails holds the cached extract and infobox for a wiki page
 type pageDetails struct {
+	Missing     bool                `json:"missing,omitempty"`
 	Extract     string              `json:"extract"`
 	Infobox     map[string]string   `json:"infobox,omitempty"`
 	InfoboxType string              `json:"infobox_type,omitempty"`
@@ -285,6 +288,7 @@ func (c *Client) getPageDetails(ctx context.Context, title string) (*pageDetails
 	// Extract the content and parse infobox from wikitext
 	details := &pageDetails{}
 	for _, page := range contentResponse.Query.Pages {
+		details.Missing = page.PageID == 0
 		details.Extract = page.Extract
 		if len(page.Revisions) > 0 {
 			wikitext := page.Revisions[0].Slots.Main.Content
@@ -305,6 +309,38 @@ func (c *Client) getPageDetails(ctx context.Context, title string) (*pageDetails
 	return details, nil
 }
 
+// Page is a wiki page's introduction with its parsed infobox and recipes
+type Page struct {
+	Title       string              `json:"title"`
+	URL         string              `json:"url"`
+	Extract     string              `json:"extract"`
+	Infobox     map[string]string   `json:"infobox,omitempty"`
+	InfoboxType string              `json:"infobox_type,omitempty"`
+	Recipes     []map[string]string `json:"recipes,omitempty"`
+}
+
+// ErrPageNotFound is returned by GetPage for titles without a wiki page
+var ErrPageNotFound = errors.New("wiki page not found")
+
+// GetPage retrieves a wiki page by its exact title
+func (c *Client) GetPage(ctx context.Context, title string) (*Page, error) {
+	details, err := c.getPageDetails(ctx, title)
+	if err != nil {
+		return nil, err
+	}
+	if details.Missing {
+		return nil, ErrPageNotFound
+	}
+	return &Page{
+		Title:       title,
+		URL:         fmt.Sprintf("%s/wiki/%s", wikiBaseURL, url.QueryEscape(title)),
+		Extract:     details.Extract,
+		Infobox:     details.Infobox,
+		InfoboxType: details.InfoboxType,
+		Recipes:     details.Recipes,
+	}, nil
+}
+
 // Compiled regexes for cleanWikiMarkup
 var (
 	rePipedLink  = regexp.MustCompile(`\[\[[^\]|]+\|([^\]]+)\]\]`)
@@ -500,6 +536,131 @@ func parseRecipes(wikitext string) []map[string]string {
 	return recipes
 }
 
+// MysticForgeIngredient is a single ingredient of a Mystic Forge recipe
+type MysticForgeIngredient struct {
+	Name   string `json:"name"`
+	Count  int    `json:"count"`
+	ItemID int    `json:"item_id,omitempty"`
+}
+
+// MysticForgeRecipe is a Mystic Forge recipe parsed from a wiki {{Recipe}} template.
+// Mystic Forge recipes are not part of /v2/recipes, so they have no recipe ID.
+type MysticForgeRecipe struct {
+	Output       string                  `json:"output"`
+	OutputItemID int                     `json:"output_item_id,omitempty"`
+	OutputCount  int                     `json:"output_count"`
+	Ingredients  []MysticForgeIngredient `json:"ingredients"`
+	URL          string                  `json:"url"`
+}
+
+// reLeadingCount matches a quantity prefix such as "250 " or "250x " in an ingredient value
+var reLeadingCount = regexp.MustCompile(`^(\d[\d,]*)\s*[x×]?\s+(.+)$`)
+
+// parseIngredient splits a recipe ingredient value into a name and count. The count is
+// taken from the matching countN field when present, otherwise from a leading quantity
+// in the value itself.
+func parseIngredient(value, countField string) (string, int) {
+	name := strings.TrimSpace(value)
+	if n, err := strconv.Atoi(strings.TrimSpace(countField)); err == nil {
+		return name, n
+	}
+	if m := reLeadingCount.FindStringSubmatch(name); m != nil {
+		if n, err := strconv.Atoi(strings.ReplaceAll(m[1], ",", "")); err == nil {
+			return strings.TrimSpace(m[2]), n
+		}
+	}
+	return name, 1
+}
+
+// isMysticForgeRecipe reports whether a parsed {{Recipe}} template describes a Mystic Forge recipe
+func isMysticForgeRecipe(fields map[string]string) bool {
+	source := strings.ToLower(fields["source"])
+	return strings.Contains(source, "mystic forge") || strings.EqualFold(fields["discipline"], "mystic forge")
+}
+
+// parseMysticForgeRecipes converts the Mystic Forge entries of parsed {{Recipe}} templates.
+// Ingredient item IDs are left unresolved.
+func parseMysticForgeRecipes(title string, recipes []map[string]string) []MysticForgeRecipe {
+	var result []MysticForgeRecipe
+	for _, fields := range recipes {
+		if !isMysticForgeRecipe(fields) {
+			continue
+		}
+
+		recipe := MysticForgeRecipe{
+			Output:      title,
+			OutputCount: 1,
+			URL:         fmt.Sprintf("%s/wiki/%s", wikiBaseURL, url.QueryEscape(title)),
+		}
+		if output := fields["output"]; output != "" {
+			recipe.Output = output
+		}
+		for _, key := range []string{"quantity", "output qty", "output count"} {
+			if n, err := strconv.Atoi(strings.TrimSpace(fields[key])); err == nil && n > 0 {
+				recipe.OutputCount = n
+				break
+			}
+		}
+
+		for i := 1; ; i++ {
+			value, ok := fields[fmt.Sprintf("ingredient%d", i)]
+			if !ok || strings.TrimSpace(value) == "" {
+				break
+			}
+			name, count := parseIngredient(value, fields[fmt.Sprintf("count%d", i)])
+			recipe.Ingredients = append(recipe.Ingredients, MysticForgeIngredient{Name: name, Count: count})
+		}
+
+		if len(recipe.Ingredients) > 0 {
+			result = append(result, recipe)
+		}
+	}
+	return result
+}
+
+// GetMysticForgeRecipes returns the Mystic Forge recipes listed on the wiki page for an item.
+// Output and ingredient item IDs are resolved from the infobox of their own wiki pages;
+// ingredients whose page has no item ID keep an ItemID of 0.
+func (c *Client) GetMysticForgeRecipes(ctx context.Context, title string) ([]MysticForgeRecipe, error) {
+	details, err := c.getPageDetails(ctx, title)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wiki page %q: %w", title, err)
+	}
+
+	recipes := parseMysticForgeRecipes(title, details.Recipes)
+	for i := range recipes {
+		recipes[i].OutputItemID = c.resolveItemID(ctx, recipes[i].Output)
+		for j := range recipes[i].Ingredients {
+			recipes[i].Ingredients[j].ItemID = c.resolveItemID(ctx, recipes[i].Ingredients[j].Name)
+		}
+	}
+	return recipes, nil
+}
+
+// resolveItemID looks up the item ID from the infobox of the wiki page with the given title.
+// It returns 0 when the page has no usable ID.
+func (c *Client) resolveItemID(ctx context.Context, title string) int {
+	details, err := c.getPageDetails(ctx, title)
+	if err != nil {
+		c.logger.Warn("Failed to resolve item ID from wiki", "title", title, "error", err)
+		return 0
+	}
+	return infoboxItemID(details.Infobox)
+}
+
+// infoboxItemID parses the first item ID from an infobox "id" field, which may list several IDs
+func infoboxItemID(infobox map[string]string) int {
+	idStr := infobox["id"]
+	if idx := strings.IndexAny(idStr, ",; "); idx >= 0 {
+		idStr = idStr[:idx]
+	}
+	id, err := strconv.Atoi(strings.TrimSpace(idStr))
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
 // cleanSnippet removes HTML tags and cleans up the snippet text
 func (c *Client) cleanSnippet(snippet string) string {
 	// Remove HTML tags
@@ -556,3 +556,98 @@ Some text after`,
 		})
 	}
 }
+
+func TestParseIngredient(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		countField string
+		wantName   string
+		wantCount  int
+	}{
+		{"count field", "Bolts of Silk", "10", "Bolts of Silk", 10},
+		{"leading quantity", "250 Obsidian Shard", "", "Obsidian Shard", 250},
+		{"leading quantity with separator", "1,000 Mystic Coin", "", "Mystic Coin", 1000},
+		{"leading quantity with x", "77x Mystic Clover", "", "Mystic Clover", 77},
+		{"no quantity", "Gift of Twilight", "", "Gift of Twilight", 1},
+		{"count field keeps numeric name", "18 Slot Silk Bag", "2", "18 Slot Silk Bag", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, count := parseIngredient(tt.value, tt.countField)
+			if name != tt.wantName || count != tt.wantCount {
+				t.Errorf("parseIngredient(%q, %q) = (%q, %d), want (%q, %d)",
+					tt.value, tt.countField, name, count, tt.wantName, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestParseMysticForgeRecipes(t *testing.T) {
+	recipes := parseRecipes(`{{Recipe
+| id = 2221
+| discipline = Tailor
+| ingredient1 = Bolt of Silk
+| count1 = 10
+}}
+{{Recipe
+| source = mystic forge
+| ingredient1 = 1 Gift of Twilight
+| ingredient2 = 1 Gift of Mastery
+| ingredient3 = 1 Gift of Fortune
+| ingredient4 = 1 Dusk
+}}
+{{Recipe
+| source = Mystic Forge
+| output = Mystic Clover
+| quantity = 3
+| ingredient1 = 3 Obsidian Shard
+| ingredient2 = 3 Mystic Coin
+| ingredient3 = 3 Glob of Ectoplasm
+| ingredient4 = 2 Philosopher's Stone
+}}`)
+
+	result := parseMysticForgeRecipes("Twilight", recipes)
+	if len(result) != 2 {
+		t.Fatalf("got %d Mystic Forge recipes, want 2", len(result))
+	}
+
+	twilight := result[0]
+	if twilight.Output != "Twilight" || twilight.OutputCount != 1 {
+		t.Errorf("first recipe output = %q x%d, want Twilight x1", twilight.Output, twilight.OutputCount)
+	}
+	if len(twilight.Ingredients) != 4 || twilight.Ingredients[3].Name != "Dusk" {
+		t.Errorf("first recipe ingredients = %+v", twilight.Ingredients)
+	}
+	if !strings.HasPrefix(twilight.URL, wikiBaseURL+"/wiki/") {
+		t.Errorf("unexpected URL %q", twilight.URL)
+	}
+
+	clover := result[1]
+	if clover.Output != "Mystic Clover" || clover.OutputCount != 3 {
+		t.Errorf("second recipe output = %q x%d, want Mystic Clover x3", clover.Output, clover.OutputCount)
+	}
+	if got := clover.Ingredients[3]; got.Name != "Philosopher's Stone" || got.Count != 2 {
+		t.Errorf("fourth ingredient = %+v, want 2 Philosopher's Stone", got)
+	}
+}
+
+func TestInfoboxItemID(t *testing.T) {
+	tests := []struct {
+		infobox map[string]string
+		want    int
+	}{
+		{map[string]string{"id": "19675"}, 19675},
+		{map[string]string{"id": "30704, 30705"}, 30704},
+		{map[string]string{"id": "abc"}, 0},
+		{map[string]string{"name": "Twilight"}, 0},
+		{nil, 0},
+	}
+
+	for _, tt := range tests {
+		if got := infoboxItemID(tt.infobox); got != tt.want {
+			t.Errorf("infoboxItemID(%v) = %d, want %d", tt.infobox, got, tt.want)
+		}
+	}
+}
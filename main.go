@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 
 	"github.com/charmbracelet/log"
@@ -48,7 +49,7 @@ func main() {
 	}
 
 	// Create and start the MCP server
-	mcpServer, err := server.NewMCPServer(logger, apiKey)
+	mcpServer, err := server.NewMCPServer(logger, apiKey, dataDir(logger))
 	if err != nil {
 		logger.Fatal("Failed to create MCP server", "error", err)
 	}
@@ -60,3 +61,18 @@ func main() {
 
 	fmt.Println("Server stopped")
 }
+
+// dataDir returns the directory to save data such as the item index to: GW2_MCP_DATA_DIR
+// if set, otherwise gw2-mcp in the user cache directory. Without either, data is only
+// kept in memory.
+func dataDir(logger *log.Logger) string {
+	if dir := os.Getenv("GW2_MCP_DATA_DIR"); dir != "" {
+		return dir
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		logger.Warn("No user cache directory; data will not be saved to disk", "error", err)
+		return ""
+	}
+	return filepath.Join(dir, "gw2-mcp")
+}